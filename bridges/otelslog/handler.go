@@ -41,6 +41,9 @@
 //     transforms for each group value.
 //   - [slog.KindLogValuer] the value is resolved and then transformed.
 //
+// The Attr can be renamed, replaced, or dropped before they are transformed
+// with [WithReplaceAttr].
+//
 // [OpenTelemetry]: https://opentelemetry.io/docs/concepts/signals/logs/
 package otelslog // import "go.opentelemetry.io/contrib/bridges/otelslog"
 
@@ -65,8 +68,9 @@ func NewLogger(options ...Option) *slog.Logger {
 }
 
 type config struct {
-	provider log.LoggerProvider
-	scope    instrumentation.Scope
+	provider    log.LoggerProvider
+	scope       instrumentation.Scope
+	replaceAttr func(groups []string, a slog.Attr) slog.Attr
 }
 
 func newConfig(options []Option) config {
@@ -136,15 +140,35 @@ func WithLoggerProvider(provider log.LoggerProvider) Option {
 	})
 }
 
+// WithReplaceAttr returns an [Option] that configures a [Handler] to rewrite
+// each non-group Attr with replace before it is transformed, like the
+// ReplaceAttr of [slog.HandlerOptions].
+//
+// The groups argument is the list of the groups the Attr is in, from the
+// outermost: the groups of [Handler.WithGroup] followed by the keys of the
+// group Attr holding it. It must not be retained or modified by replace. The
+// value of the Attr is resolved before replace is called. If replace returns
+// an Attr with an empty key, the Attr is dropped, unless it is a group whose
+// Attrs are then inlined. Groups left without any Attr are dropped.
+//
+// The Attr added with [Handler.WithAttrs] are rewritten when they are added.
+func WithReplaceAttr(replace func(groups []string, a slog.Attr) slog.Attr) Option {
+	return optFunc(func(c config) config {
+		c.replaceAttr = replace
+		return c
+	})
+}
+
 // Handler is an [slog.Handler] that sends all logging records it receives to
 // OpenTelemetry. See package documentation for how conversions are made.
 type Handler struct {
 	// Ensure forward compatibility by explicitly making this not comparable.
 	noCmp [0]func() //nolint: unused  // This is indeed used.
 
-	attrs  *kvBuffer
-	group  *group
-	logger log.Logger
+	attrs       *kvBuffer
+	group       *group
+	logger      log.Logger
+	replaceAttr func(groups []string, a slog.Attr) slog.Attr
 }
 
 // Compile-time check *Handler implements slog.Handler.
@@ -161,10 +185,15 @@ var _ slog.Handler = (*Handler)(nil)
 // will instrument.
 func NewHandler(options ...Option) *Handler {
 	cfg := newConfig(options)
-	return &Handler{logger: cfg.logger()}
+	return &Handler{logger: cfg.logger(), replaceAttr: cfg.replaceAttr}
 }
 
 // Handle handles the passed record.
+//
+// The record is emitted with ctx. This allows the span context of an active
+// span in ctx, as when logging with [slog.Logger.InfoContext], to be
+// associated with the emitted record by the [log.Logger] implementation
+// (e.g. the OpenTelemetry SDK sets the trace and span IDs of the record).
 func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
 	h.logger.Emit(ctx, h.convertRecord(record))
 	return nil
@@ -183,29 +212,95 @@ func (h *Handler) convertRecord(r slog.Record) log.Record {
 	}
 
 	n := r.NumAttrs()
+	addAttr := h.attrAdder(r.Attrs)
 	if h.group != nil {
+		buf, free := getKVBuffer()
+		defer free()
 		if n > 0 {
-			buf, free := getKVBuffer()
-			defer free()
-			r.Attrs(buf.AddAttr)
+			addAttr(buf)
+		}
+		if buf.Len() > 0 {
 			record.AddAttributes(h.group.KeyValue(buf.KeyValues()...))
 		} else {
-			// A Handler should not output groups if there are no attributes.
+			// A Handler should not output groups if there are no attributes,
+			// including when all of them are dropped by replaceAttr.
 			g := h.group.NextNonEmpty()
 			if g != nil {
 				record.AddAttributes(g.KeyValue())
 			}
 		}
 	} else if n > 0 {
-		buf, free := getKVBuffer()
+		// AddAttributes copies the top-level attributes into the record so
+		// the backing array of buf can be reused once the record is built.
+		buf, free := getReusableKVBuffer()
 		defer free()
-		r.Attrs(buf.AddAttr)
+		addAttr(buf)
 		record.AddAttributes(buf.KeyValues()...)
 	}
 
 	return record
 }
 
+// attrAdder returns a function adding the Attr walked by attrs to a buffer,
+// rewritten with the replaceAttr of h if any.
+func (h *Handler) attrAdder(attrs func(func(slog.Attr) bool)) func(*kvBuffer) {
+	if h.replaceAttr == nil {
+		return func(buf *kvBuffer) { attrs(buf.AddAttr) }
+	}
+	groups := h.group.Names()
+	return func(buf *kvBuffer) {
+		attrs(func(a slog.Attr) bool {
+			if a, ok := h.replace(groups, a); ok {
+				_ = buf.AddAttr(a)
+			}
+			return true
+		})
+	}
+}
+
+// replaceAttrs returns attrs rewritten with the replaceAttr of h, attrs if h
+// has none.
+func (h *Handler) replaceAttrs(attrs []slog.Attr) []slog.Attr {
+	if h.replaceAttr == nil {
+		return attrs
+	}
+	groups := h.group.Names()
+	out := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a, ok := h.replace(groups, a); ok {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// replace returns a rewritten with the replaceAttr of h, and false if it is
+// dropped. The Attr of a group are rewritten with the key of the group
+// appended to groups.
+func (h *Handler) replace(groups []string, a slog.Attr) (slog.Attr, bool) {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		a = h.replaceAttr(groups, a)
+		return a, a.Key != "" || (a.Value.Kind() == slog.KindGroup && len(a.Value.Group()) > 0)
+	}
+
+	if a.Key != "" {
+		groups = append(groups[:len(groups):len(groups)], a.Key)
+	}
+	attrs := a.Value.Group()
+	out := make([]slog.Attr, 0, len(attrs))
+	for _, ga := range attrs {
+		if ga, ok := h.replace(groups, ga); ok {
+			out = append(out, ga)
+		}
+	}
+	if len(out) == 0 {
+		// A Handler should not output groups if there are no attributes.
+		return slog.Attr{}, false
+	}
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(out...)}, true
+}
+
 // Enable returns true if the Handler is enabled to log for the provided
 // context and Level. Otherwise, false is returned if it is not enabled.
 func (h *Handler) Enabled(ctx context.Context, l slog.Level) bool {
@@ -218,6 +313,7 @@ func (h *Handler) Enabled(ctx context.Context, l slog.Level) bool {
 // WithAttrs returns a new [slog.Handler] based on h that will log using the
 // passed attrs.
 func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	attrs = h.replaceAttrs(attrs)
 	h2 := *h
 	if h2.group != nil {
 		h2.group = h2.group.Clone()
@@ -302,6 +398,17 @@ func (g *group) NextNonEmpty() *group {
 	return g.next.NextNonEmpty()
 }
 
+// Names returns the names of the groups of g's linked-list, from the
+// outermost group to g.
+func (g *group) Names() []string {
+	var names []string
+	for ; g != nil; g = g.next {
+		names = append(names, g.name)
+	}
+	slices.Reverse(names)
+	return names
+}
+
 // KeyValue returns group g containing kvs as a [log.KeyValue]. The value of
 // the returned KeyValue will be of type [log.KindMap].
 //
@@ -364,6 +471,35 @@ func getKVBuffer() (buf *kvBuffer, free func()) {
 	}
 }
 
+// maxReusableKVBufferCap is the largest capacity of a backing array that will
+// be retained by reusableKVBufferPool. Larger arrays are dropped so the pool
+// does not hold on to the memory of rare, large records.
+const maxReusableKVBufferCap = 64
+
+var reusableKVBufferPool = sync.Pool{
+	New: func() any { return newKVBuffer(5) },
+}
+
+// getReusableKVBuffer returns a buffer from a pool that retains the backing
+// array of the buffer between uses.
+//
+// Unlike getKVBuffer, the data held by buf is overwritten by subsequent users
+// after free is called. The caller needs to ensure the data is copied, and
+// not referenced, before free is called. This means the data cannot be used
+// as the value of a [log.MapValue] or [log.SliceValue].
+func getReusableKVBuffer() (buf *kvBuffer, free func()) {
+	buf = reusableKVBufferPool.Get().(*kvBuffer)
+	return buf, func() {
+		if cap(buf.data) > maxReusableKVBufferCap {
+			buf.data = buf.data[:0:0]
+		} else {
+			clear(buf.data)
+			buf.data = buf.data[:0]
+		}
+		reusableKVBufferPool.Put(buf)
+	}
+}
+
 type kvBuffer struct {
 	data []log.KeyValue
 }
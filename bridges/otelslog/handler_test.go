@@ -13,6 +13,8 @@ import (
 	"log/slog"
 	"reflect"
 	"runtime"
+	"slices"
+	"sync"
 	"testing"
 	"testing/slogtest"
 	"time"
@@ -24,6 +26,7 @@ import (
 	"go.opentelemetry.io/otel/log/embedded"
 	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var now = time.Now()
@@ -468,6 +471,178 @@ func TestHandlerEnabled(t *testing.T) {
 	assert.True(t, h.Enabled(ctx, slog.LevelDebug), "context not passed")
 }
 
+// ctxRecorder is a recorder that also records the context each record is
+// emitted with.
+type ctxRecorder struct {
+	recorder
+
+	Contexts []context.Context
+}
+
+func (r *ctxRecorder) Logger(string, ...log.LoggerOption) log.Logger { return r }
+
+func (r *ctxRecorder) Emit(ctx context.Context, record log.Record) {
+	r.Contexts = append(r.Contexts, ctx)
+	r.recorder.Emit(ctx, record)
+}
+
+func TestHandlerSpanContext(t *testing.T) {
+	r := new(ctxRecorder)
+	logger := NewLogger(WithLoggerProvider(r))
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	logger.InfoContext(ctx, "in span")
+	logger.Info("no span")
+
+	require.Len(t, r.Contexts, 2)
+	got := trace.SpanContextFromContext(r.Contexts[0])
+	assert.Equal(t, sc.TraceID(), got.TraceID(), "trace ID")
+	assert.Equal(t, sc.SpanID(), got.SpanID(), "span ID")
+	assert.False(t, trace.SpanContextFromContext(r.Contexts[1]).IsValid(), "span context without active span")
+}
+
+// syncRecorder is a recorder that is safe to use concurrently.
+type syncRecorder struct {
+	recorder
+
+	mu sync.Mutex
+}
+
+func (r *syncRecorder) Logger(string, ...log.LoggerOption) log.Logger { return r }
+
+func (r *syncRecorder) Emit(ctx context.Context, record log.Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recorder.Emit(ctx, record)
+}
+
+func TestHandlerReplaceAttr(t *testing.T) {
+	var groupPaths [][]string
+	replace := func(groups []string, a slog.Attr) slog.Attr {
+		groupPaths = append(groupPaths, slices.Clone(groups))
+		switch a.Key {
+		case "password":
+			return slog.String(a.Key, "REDACTED")
+		case "usr":
+			return slog.Attr{Key: "user", Value: a.Value}
+		case "debug":
+			return slog.Attr{}
+		}
+		return a
+	}
+
+	t.Run("Record", func(t *testing.T) {
+		groupPaths = nil
+		r := new(recorder)
+		logger := NewLogger(WithLoggerProvider(r), WithReplaceAttr(replace))
+		logger.Info("msg", "usr", "alice", "password", "secret", "debug", true)
+
+		want := map[string]any{"user": "alice", "password": "REDACTED"}
+		require.Len(t, r.Records, 1)
+		assert.Equal(t, want, attrsMap(r.Records[0]))
+		assert.Equal(t, [][]string{nil, nil, nil}, groupPaths)
+	})
+
+	t.Run("Groups", func(t *testing.T) {
+		groupPaths = nil
+		r := new(recorder)
+		logger := NewLogger(WithLoggerProvider(r), WithReplaceAttr(replace))
+		logger.WithGroup("G").Info("msg",
+			slog.Group("H", "password", "secret", "usr", "bob"),
+			slog.Group("I", "debug", true),
+		)
+
+		want := map[string]any{"G": map[string]any{
+			"H": map[string]any{"password": "REDACTED", "user": "bob"},
+		}}
+		require.Len(t, r.Records, 1)
+		assert.Equal(t, want, attrsMap(r.Records[0]))
+		assert.Equal(t, [][]string{{"G", "H"}, {"G", "H"}, {"G", "I"}}, groupPaths)
+	})
+
+	t.Run("WithAttrs", func(t *testing.T) {
+		groupPaths = nil
+		r := new(recorder)
+		logger := NewLogger(WithLoggerProvider(r), WithReplaceAttr(replace))
+		logger.With("password", "secret").WithGroup("G").With("usr", "carol").Info("msg")
+
+		want := map[string]any{
+			"password": "REDACTED",
+			"G":        map[string]any{"user": "carol"},
+		}
+		require.Len(t, r.Records, 1)
+		assert.Equal(t, want, attrsMap(r.Records[0]))
+		assert.Equal(t, [][]string{nil, {"G"}}, groupPaths)
+	})
+
+	t.Run("DroppedGroup", func(t *testing.T) {
+		r := new(recorder)
+		logger := NewLogger(WithLoggerProvider(r), WithReplaceAttr(replace))
+		logger.WithGroup("G").Info("msg", "debug", true)
+
+		require.Len(t, r.Records, 1)
+		assert.Empty(t, attrsMap(r.Records[0]))
+	})
+}
+
+// attrsMap returns the attributes of r as a map, using the same
+// representation as the recorder results.
+func attrsMap(r log.Record) map[string]any {
+	m := make(map[string]any)
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		m[kv.Key] = value2Result(kv.Value)
+		return true
+	})
+	return m
+}
+
+func TestHandlerConcurrentHandle(t *testing.T) {
+	r := new(syncRecorder)
+	logger := NewLogger(WithLoggerProvider(r))
+
+	const goroutines, logs = 8, 100
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			for i := 0; i < logs; i++ {
+				logger.Info(
+					"msg",
+					"a", id, "b", id, "c", id, "d", id,
+					"e", id, "f", id, "g", id,
+					slog.Group("group", "h", id),
+				)
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+
+	require.Len(t, r.Records, goroutines*logs)
+	for _, rec := range r.Records {
+		var want int64 = -1
+		rec.WalkAttributes(func(kv log.KeyValue) bool {
+			v := kv.Value
+			if v.Kind() == log.KindMap {
+				require.Len(t, v.AsMap(), 1)
+				v = v.AsMap()[0].Value
+			}
+			if want < 0 {
+				want = v.AsInt64()
+			}
+			assert.Equal(t, want, v.AsInt64(), "attribute %q", kv.Key)
+			return true
+		})
+		assert.Equal(t, 8, rec.AttributesLen())
+	}
+}
+
 func BenchmarkHandler(b *testing.B) {
 	var (
 		h   slog.Handler
@@ -503,6 +678,31 @@ func BenchmarkHandler(b *testing.B) {
 		}
 	})
 
+	b.Run("HandleAttrs", func(b *testing.B) {
+		b.Run("5", func(b *testing.B) {
+			r := record.Clone()
+			r.AddAttrs(attrs5...)
+			h := NewHandler()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				err = h.Handle(ctx, r)
+			}
+		})
+		b.Run("10", func(b *testing.B) {
+			r := record.Clone()
+			r.AddAttrs(attrs10...)
+			h := NewHandler()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				err = h.Handle(ctx, r)
+			}
+		})
+	})
+
 	b.Run("WithAttrs", func(b *testing.B) {
 		b.Run("5", func(b *testing.B) {
 			handlers := make([]*Handler, b.N)
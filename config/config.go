@@ -7,7 +7,13 @@ import (
 	"context"
 	"errors"
 
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -22,6 +28,8 @@ const (
 type configOptions struct {
 	ctx                 context.Context
 	opentelemetryConfig OpenTelemetryConfiguration
+	dryRun              bool
+	resource            *resource.Resource
 }
 
 type shutdownFunc func(context.Context) error
@@ -35,6 +43,9 @@ func noopShutdown(context.Context) error {
 type SDK struct {
 	meterProvider  metric.MeterProvider
 	tracerProvider trace.TracerProvider
+	loggerProvider log.LoggerProvider
+	propagator     propagation.TextMapPropagator
+	description    Description
 	shutdown       shutdownFunc
 }
 
@@ -48,6 +59,23 @@ func (s *SDK) MeterProvider() metric.MeterProvider {
 	return s.meterProvider
 }
 
+// LoggerProvider returns a configured log.LoggerProvider.
+func (s *SDK) LoggerProvider() log.LoggerProvider {
+	return s.loggerProvider
+}
+
+// Propagator returns the configured propagation.TextMapPropagator, nil if
+// none is configured.
+func (s *SDK) Propagator() propagation.TextMapPropagator {
+	return s.propagator
+}
+
+// Description returns the description of the configured providers, their
+// processors and exporters.
+func (s *SDK) Description() Description {
+	return s.description
+}
+
 // Shutdown calls shutdown on all configured providers.
 func (s *SDK) Shutdown(ctx context.Context) error {
 	return s.shutdown(ctx)
@@ -55,14 +83,32 @@ func (s *SDK) Shutdown(ctx context.Context) error {
 
 // NewSDK creates SDK providers based on the configuration model.
 //
-// Caution: The implementation only returns noop providers.
+// If the configuration has a propagator, it is also set as the global
+// TextMapPropagator, unless WithDryRun is used.
+//
+// Noop providers are returned for all the signals if the configuration is
+// disabled or if the OTEL_SDK_DISABLED environment variable is "true", and
+// for a single signal if its OTEL_TRACES_ENABLED, OTEL_METRICS_ENABLED, or
+// OTEL_LOGS_ENABLED environment variable is "false". The configuration of the
+// disabled providers is then neither validated nor described.
 func NewSDK(opts ...ConfigurationOption) (SDK, error) {
-	o := configOptions{}
+	o := configOptions{
+		ctx: context.Background(),
+	}
 	for _, opt := range opts {
 		o = opt.apply(o)
 	}
+	if o.dryRun {
+		o.ctx = contextWithDryRun(o.ctx)
+	}
+	o.opentelemetryConfig = withoutDisabledSignals(o.opentelemetryConfig)
 
-	r, err := newResource(o.opentelemetryConfig.Resource)
+	r, err := newResource(o.ctx, o.opentelemetryConfig.Resource, o.resource)
+	if err != nil {
+		return SDK{}, err
+	}
+
+	prop, err := propagator(o.opentelemetryConfig.Propagator)
 	if err != nil {
 		return SDK{}, err
 	}
@@ -77,12 +123,23 @@ func NewSDK(opts ...ConfigurationOption) (SDK, error) {
 		return SDK{}, err
 	}
 
+	lp, lpShutdown, err := loggerProvider(o, r)
+	if err != nil {
+		return SDK{}, err
+	}
+
+	if prop != nil && !o.dryRun {
+		otel.SetTextMapPropagator(prop)
+	}
+
 	return SDK{
 		meterProvider:  mp,
 		tracerProvider: tp,
+		loggerProvider: lp,
+		propagator:     prop,
+		description:    describe(o.opentelemetryConfig),
 		shutdown: func(ctx context.Context) error {
-			err := mpShutdown(ctx)
-			return errors.Join(err, tpShutdown(ctx))
+			return errors.Join(mpShutdown(ctx), tpShutdown(ctx), lpShutdown(ctx))
 		},
 	}, nil
 }
@@ -106,6 +163,35 @@ func WithContext(ctx context.Context) ConfigurationOption {
 	})
 }
 
+// WithDryRun configures NewSDK to validate the configuration and create the
+// providers without starting the exporters. The OTLP, Prometheus, and custom
+// exporters are replaced with stubs dropping the telemetry, and the remote
+// samplers with their initial sampler, so that no connection is opened and no
+// port is listened on. This can be used to validate a configuration, for
+// example in CI, with the description of the providers returned by
+// SDK.Description.
+func WithDryRun() ConfigurationOption {
+	return configurationOptionFunc(func(c configOptions) configOptions {
+		c.dryRun = true
+		return c
+	})
+}
+
+// WithResource sets a resource merged with the resource of the
+// configuration, for example with the attributes of resource detectors run by
+// the application. The attributes of res take precedence over the default and
+// detected attributes of the configuration, and the attributes declared in
+// the configuration take precedence over the ones of res.
+//
+// NewSDK returns an error if the schema URL of res conflicts with the one of
+// the resource of the configuration.
+func WithResource(res *resource.Resource) ConfigurationOption {
+	return configurationOptionFunc(func(c configOptions) configOptions {
+		c.resource = res
+		return c
+	})
+}
+
 // WithOpenTelemetryConfiguration sets the OpenTelemetryConfiguration used
 // to produce the SDK.
 func WithOpenTelemetryConfiguration(cfg OpenTelemetryConfiguration) ConfigurationOption {
@@ -115,8 +201,34 @@ func WithOpenTelemetryConfiguration(cfg OpenTelemetryConfiguration) Configuratio
 	})
 }
 
+// ParseYAML parses a YAML configuration file into an OpenTelemetryConfiguration.
+//
+// The environment variable references in the values of the file are
+// replaced before it is parsed: ${VAR} is replaced by the value of VAR,
+// ${VAR:-default} by default if VAR is not set or empty, and ${VAR:?message}
+// returns an error with message if VAR is not set or empty. Use $$ for a
+// literal $.
+func ParseYAML(file []byte) (*OpenTelemetryConfiguration, error) {
+	var node yaml.Node
+	err := yaml.Unmarshal(file, &node)
+	if err != nil {
+		return nil, err
+	}
+	err = expandEnvNode(&node)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg OpenTelemetryConfiguration
+	err = node.Decode(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
 // TODO: implement parsing functionality:
-// - https://github.com/open-telemetry/opentelemetry-go-contrib/issues/4373
 // - https://github.com/open-telemetry/opentelemetry-go-contrib/issues/4412
 
 // TODO: create SDK from the model:
@@ -10,7 +10,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	lognoop "go.opentelemetry.io/otel/log/noop"
 	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	tracenoop "go.opentelemetry.io/otel/trace/noop"
@@ -22,6 +24,7 @@ func TestNewSDK(t *testing.T) {
 		cfg                []ConfigurationOption
 		wantTracerProvider any
 		wantMeterProvider  any
+		wantLoggerProvider any
 		wantErr            error
 		wantShutdownErr    error
 	}{
@@ -29,18 +32,25 @@ func TestNewSDK(t *testing.T) {
 			name:               "no-configuration",
 			wantTracerProvider: tracenoop.NewTracerProvider(),
 			wantMeterProvider:  metricnoop.NewMeterProvider(),
+			wantLoggerProvider: lognoop.NewLoggerProvider(),
 		},
 		{
 			name: "with-configuration",
 			cfg: []ConfigurationOption{
 				WithContext(context.Background()),
 				WithOpenTelemetryConfiguration(OpenTelemetryConfiguration{
-					TracerProvider: &TracerProvider{},
+					TracerProvider: &TracerProvider{
+						Processors: []SpanProcessor{
+							{Simple: &SimpleSpanProcessor{Exporter: SpanExporter{Console: Console{}}}},
+						},
+					},
 					MeterProvider:  &MeterProvider{},
+					LoggerProvider: &LoggerProvider{},
 				}),
 			},
 			wantTracerProvider: &sdktrace.TracerProvider{},
 			wantMeterProvider:  &sdkmetric.MeterProvider{},
+			wantLoggerProvider: &sdklog.LoggerProvider{},
 		},
 	}
 	for _, tt := range tests {
@@ -48,10 +58,49 @@ func TestNewSDK(t *testing.T) {
 		require.Equal(t, tt.wantErr, err)
 		assert.IsType(t, tt.wantTracerProvider, sdk.TracerProvider())
 		assert.IsType(t, tt.wantMeterProvider, sdk.MeterProvider())
+		assert.IsType(t, tt.wantLoggerProvider, sdk.LoggerProvider())
 		require.Equal(t, tt.wantShutdownErr, sdk.Shutdown(context.Background()))
 	}
 }
 
+func TestParseYAML(t *testing.T) {
+	cfg, err := ParseYAML([]byte(`
+file_format: "0.2"
+disabled: false
+tracer_provider:
+  processors:
+    - batch:
+        max_queue_size: 100
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: http://localhost:4318
+`))
+	require.NoError(t, err)
+	assert.Equal(t, &OpenTelemetryConfiguration{
+		FileFormat: "0.2",
+		Disabled:   ptr(false),
+		TracerProvider: &TracerProvider{
+			Processors: []SpanProcessor{
+				{
+					Batch: &BatchSpanProcessor{
+						MaxQueueSize: ptr(100),
+						Exporter: SpanExporter{
+							OTLP: &OTLP{
+								Protocol: "http/protobuf",
+								Endpoint: "http://localhost:4318",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, cfg)
+
+	_, err = ParseYAML([]byte("file_format: [invalid"))
+	assert.Error(t, err)
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
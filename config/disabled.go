@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config // import "go.opentelemetry.io/contrib/config"
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	envSDKDisabled    = "OTEL_SDK_DISABLED"
+	envTracesEnabled  = "OTEL_TRACES_ENABLED"
+	envMetricsEnabled = "OTEL_METRICS_ENABLED"
+	envLogsEnabled    = "OTEL_LOGS_ENABLED"
+)
+
+// withoutDisabledSignals returns a copy of cfg without the providers of the
+// disabled signals, for which noop providers are then created.
+//
+// All the signals are disabled if cfg is disabled or if OTEL_SDK_DISABLED is
+// "true". A single signal is disabled if its OTEL_<SIGNAL>_ENABLED
+// environment variable, e.g. OTEL_TRACES_ENABLED, is "false". Both values
+// are case-insensitive, any other value is ignored.
+func withoutDisabledSignals(cfg OpenTelemetryConfiguration) OpenTelemetryConfiguration {
+	if (cfg.Disabled != nil && *cfg.Disabled) || envEquals(envSDKDisabled, "true") {
+		cfg.TracerProvider = nil
+		cfg.MeterProvider = nil
+		cfg.LoggerProvider = nil
+		return cfg
+	}
+	if envEquals(envTracesEnabled, "false") {
+		cfg.TracerProvider = nil
+	}
+	if envEquals(envMetricsEnabled, "false") {
+		cfg.MeterProvider = nil
+	}
+	if envEquals(envLogsEnabled, "false") {
+		cfg.LoggerProvider = nil
+	}
+	return cfg
+}
+
+// envEquals returns true if the environment variable key is set to value,
+// ignoring case and surrounding white spaces.
+func envEquals(key, value string) bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv(key)), value)
+}
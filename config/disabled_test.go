@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestNewSDKDisabledSignals(t *testing.T) {
+	disabled := true
+	enabled := false
+	tests := []struct {
+		name               string
+		env                map[string]string
+		disabled           *bool
+		wantTracerProvider any
+		wantMeterProvider  any
+		wantLoggerProvider any
+	}{
+		{
+			name:               "enabled",
+			wantTracerProvider: &sdktrace.TracerProvider{},
+			wantMeterProvider:  &sdkmetric.MeterProvider{},
+			wantLoggerProvider: &sdklog.LoggerProvider{},
+		},
+		{
+			name:               "configuration-enabled",
+			disabled:           &enabled,
+			wantTracerProvider: &sdktrace.TracerProvider{},
+			wantMeterProvider:  &sdkmetric.MeterProvider{},
+			wantLoggerProvider: &sdklog.LoggerProvider{},
+		},
+		{
+			name:               "configuration-disabled",
+			disabled:           &disabled,
+			wantTracerProvider: tracenoop.NewTracerProvider(),
+			wantMeterProvider:  metricnoop.NewMeterProvider(),
+			wantLoggerProvider: lognoop.NewLoggerProvider(),
+		},
+		{
+			name:               "sdk-disabled",
+			env:                map[string]string{envSDKDisabled: "TRUE"},
+			wantTracerProvider: tracenoop.NewTracerProvider(),
+			wantMeterProvider:  metricnoop.NewMeterProvider(),
+			wantLoggerProvider: lognoop.NewLoggerProvider(),
+		},
+		{
+			name:               "sdk-not-disabled",
+			env:                map[string]string{envSDKDisabled: "false"},
+			wantTracerProvider: &sdktrace.TracerProvider{},
+			wantMeterProvider:  &sdkmetric.MeterProvider{},
+			wantLoggerProvider: &sdklog.LoggerProvider{},
+		},
+		{
+			name:               "traces-disabled",
+			env:                map[string]string{envTracesEnabled: "false"},
+			wantTracerProvider: tracenoop.NewTracerProvider(),
+			wantMeterProvider:  &sdkmetric.MeterProvider{},
+			wantLoggerProvider: &sdklog.LoggerProvider{},
+		},
+		{
+			name:               "metrics-disabled",
+			env:                map[string]string{envMetricsEnabled: "False"},
+			wantTracerProvider: &sdktrace.TracerProvider{},
+			wantMeterProvider:  metricnoop.NewMeterProvider(),
+			wantLoggerProvider: &sdklog.LoggerProvider{},
+		},
+		{
+			name:               "logs-disabled",
+			env:                map[string]string{envLogsEnabled: " false "},
+			wantTracerProvider: &sdktrace.TracerProvider{},
+			wantMeterProvider:  &sdkmetric.MeterProvider{},
+			wantLoggerProvider: lognoop.NewLoggerProvider(),
+		},
+		{
+			name:               "invalid-value",
+			env:                map[string]string{envTracesEnabled: "no"},
+			wantTracerProvider: &sdktrace.TracerProvider{},
+			wantMeterProvider:  &sdkmetric.MeterProvider{},
+			wantLoggerProvider: &sdklog.LoggerProvider{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			sdk, err := NewSDK(WithOpenTelemetryConfiguration(OpenTelemetryConfiguration{
+				Disabled: tt.disabled,
+				TracerProvider: &TracerProvider{
+					Processors: []SpanProcessor{
+						{Simple: &SimpleSpanProcessor{Exporter: SpanExporter{Console: Console{}}}},
+					},
+				},
+				MeterProvider:  &MeterProvider{},
+				LoggerProvider: &LoggerProvider{},
+			}))
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantTracerProvider, sdk.TracerProvider())
+			assert.IsType(t, tt.wantMeterProvider, sdk.MeterProvider())
+			assert.IsType(t, tt.wantLoggerProvider, sdk.LoggerProvider())
+			require.NoError(t, sdk.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestNewSDKDisabledSignalNotValidated(t *testing.T) {
+	t.Setenv(envTracesEnabled, "false")
+	sdk, err := NewSDK(WithOpenTelemetryConfiguration(OpenTelemetryConfiguration{
+		TracerProvider: &TracerProvider{
+			Processors: []SpanProcessor{{}},
+		},
+	}))
+	require.NoError(t, err)
+	assert.IsType(t, tracenoop.NewTracerProvider(), sdk.TracerProvider())
+	assert.Nil(t, sdk.Description().TracerProvider)
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config // import "go.opentelemetry.io/contrib/config"
+
+import (
+	"context"
+	"fmt"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Description describes the providers configured by the configuration model.
+type Description struct {
+	// TracerProvider is nil if no tracer provider is configured.
+	TracerProvider *ProviderDescription
+	// MeterProvider is nil if no meter provider is configured.
+	MeterProvider *ProviderDescription
+	// LoggerProvider is nil if no logger provider is configured.
+	LoggerProvider *ProviderDescription
+}
+
+// ProviderDescription describes the processors of a provider, in the order
+// they are configured.
+type ProviderDescription struct {
+	// Processors are the span or log record processors, or the metric
+	// readers, of the provider.
+	Processors []ProcessorDescription
+}
+
+// ProcessorDescription describes a processor, or a metric reader, and its
+// exporter.
+type ProcessorDescription struct {
+	// Type is "batch" or "simple" for processors, and "periodic" or "pull"
+	// for metric readers.
+	Type     string
+	Exporter ExporterDescription
+}
+
+// ExporterDescription describes an exporter.
+type ExporterDescription struct {
+	// Type is "console", "otlp", "prometheus", or the name of a custom
+	// exporter.
+	Type string
+	// Protocol is the protocol of OTLP exporters.
+	Protocol string
+	// Endpoint is the endpoint of OTLP exporters, or the address the
+	// Prometheus exporter listens on.
+	Endpoint string
+}
+
+// describe returns the description of the providers configured by cfg.
+func describe(cfg OpenTelemetryConfiguration) Description {
+	var d Description
+	if tp := cfg.TracerProvider; tp != nil {
+		d.TracerProvider = &ProviderDescription{}
+		for _, p := range tp.Processors {
+			switch {
+			case p.Batch != nil:
+				d.TracerProvider.add("batch", describeSpanExporter(p.Batch.Exporter))
+			case p.Simple != nil:
+				d.TracerProvider.add("simple", describeSpanExporter(p.Simple.Exporter))
+			}
+		}
+	}
+	if mp := cfg.MeterProvider; mp != nil {
+		d.MeterProvider = &ProviderDescription{}
+		for _, r := range mp.Readers {
+			switch {
+			case r.Periodic != nil:
+				d.MeterProvider.add("periodic", describeMetricExporter(r.Periodic.Exporter))
+			case r.Pull != nil:
+				d.MeterProvider.add("pull", describeMetricExporter(r.Pull.Exporter))
+			}
+		}
+	}
+	if lp := cfg.LoggerProvider; lp != nil {
+		d.LoggerProvider = &ProviderDescription{}
+		for _, p := range lp.Processors {
+			switch {
+			case p.Batch != nil:
+				d.LoggerProvider.add("batch", describeLogExporter(p.Batch.Exporter))
+			case p.Simple != nil:
+				d.LoggerProvider.add("simple", describeLogExporter(p.Simple.Exporter))
+			}
+		}
+	}
+	return d
+}
+
+func (d *ProviderDescription) add(typ string, exporter ExporterDescription) {
+	d.Processors = append(d.Processors, ProcessorDescription{Type: typ, Exporter: exporter})
+}
+
+func describeSpanExporter(e SpanExporter) ExporterDescription {
+	switch {
+	case e.Console != nil:
+		return ExporterDescription{Type: "console"}
+	case e.OTLP != nil:
+		return ExporterDescription{Type: "otlp", Protocol: otlpProtocol(e.OTLP.Protocol, e.OTLP.Endpoint), Endpoint: e.OTLP.Endpoint}
+	case e.Custom != nil:
+		return ExporterDescription{Type: e.Custom.Name}
+	}
+	return ExporterDescription{}
+}
+
+func describeMetricExporter(e MetricExporter) ExporterDescription {
+	switch {
+	case e.Console != nil:
+		return ExporterDescription{Type: "console"}
+	case e.OTLP != nil:
+		return ExporterDescription{Type: "otlp", Protocol: otlpProtocol(e.OTLP.Protocol, e.OTLP.Endpoint), Endpoint: e.OTLP.Endpoint}
+	case e.Prometheus != nil:
+		d := ExporterDescription{Type: "prometheus"}
+		if e.Prometheus.Host != nil && e.Prometheus.Port != nil {
+			d.Endpoint = fmt.Sprintf("%s:%d", *e.Prometheus.Host, *e.Prometheus.Port)
+		}
+		return d
+	}
+	return ExporterDescription{}
+}
+
+func describeLogExporter(e LogRecordExporter) ExporterDescription {
+	switch {
+	case e.Console != nil:
+		return ExporterDescription{Type: "console"}
+	case e.OTLP != nil:
+		return ExporterDescription{Type: "otlp", Protocol: otlpProtocol(e.OTLP.Protocol, e.OTLP.Endpoint), Endpoint: e.OTLP.Endpoint}
+	}
+	return ExporterDescription{}
+}
+
+type dryRunKey struct{}
+
+// contextWithDryRun returns a copy of parent in which the exporters are
+// created in dry-run mode.
+func contextWithDryRun(parent context.Context) context.Context {
+	return context.WithValue(parent, dryRunKey{}, true)
+}
+
+// isDryRun returns true if the exporters must be created in dry-run mode:
+// their configuration is validated, but stub exporters that do not open any
+// connection are returned.
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// dryRunSpanExporter is the span exporter created in dry-run mode. It drops
+// the spans.
+type dryRunSpanExporter struct{}
+
+func (dryRunSpanExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error { return nil }
+func (dryRunSpanExporter) Shutdown(context.Context) error                             { return nil }
+
+// dryRunMetricExporter is the metric exporter created in dry-run mode. It
+// drops the metrics.
+type dryRunMetricExporter struct{}
+
+func (dryRunMetricExporter) Temporality(k sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(k)
+}
+
+func (dryRunMetricExporter) Aggregation(k sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(k)
+}
+
+func (dryRunMetricExporter) Export(context.Context, *metricdata.ResourceMetrics) error { return nil }
+func (dryRunMetricExporter) ForceFlush(context.Context) error                          { return nil }
+func (dryRunMetricExporter) Shutdown(context.Context) error                            { return nil }
+
+// dryRunLogExporter is the log record exporter created in dry-run mode. It
+// drops the log records.
+type dryRunLogExporter struct{}
+
+func (dryRunLogExporter) Export(context.Context, []sdklog.Record) error { return nil }
+func (dryRunLogExporter) ForceFlush(context.Context) error              { return nil }
+func (dryRunLogExporter) Shutdown(context.Context) error                { return nil }
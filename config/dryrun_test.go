@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+func TestNewSDKDryRun(t *testing.T) {
+	// The OTLP/gRPC exporters would connect to this listener.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	var grpcConns atomic.Int64
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			grpcConns.Add(1)
+			_ = conn.Close()
+		}
+	}()
+
+	// The OTLP/HTTP exporters would send requests to this server.
+	var httpRequests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		httpRequests.Add(1)
+	}))
+	defer srv.Close()
+
+	// The Prometheus exporter would fail to listen on this port.
+	promLis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer promLis.Close()
+	promPort := promLis.Addr().(*net.TCPAddr).Port
+
+	grpcEndpoint := "http://" + lis.Addr().String()
+	cfg, err := ParseYAML([]byte(fmt.Sprintf(`
+file_format: "0.2"
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            protocol: grpc/protobuf
+            endpoint: %[1]s
+  sampler:
+    jaeger_remote:
+      endpoint: %[2]s
+      interval: 1
+meter_provider:
+  readers:
+    - periodic:
+        interval: 1
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: %[2]s/v1/metrics
+    - pull:
+        exporter:
+          prometheus:
+            host: 127.0.0.1
+            port: %[3]d
+logger_provider:
+  processors:
+    - simple:
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: %[2]s/v1/logs
+`, grpcEndpoint, srv.URL, promPort)))
+	require.NoError(t, err)
+
+	sdk, err := NewSDK(WithOpenTelemetryConfiguration(*cfg), WithDryRun())
+	require.NoError(t, err)
+
+	assert.Equal(t, Description{
+		TracerProvider: &ProviderDescription{Processors: []ProcessorDescription{
+			{Type: "batch", Exporter: ExporterDescription{Type: "otlp", Protocol: "grpc/protobuf", Endpoint: grpcEndpoint}},
+		}},
+		MeterProvider: &ProviderDescription{Processors: []ProcessorDescription{
+			{Type: "periodic", Exporter: ExporterDescription{Type: "otlp", Protocol: "http/protobuf", Endpoint: srv.URL + "/v1/metrics"}},
+			{Type: "pull", Exporter: ExporterDescription{Type: "prometheus", Endpoint: fmt.Sprintf("127.0.0.1:%d", promPort)}},
+		}},
+		LoggerProvider: &ProviderDescription{Processors: []ProcessorDescription{
+			{Type: "simple", Exporter: ExporterDescription{Type: "otlp", Protocol: "http/protobuf", Endpoint: srv.URL + "/v1/logs"}},
+		}},
+	}, sdk.Description())
+
+	// Telemetry is processed by the stub exporters.
+	ctx := context.Background()
+	_, span := sdk.TracerProvider().Tracer("test").Start(ctx, "span")
+	span.End()
+	counter, err := sdk.MeterProvider().Meter("test").Int64Counter("counter")
+	require.NoError(t, err)
+	counter.Add(ctx, 1)
+	var record log.Record
+	record.SetBody(log.StringValue("record"))
+	sdk.LoggerProvider().Logger("test").Emit(ctx, record)
+
+	// Leave time for the periodic reader and remote sampler to run.
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, sdk.Shutdown(ctx))
+
+	assert.Zero(t, grpcConns.Load(), "OTLP/gRPC connection opened")
+	assert.Zero(t, httpRequests.Load(), "HTTP request sent")
+}
+
+func TestNewSDKDryRunInvalidConfig(t *testing.T) {
+	cfg, err := ParseYAML([]byte(`
+file_format: "0.2"
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: http://localhost:4318
+            compression: invalid
+`))
+	require.NoError(t, err)
+
+	_, err = NewSDK(WithOpenTelemetryConfiguration(*cfg), WithDryRun())
+	assert.EqualError(t, err, `unsupported compression "invalid"`)
+}
+
+func TestNewSDKDescription(t *testing.T) {
+	sdk, err := NewSDK(WithOpenTelemetryConfiguration(OpenTelemetryConfiguration{
+		TracerProvider: &TracerProvider{
+			Processors: []SpanProcessor{
+				{Simple: &SimpleSpanProcessor{Exporter: SpanExporter{Console: Console{}}}},
+			},
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, Description{
+		TracerProvider: &ProviderDescription{Processors: []ProcessorDescription{
+			{Type: "simple", Exporter: ExporterDescription{Type: "console"}},
+		}},
+	}, sdk.Description())
+	require.NoError(t, sdk.Shutdown(context.Background()))
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config // import "go.opentelemetry.io/contrib/config"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// expandEnvNode replaces the environment variable references in the scalar
+// values of the YAML document node. Mapping keys are not expanded.
+func expandEnvNode(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		value, err := expandEnv(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		if value != node.Value && node.Style&(yaml.TaggedStyle|yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) == 0 {
+			// Resolve the type of unquoted values once expanded, so that
+			// "${QUEUE_SIZE}" can be decoded as an integer.
+			node.Tag = ""
+		}
+		node.Value = value
+	case yaml.MappingNode:
+		var errs []error
+		for i := 1; i < len(node.Content); i += 2 {
+			errs = append(errs, expandEnvNode(node.Content[i]))
+		}
+		return errors.Join(errs...)
+	case yaml.DocumentNode, yaml.SequenceNode:
+		var errs []error
+		for _, n := range node.Content {
+			errs = append(errs, expandEnvNode(n))
+		}
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// expandEnv replaces the environment variable references in s. The
+// following references are supported:
+//
+//   - ${VAR} is replaced by the value of VAR, or an empty string if VAR is
+//     not set.
+//   - ${VAR:-default} is replaced by the value of VAR, or default if VAR is
+//     not set or empty.
+//   - ${VAR:?message} is replaced by the value of VAR. An error containing
+//     message is returned if VAR is not set or empty.
+//   - $$ is replaced by a literal $.
+//
+// Any other $ is kept as is.
+func expandEnv(s string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated environment variable reference %q", s[i:])
+			}
+			value, err := lookupEnv(s[i+2 : i+2+end])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			i += end + 2
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// lookupEnv returns the value of the environment variable reference ref, the
+// content of a ${...} expression.
+func lookupEnv(ref string) (string, error) {
+	name, op, arg := ref, "", ""
+	if i := strings.Index(ref, ":"); i >= 0 {
+		name, op = ref[:i], ref[i:]
+		if len(op) < 2 || (op[1] != '-' && op[1] != '?') {
+			return "", fmt.Errorf("invalid environment variable reference \"${%s}\"", ref)
+		}
+		op, arg = op[:2], op[2:]
+	}
+	if !validEnvName(name) {
+		return "", fmt.Errorf("invalid environment variable name %q", name)
+	}
+
+	value := os.Getenv(name)
+	if value != "" {
+		return value, nil
+	}
+	switch op {
+	case ":-":
+		return arg, nil
+	case ":?":
+		if arg == "" {
+			arg = "required"
+		}
+		return "", fmt.Errorf("environment variable %s: %s", name, arg)
+	}
+	return "", nil
+}
+
+// validEnvName returns true if name is a valid environment variable name.
+func validEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
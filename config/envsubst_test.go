@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("OTEL_TEST_SET", "value")
+	t.Setenv("OTEL_TEST_EMPTY", "")
+
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr error
+	}{
+		{name: "no reference", in: "http://localhost:4318", want: "http://localhost:4318"},
+		{name: "set", in: "${OTEL_TEST_SET}", want: "value"},
+		{name: "unset", in: "${OTEL_TEST_UNSET}", want: ""},
+		{name: "embedded", in: "http://${OTEL_TEST_SET}:4318/v1", want: "http://value:4318/v1"},
+		{name: "multiple", in: "${OTEL_TEST_SET}-${OTEL_TEST_SET}", want: "value-value"},
+		{name: "default set", in: "${OTEL_TEST_SET:-other}", want: "value"},
+		{name: "default unset", in: "${OTEL_TEST_UNSET:-other}", want: "other"},
+		{name: "default empty", in: "${OTEL_TEST_EMPTY:-other}", want: "other"},
+		{name: "default with colon", in: "${OTEL_TEST_UNSET:-http://localhost:4318}", want: "http://localhost:4318"},
+		{name: "required present", in: "${OTEL_TEST_SET:?must be set}", want: "value"},
+		{
+			name:    "required missing",
+			in:      "${OTEL_TEST_UNSET:?must be set}",
+			wantErr: errors.New("environment variable OTEL_TEST_UNSET: must be set"),
+		},
+		{
+			name:    "required empty",
+			in:      "${OTEL_TEST_EMPTY:?}",
+			wantErr: errors.New("environment variable OTEL_TEST_EMPTY: required"),
+		},
+		{name: "escape", in: "$$", want: "$"},
+		{name: "escaped reference", in: "$${OTEL_TEST_SET}", want: "${OTEL_TEST_SET}"},
+		{name: "lone dollar", in: "a$b$", want: "a$b$"},
+		{
+			name:    "unterminated",
+			in:      "${OTEL_TEST_SET",
+			wantErr: errors.New("unterminated environment variable reference \"${OTEL_TEST_SET\""),
+		},
+		{
+			name:    "invalid name",
+			in:      "${1VAR}",
+			wantErr: errors.New("invalid environment variable name \"1VAR\""),
+		},
+		{
+			name:    "invalid operator",
+			in:      "${OTEL_TEST_SET:+other}",
+			wantErr: errors.New("invalid environment variable reference \"${OTEL_TEST_SET:+other}\""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandEnv(tt.in)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseYAMLExpandEnv(t *testing.T) {
+	t.Setenv("OTEL_TEST_ENDPOINT", "http://collector:4318")
+	t.Setenv("OTEL_TEST_QUEUE_SIZE", "100")
+
+	cfg, err := ParseYAML([]byte(`
+file_format: "0.2"
+# ${OTEL_TEST_IN_COMMENT:?comments are not expanded}
+tracer_provider:
+  processors:
+    - batch:
+        max_queue_size: ${OTEL_TEST_QUEUE_SIZE}
+        exporter:
+          otlp:
+            protocol: ${OTEL_TEST_PROTOCOL:-http/protobuf}
+            endpoint: ${OTEL_TEST_ENDPOINT:?endpoint is required}
+            headers:
+              api-key: "$$secret"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, &OpenTelemetryConfiguration{
+		FileFormat: "0.2",
+		TracerProvider: &TracerProvider{
+			Processors: []SpanProcessor{
+				{
+					Batch: &BatchSpanProcessor{
+						MaxQueueSize: ptr(100),
+						Exporter: SpanExporter{
+							OTLP: &OTLP{
+								Protocol: "http/protobuf",
+								Endpoint: "http://collector:4318",
+								Headers:  map[string]string{"api-key": "$secret"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, cfg)
+
+	_, err = ParseYAML([]byte(`
+file_format: "0.2"
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            endpoint: ${OTEL_TEST_MISSING_ENDPOINT:?endpoint is required}
+`))
+	assert.EqualError(t, err, "line 8: environment variable OTEL_TEST_MISSING_ENDPOINT: endpoint is required")
+
+	cfg, err = ParseYAML(nil)
+	require.NoError(t, err)
+	assert.Equal(t, &OpenTelemetryConfiguration{}, cfg)
+}
@@ -9,176 +9,198 @@ import "reflect"
 type AttributeLimits struct {
 	// AttributeCountLimit corresponds to the JSON schema field
 	// "attribute_count_limit".
-	AttributeCountLimit *int `mapstructure:"attribute_count_limit,omitempty"`
+	AttributeCountLimit *int `json:"attribute_count_limit,omitempty" yaml:"attribute_count_limit,omitempty" mapstructure:"attribute_count_limit,omitempty"`
 
 	// AttributeValueLengthLimit corresponds to the JSON schema field
 	// "attribute_value_length_limit".
-	AttributeValueLengthLimit *int `mapstructure:"attribute_value_length_limit,omitempty"`
+	AttributeValueLengthLimit *int `json:"attribute_value_length_limit,omitempty" yaml:"attribute_value_length_limit,omitempty" mapstructure:"attribute_value_length_limit,omitempty"`
 }
 
 type Attributes struct {
 	// ServiceName corresponds to the JSON schema field "service.name".
-	ServiceName *string `mapstructure:"service.name,omitempty"`
+	ServiceName *string `json:"service.name,omitempty" yaml:"service.name,omitempty" mapstructure:"service.name,omitempty"`
 }
 
 type BatchLogRecordProcessor struct {
 	// ExportTimeout corresponds to the JSON schema field "export_timeout".
-	ExportTimeout *int `mapstructure:"export_timeout,omitempty"`
+	ExportTimeout *int `json:"export_timeout,omitempty" yaml:"export_timeout,omitempty" mapstructure:"export_timeout,omitempty"`
 
 	// Exporter corresponds to the JSON schema field "exporter".
-	Exporter LogRecordExporter `mapstructure:"exporter"`
+	Exporter LogRecordExporter `json:"exporter" yaml:"exporter" mapstructure:"exporter"`
 
 	// MaxExportBatchSize corresponds to the JSON schema field
 	// "max_export_batch_size".
-	MaxExportBatchSize *int `mapstructure:"max_export_batch_size,omitempty"`
+	MaxExportBatchSize *int `json:"max_export_batch_size,omitempty" yaml:"max_export_batch_size,omitempty" mapstructure:"max_export_batch_size,omitempty"`
 
 	// MaxQueueSize corresponds to the JSON schema field "max_queue_size".
-	MaxQueueSize *int `mapstructure:"max_queue_size,omitempty"`
+	MaxQueueSize *int `json:"max_queue_size,omitempty" yaml:"max_queue_size,omitempty" mapstructure:"max_queue_size,omitempty"`
 
 	// ScheduleDelay corresponds to the JSON schema field "schedule_delay".
-	ScheduleDelay *int `mapstructure:"schedule_delay,omitempty"`
+	ScheduleDelay *int `json:"schedule_delay,omitempty" yaml:"schedule_delay,omitempty" mapstructure:"schedule_delay,omitempty"`
 }
 
 type BatchSpanProcessor struct {
 	// ExportTimeout corresponds to the JSON schema field "export_timeout".
-	ExportTimeout *int `mapstructure:"export_timeout,omitempty"`
+	ExportTimeout *int `json:"export_timeout,omitempty" yaml:"export_timeout,omitempty" mapstructure:"export_timeout,omitempty"`
 
 	// Exporter corresponds to the JSON schema field "exporter".
-	Exporter SpanExporter `mapstructure:"exporter"`
+	Exporter SpanExporter `json:"exporter" yaml:"exporter" mapstructure:"exporter"`
 
 	// MaxExportBatchSize corresponds to the JSON schema field
 	// "max_export_batch_size".
-	MaxExportBatchSize *int `mapstructure:"max_export_batch_size,omitempty"`
+	MaxExportBatchSize *int `json:"max_export_batch_size,omitempty" yaml:"max_export_batch_size,omitempty" mapstructure:"max_export_batch_size,omitempty"`
 
 	// MaxQueueSize corresponds to the JSON schema field "max_queue_size".
-	MaxQueueSize *int `mapstructure:"max_queue_size,omitempty"`
+	MaxQueueSize *int `json:"max_queue_size,omitempty" yaml:"max_queue_size,omitempty" mapstructure:"max_queue_size,omitempty"`
 
 	// ScheduleDelay corresponds to the JSON schema field "schedule_delay".
-	ScheduleDelay *int `mapstructure:"schedule_delay,omitempty"`
+	ScheduleDelay *int `json:"schedule_delay,omitempty" yaml:"schedule_delay,omitempty" mapstructure:"schedule_delay,omitempty"`
 }
 
 type Common map[string]interface{}
 
 type Console map[string]interface{}
 
+// CustomExporter configures an exporter created by a registered factory, it is
+// not part of the JSON schema.
+type CustomExporter struct {
+	// Name is the name the factory of the exporter is registered with.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Properties are passed to the factory of the exporter.
+	Properties map[string]interface{} `json:"properties,omitempty" yaml:"properties,omitempty" mapstructure:"properties,omitempty"`
+}
+
 type Headers map[string]string
 
 type LogRecordExporter struct {
+	// Console configures a log record exporter writing to the standard output,
+	// it is not part of the JSON schema.
+	Console Console `json:"console,omitempty" yaml:"console,omitempty" mapstructure:"console,omitempty"`
+
 	// OTLP corresponds to the JSON schema field "otlp".
-	OTLP *OTLP `mapstructure:"otlp,omitempty"`
+	OTLP *OTLP `json:"otlp,omitempty" yaml:"otlp,omitempty" mapstructure:"otlp,omitempty"`
 }
 
 type LogRecordLimits struct {
 	// AttributeCountLimit corresponds to the JSON schema field
 	// "attribute_count_limit".
-	AttributeCountLimit *int `mapstructure:"attribute_count_limit,omitempty"`
+	AttributeCountLimit *int `json:"attribute_count_limit,omitempty" yaml:"attribute_count_limit,omitempty" mapstructure:"attribute_count_limit,omitempty"`
 
 	// AttributeValueLengthLimit corresponds to the JSON schema field
 	// "attribute_value_length_limit".
-	AttributeValueLengthLimit *int `mapstructure:"attribute_value_length_limit,omitempty"`
+	AttributeValueLengthLimit *int `json:"attribute_value_length_limit,omitempty" yaml:"attribute_value_length_limit,omitempty" mapstructure:"attribute_value_length_limit,omitempty"`
 }
 
 type LogRecordProcessor struct {
 	// Batch corresponds to the JSON schema field "batch".
-	Batch *BatchLogRecordProcessor `mapstructure:"batch,omitempty"`
+	Batch *BatchLogRecordProcessor `json:"batch,omitempty" yaml:"batch,omitempty" mapstructure:"batch,omitempty"`
 
 	// Simple corresponds to the JSON schema field "simple".
-	Simple *SimpleLogRecordProcessor `mapstructure:"simple,omitempty"`
+	Simple *SimpleLogRecordProcessor `json:"simple,omitempty" yaml:"simple,omitempty" mapstructure:"simple,omitempty"`
 }
 
 type LoggerProvider struct {
 	// Limits corresponds to the JSON schema field "limits".
-	Limits *LogRecordLimits `mapstructure:"limits,omitempty"`
+	Limits *LogRecordLimits `json:"limits,omitempty" yaml:"limits,omitempty" mapstructure:"limits,omitempty"`
 
 	// Processors corresponds to the JSON schema field "processors".
-	Processors []LogRecordProcessor `mapstructure:"processors,omitempty"`
+	Processors []LogRecordProcessor `json:"processors,omitempty" yaml:"processors,omitempty" mapstructure:"processors,omitempty"`
 }
 
 type MeterProvider struct {
 	// Readers corresponds to the JSON schema field "readers".
-	Readers []MetricReader `mapstructure:"readers,omitempty"`
+	Readers []MetricReader `json:"readers,omitempty" yaml:"readers,omitempty" mapstructure:"readers,omitempty"`
 
 	// Views corresponds to the JSON schema field "views".
-	Views []View `mapstructure:"views,omitempty"`
+	Views []View `json:"views,omitempty" yaml:"views,omitempty" mapstructure:"views,omitempty"`
 }
 
 type MetricExporter struct {
 	// Console corresponds to the JSON schema field "console".
-	Console Console `mapstructure:"console,omitempty"`
+	Console Console `json:"console,omitempty" yaml:"console,omitempty" mapstructure:"console,omitempty"`
 
 	// OTLP corresponds to the JSON schema field "otlp".
-	OTLP *OTLPMetric `mapstructure:"otlp,omitempty"`
+	OTLP *OTLPMetric `json:"otlp,omitempty" yaml:"otlp,omitempty" mapstructure:"otlp,omitempty"`
 
 	// Prometheus corresponds to the JSON schema field "prometheus".
-	Prometheus *Prometheus `mapstructure:"prometheus,omitempty"`
+	Prometheus *Prometheus `json:"prometheus,omitempty" yaml:"prometheus,omitempty" mapstructure:"prometheus,omitempty"`
 }
 
 type MetricReader struct {
 	// Periodic corresponds to the JSON schema field "periodic".
-	Periodic *PeriodicMetricReader `mapstructure:"periodic,omitempty"`
+	Periodic *PeriodicMetricReader `json:"periodic,omitempty" yaml:"periodic,omitempty" mapstructure:"periodic,omitempty"`
 
 	// Pull corresponds to the JSON schema field "pull".
-	Pull *PullMetricReader `mapstructure:"pull,omitempty"`
+	Pull *PullMetricReader `json:"pull,omitempty" yaml:"pull,omitempty" mapstructure:"pull,omitempty"`
 }
 
 type OTLP struct {
 	// Certificate corresponds to the JSON schema field "certificate".
-	Certificate *string `mapstructure:"certificate,omitempty"`
+	Certificate *string `json:"certificate,omitempty" yaml:"certificate,omitempty" mapstructure:"certificate,omitempty"`
 
 	// ClientCertificate corresponds to the JSON schema field "client_certificate".
-	ClientCertificate *string `mapstructure:"client_certificate,omitempty"`
+	ClientCertificate *string `json:"client_certificate,omitempty" yaml:"client_certificate,omitempty" mapstructure:"client_certificate,omitempty"`
 
 	// ClientKey corresponds to the JSON schema field "client_key".
-	ClientKey *string `mapstructure:"client_key,omitempty"`
+	ClientKey *string `json:"client_key,omitempty" yaml:"client_key,omitempty" mapstructure:"client_key,omitempty"`
 
 	// Compression corresponds to the JSON schema field "compression".
-	Compression *string `mapstructure:"compression,omitempty"`
+	Compression *string `json:"compression,omitempty" yaml:"compression,omitempty" mapstructure:"compression,omitempty"`
 
 	// Endpoint corresponds to the JSON schema field "endpoint".
-	Endpoint string `mapstructure:"endpoint"`
+	Endpoint string `json:"endpoint" yaml:"endpoint" mapstructure:"endpoint"`
 
 	// Headers corresponds to the JSON schema field "headers".
-	Headers Headers `mapstructure:"headers,omitempty"`
+	Headers Headers `json:"headers,omitempty" yaml:"headers,omitempty" mapstructure:"headers,omitempty"`
 
 	// Protocol corresponds to the JSON schema field "protocol".
-	Protocol string `mapstructure:"protocol"`
+	Protocol string `json:"protocol" yaml:"protocol" mapstructure:"protocol"`
+
+	// Retry configures the retry of the failed exports, it is not part of the
+	// JSON schema.
+	Retry *OTLPRetry `json:"retry,omitempty" yaml:"retry,omitempty" mapstructure:"retry,omitempty"`
 
 	// Timeout corresponds to the JSON schema field "timeout".
-	Timeout *int `mapstructure:"timeout,omitempty"`
+	Timeout *int `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
 }
 
 type OTLPMetric struct {
 	// Certificate corresponds to the JSON schema field "certificate".
-	Certificate *string `mapstructure:"certificate,omitempty"`
+	Certificate *string `json:"certificate,omitempty" yaml:"certificate,omitempty" mapstructure:"certificate,omitempty"`
 
 	// ClientCertificate corresponds to the JSON schema field "client_certificate".
-	ClientCertificate *string `mapstructure:"client_certificate,omitempty"`
+	ClientCertificate *string `json:"client_certificate,omitempty" yaml:"client_certificate,omitempty" mapstructure:"client_certificate,omitempty"`
 
 	// ClientKey corresponds to the JSON schema field "client_key".
-	ClientKey *string `mapstructure:"client_key,omitempty"`
+	ClientKey *string `json:"client_key,omitempty" yaml:"client_key,omitempty" mapstructure:"client_key,omitempty"`
 
 	// Compression corresponds to the JSON schema field "compression".
-	Compression *string `mapstructure:"compression,omitempty"`
+	Compression *string `json:"compression,omitempty" yaml:"compression,omitempty" mapstructure:"compression,omitempty"`
 
 	// DefaultHistogramAggregation corresponds to the JSON schema field
 	// "default_histogram_aggregation".
-	DefaultHistogramAggregation *OTLPMetricDefaultHistogramAggregation `mapstructure:"default_histogram_aggregation,omitempty"`
+	DefaultHistogramAggregation *OTLPMetricDefaultHistogramAggregation `json:"default_histogram_aggregation,omitempty" yaml:"default_histogram_aggregation,omitempty" mapstructure:"default_histogram_aggregation,omitempty"`
 
 	// Endpoint corresponds to the JSON schema field "endpoint".
-	Endpoint string `mapstructure:"endpoint"`
+	Endpoint string `json:"endpoint" yaml:"endpoint" mapstructure:"endpoint"`
 
 	// Headers corresponds to the JSON schema field "headers".
-	Headers Headers `mapstructure:"headers,omitempty"`
+	Headers Headers `json:"headers,omitempty" yaml:"headers,omitempty" mapstructure:"headers,omitempty"`
 
 	// Protocol corresponds to the JSON schema field "protocol".
-	Protocol string `mapstructure:"protocol"`
+	Protocol string `json:"protocol" yaml:"protocol" mapstructure:"protocol"`
+
+	// Retry configures the retry of the failed exports, it is not part of the
+	// JSON schema.
+	Retry *OTLPRetry `json:"retry,omitempty" yaml:"retry,omitempty" mapstructure:"retry,omitempty"`
 
 	// TemporalityPreference corresponds to the JSON schema field
 	// "temporality_preference".
-	TemporalityPreference *string `mapstructure:"temporality_preference,omitempty"`
+	TemporalityPreference *string `json:"temporality_preference,omitempty" yaml:"temporality_preference,omitempty" mapstructure:"temporality_preference,omitempty"`
 
 	// Timeout corresponds to the JSON schema field "timeout".
-	Timeout *int `mapstructure:"timeout,omitempty"`
+	Timeout *int `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
 }
 
 type OTLPMetricDefaultHistogramAggregation string
@@ -188,91 +210,95 @@ const OTLPMetricDefaultHistogramAggregationExplicitBucketHistogram OTLPMetricDef
 
 type OpenTelemetryConfiguration struct {
 	// AttributeLimits corresponds to the JSON schema field "attribute_limits".
-	AttributeLimits *AttributeLimits `mapstructure:"attribute_limits,omitempty"`
+	AttributeLimits *AttributeLimits `json:"attribute_limits,omitempty" yaml:"attribute_limits,omitempty" mapstructure:"attribute_limits,omitempty"`
 
 	// Disabled corresponds to the JSON schema field "disabled".
-	Disabled *bool `mapstructure:"disabled,omitempty"`
+	Disabled *bool `json:"disabled,omitempty" yaml:"disabled,omitempty" mapstructure:"disabled,omitempty"`
 
 	// FileFormat corresponds to the JSON schema field "file_format".
-	FileFormat string `mapstructure:"file_format"`
+	FileFormat string `json:"file_format" yaml:"file_format" mapstructure:"file_format"`
 
 	// LoggerProvider corresponds to the JSON schema field "logger_provider".
-	LoggerProvider *LoggerProvider `mapstructure:"logger_provider,omitempty"`
+	LoggerProvider *LoggerProvider `json:"logger_provider,omitempty" yaml:"logger_provider,omitempty" mapstructure:"logger_provider,omitempty"`
 
 	// MeterProvider corresponds to the JSON schema field "meter_provider".
-	MeterProvider *MeterProvider `mapstructure:"meter_provider,omitempty"`
+	MeterProvider *MeterProvider `json:"meter_provider,omitempty" yaml:"meter_provider,omitempty" mapstructure:"meter_provider,omitempty"`
 
 	// Propagator corresponds to the JSON schema field "propagator".
-	Propagator *Propagator `mapstructure:"propagator,omitempty"`
+	Propagator *Propagator `json:"propagator,omitempty" yaml:"propagator,omitempty" mapstructure:"propagator,omitempty"`
 
 	// Resource corresponds to the JSON schema field "resource".
-	Resource *Resource `mapstructure:"resource,omitempty"`
+	Resource *Resource `json:"resource,omitempty" yaml:"resource,omitempty" mapstructure:"resource,omitempty"`
 
 	// TracerProvider corresponds to the JSON schema field "tracer_provider".
-	TracerProvider *TracerProvider `mapstructure:"tracer_provider,omitempty"`
+	TracerProvider *TracerProvider `json:"tracer_provider,omitempty" yaml:"tracer_provider,omitempty" mapstructure:"tracer_provider,omitempty"`
 }
 
 type PeriodicMetricReader struct {
 	// Exporter corresponds to the JSON schema field "exporter".
-	Exporter MetricExporter `mapstructure:"exporter"`
+	Exporter MetricExporter `json:"exporter" yaml:"exporter" mapstructure:"exporter"`
 
 	// Interval corresponds to the JSON schema field "interval".
-	Interval *int `mapstructure:"interval,omitempty"`
+	Interval *int `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval,omitempty"`
 
 	// Timeout corresponds to the JSON schema field "timeout".
-	Timeout *int `mapstructure:"timeout,omitempty"`
+	Timeout *int `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
 }
 
 type Prometheus struct {
 	// Host corresponds to the JSON schema field "host".
-	Host *string `mapstructure:"host,omitempty"`
+	Host *string `json:"host,omitempty" yaml:"host,omitempty" mapstructure:"host,omitempty"`
 
 	// Port corresponds to the JSON schema field "port".
-	Port *int `mapstructure:"port,omitempty"`
+	Port *int `json:"port,omitempty" yaml:"port,omitempty" mapstructure:"port,omitempty"`
 
 	// WithoutScopeInfo corresponds to the JSON schema field "without_scope_info".
-	WithoutScopeInfo *bool `mapstructure:"without_scope_info,omitempty"`
+	WithoutScopeInfo *bool `json:"without_scope_info,omitempty" yaml:"without_scope_info,omitempty" mapstructure:"without_scope_info,omitempty"`
 
 	// WithoutTypeSuffix corresponds to the JSON schema field "without_type_suffix".
-	WithoutTypeSuffix *bool `mapstructure:"without_type_suffix,omitempty"`
+	WithoutTypeSuffix *bool `json:"without_type_suffix,omitempty" yaml:"without_type_suffix,omitempty" mapstructure:"without_type_suffix,omitempty"`
 
 	// WithoutUnits corresponds to the JSON schema field "without_units".
-	WithoutUnits *bool `mapstructure:"without_units,omitempty"`
+	WithoutUnits *bool `json:"without_units,omitempty" yaml:"without_units,omitempty" mapstructure:"without_units,omitempty"`
 }
 
 type Propagator struct {
 	// Composite corresponds to the JSON schema field "composite".
-	Composite []string `mapstructure:"composite,omitempty"`
+	Composite []string `json:"composite,omitempty" yaml:"composite,omitempty" mapstructure:"composite,omitempty"`
 }
 
 type PullMetricReader struct {
 	// Exporter corresponds to the JSON schema field "exporter".
-	Exporter MetricExporter `mapstructure:"exporter"`
+	Exporter MetricExporter `json:"exporter" yaml:"exporter" mapstructure:"exporter"`
 }
 
 type Resource struct {
 	// Attributes corresponds to the JSON schema field "attributes".
-	Attributes *Attributes `mapstructure:"attributes,omitempty"`
+	Attributes *Attributes `json:"attributes,omitempty" yaml:"attributes,omitempty" mapstructure:"attributes,omitempty"`
+
+	// Detectors lists the resource detectors run to detect the attributes of
+	// the resource, it is not part of the JSON schema.
+	Detectors []string `json:"detectors,omitempty" yaml:"detectors,omitempty" mapstructure:"detectors,omitempty"`
 
 	// SchemaUrl corresponds to the JSON schema field "schema_url".
-	SchemaUrl *string `mapstructure:"schema_url,omitempty"`
+	SchemaUrl *string `json:"schema_url,omitempty" yaml:"schema_url,omitempty" mapstructure:"schema_url,omitempty"`
 }
 
 type Sampler struct {
 	// AlwaysOff corresponds to the JSON schema field "always_off".
-	AlwaysOff SamplerAlwaysOff `mapstructure:"always_off,omitempty"`
+	AlwaysOff SamplerAlwaysOff `json:"always_off,omitempty" yaml:"always_off,omitempty" mapstructure:"always_off,omitempty"`
 
 	// AlwaysOn corresponds to the JSON schema field "always_on".
-	AlwaysOn SamplerAlwaysOn `mapstructure:"always_on,omitempty"`
+	AlwaysOn SamplerAlwaysOn `json:"always_on,omitempty" yaml:"always_on,omitempty" mapstructure:"always_on,omitempty"`
 
 	// JaegerRemote corresponds to the JSON schema field "jaeger_remote".
-	JaegerRemote *SamplerJaegerRemote `mapstructure:"jaeger_remote,omitempty"`
+	JaegerRemote *SamplerJaegerRemote `json:"jaeger_remote,omitempty" yaml:"jaeger_remote,omitempty" mapstructure:"jaeger_remote,omitempty"`
 
 	// ParentBased corresponds to the JSON schema field "parent_based".
-	ParentBased *SamplerParentBased `mapstructure:"parent_based,omitempty"`
+	ParentBased *SamplerParentBased `json:"parent_based,omitempty" yaml:"parent_based,omitempty" mapstructure:"parent_based,omitempty"`
 
 	// TraceIDRatioBased corresponds to the JSON schema field "trace_id_ratio_based".
-	TraceIDRatioBased *SamplerTraceIDRatioBased `mapstructure:"trace_id_ratio_based,omitempty"`
+	TraceIDRatioBased *SamplerTraceIDRatioBased `json:"trace_id_ratio_based,omitempty" yaml:"trace_id_ratio_based,omitempty" mapstructure:"trace_id_ratio_based,omitempty"`
 }
 
 type SamplerAlwaysOff map[string]interface{}
@@ -281,130 +307,134 @@ type SamplerAlwaysOn map[string]interface{}
 
 type SamplerJaegerRemote struct {
 	// Endpoint corresponds to the JSON schema field "endpoint".
-	Endpoint *string `mapstructure:"endpoint,omitempty"`
+	Endpoint *string `json:"endpoint,omitempty" yaml:"endpoint,omitempty" mapstructure:"endpoint,omitempty"`
 
 	// InitialSampler corresponds to the JSON schema field "initial_sampler".
-	InitialSampler *Sampler `mapstructure:"initial_sampler,omitempty"`
+	InitialSampler *Sampler `json:"initial_sampler,omitempty" yaml:"initial_sampler,omitempty" mapstructure:"initial_sampler,omitempty"`
 
 	// Interval corresponds to the JSON schema field "interval".
-	Interval *int `mapstructure:"interval,omitempty"`
+	Interval *int `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval,omitempty"`
 }
 
 type SamplerParentBased struct {
 	// LocalParentNotSampled corresponds to the JSON schema field
 	// "local_parent_not_sampled".
-	LocalParentNotSampled *Sampler `mapstructure:"local_parent_not_sampled,omitempty"`
+	LocalParentNotSampled *Sampler `json:"local_parent_not_sampled,omitempty" yaml:"local_parent_not_sampled,omitempty" mapstructure:"local_parent_not_sampled,omitempty"`
 
 	// LocalParentSampled corresponds to the JSON schema field "local_parent_sampled".
-	LocalParentSampled *Sampler `mapstructure:"local_parent_sampled,omitempty"`
+	LocalParentSampled *Sampler `json:"local_parent_sampled,omitempty" yaml:"local_parent_sampled,omitempty" mapstructure:"local_parent_sampled,omitempty"`
 
 	// RemoteParentNotSampled corresponds to the JSON schema field
 	// "remote_parent_not_sampled".
-	RemoteParentNotSampled *Sampler `mapstructure:"remote_parent_not_sampled,omitempty"`
+	RemoteParentNotSampled *Sampler `json:"remote_parent_not_sampled,omitempty" yaml:"remote_parent_not_sampled,omitempty" mapstructure:"remote_parent_not_sampled,omitempty"`
 
 	// RemoteParentSampled corresponds to the JSON schema field
 	// "remote_parent_sampled".
-	RemoteParentSampled *Sampler `mapstructure:"remote_parent_sampled,omitempty"`
+	RemoteParentSampled *Sampler `json:"remote_parent_sampled,omitempty" yaml:"remote_parent_sampled,omitempty" mapstructure:"remote_parent_sampled,omitempty"`
 
 	// Root corresponds to the JSON schema field "root".
-	Root *Sampler `mapstructure:"root,omitempty"`
+	Root *Sampler `json:"root,omitempty" yaml:"root,omitempty" mapstructure:"root,omitempty"`
 }
 
 type SamplerTraceIDRatioBased struct {
 	// Ratio corresponds to the JSON schema field "ratio".
-	Ratio *float64 `mapstructure:"ratio,omitempty"`
+	Ratio *float64 `json:"ratio,omitempty" yaml:"ratio,omitempty" mapstructure:"ratio,omitempty"`
 }
 
 type SimpleLogRecordProcessor struct {
 	// Exporter corresponds to the JSON schema field "exporter".
-	Exporter LogRecordExporter `mapstructure:"exporter"`
+	Exporter LogRecordExporter `json:"exporter" yaml:"exporter" mapstructure:"exporter"`
 }
 
 type SimpleSpanProcessor struct {
 	// Exporter corresponds to the JSON schema field "exporter".
-	Exporter SpanExporter `mapstructure:"exporter"`
+	Exporter SpanExporter `json:"exporter" yaml:"exporter" mapstructure:"exporter"`
 }
 
 type SpanExporter struct {
 	// Console corresponds to the JSON schema field "console".
-	Console Console `mapstructure:"console,omitempty"`
+	Console Console `json:"console,omitempty" yaml:"console,omitempty" mapstructure:"console,omitempty"`
+
+	// Custom configures a span exporter created by the factory registered with
+	// RegisterSpanExporterFactory, it is not part of the JSON schema.
+	Custom *CustomExporter `json:"custom,omitempty" yaml:"custom,omitempty" mapstructure:"custom,omitempty"`
 
 	// OTLP corresponds to the JSON schema field "otlp".
-	OTLP *OTLP `mapstructure:"otlp,omitempty"`
+	OTLP *OTLP `json:"otlp,omitempty" yaml:"otlp,omitempty" mapstructure:"otlp,omitempty"`
 
 	// Zipkin corresponds to the JSON schema field "zipkin".
-	Zipkin *Zipkin `mapstructure:"zipkin,omitempty"`
+	Zipkin *Zipkin `json:"zipkin,omitempty" yaml:"zipkin,omitempty" mapstructure:"zipkin,omitempty"`
 }
 
 type SpanLimits struct {
 	// AttributeCountLimit corresponds to the JSON schema field
 	// "attribute_count_limit".
-	AttributeCountLimit *int `mapstructure:"attribute_count_limit,omitempty"`
+	AttributeCountLimit *int `json:"attribute_count_limit,omitempty" yaml:"attribute_count_limit,omitempty" mapstructure:"attribute_count_limit,omitempty"`
 
 	// AttributeValueLengthLimit corresponds to the JSON schema field
 	// "attribute_value_length_limit".
-	AttributeValueLengthLimit *int `mapstructure:"attribute_value_length_limit,omitempty"`
+	AttributeValueLengthLimit *int `json:"attribute_value_length_limit,omitempty" yaml:"attribute_value_length_limit,omitempty" mapstructure:"attribute_value_length_limit,omitempty"`
 
 	// EventAttributeCountLimit corresponds to the JSON schema field
 	// "event_attribute_count_limit".
-	EventAttributeCountLimit *int `mapstructure:"event_attribute_count_limit,omitempty"`
+	EventAttributeCountLimit *int `json:"event_attribute_count_limit,omitempty" yaml:"event_attribute_count_limit,omitempty" mapstructure:"event_attribute_count_limit,omitempty"`
 
 	// EventCountLimit corresponds to the JSON schema field "event_count_limit".
-	EventCountLimit *int `mapstructure:"event_count_limit,omitempty"`
+	EventCountLimit *int `json:"event_count_limit,omitempty" yaml:"event_count_limit,omitempty" mapstructure:"event_count_limit,omitempty"`
 
 	// LinkAttributeCountLimit corresponds to the JSON schema field
 	// "link_attribute_count_limit".
-	LinkAttributeCountLimit *int `mapstructure:"link_attribute_count_limit,omitempty"`
+	LinkAttributeCountLimit *int `json:"link_attribute_count_limit,omitempty" yaml:"link_attribute_count_limit,omitempty" mapstructure:"link_attribute_count_limit,omitempty"`
 
 	// LinkCountLimit corresponds to the JSON schema field "link_count_limit".
-	LinkCountLimit *int `mapstructure:"link_count_limit,omitempty"`
+	LinkCountLimit *int `json:"link_count_limit,omitempty" yaml:"link_count_limit,omitempty" mapstructure:"link_count_limit,omitempty"`
 }
 
 type SpanProcessor struct {
 	// Batch corresponds to the JSON schema field "batch".
-	Batch *BatchSpanProcessor `mapstructure:"batch,omitempty"`
+	Batch *BatchSpanProcessor `json:"batch,omitempty" yaml:"batch,omitempty" mapstructure:"batch,omitempty"`
 
 	// Simple corresponds to the JSON schema field "simple".
-	Simple *SimpleSpanProcessor `mapstructure:"simple,omitempty"`
+	Simple *SimpleSpanProcessor `json:"simple,omitempty" yaml:"simple,omitempty" mapstructure:"simple,omitempty"`
 }
 
 type TracerProvider struct {
 	// Limits corresponds to the JSON schema field "limits".
-	Limits *SpanLimits `mapstructure:"limits,omitempty"`
+	Limits *SpanLimits `json:"limits,omitempty" yaml:"limits,omitempty" mapstructure:"limits,omitempty"`
 
 	// Processors corresponds to the JSON schema field "processors".
-	Processors []SpanProcessor `mapstructure:"processors,omitempty"`
+	Processors []SpanProcessor `json:"processors,omitempty" yaml:"processors,omitempty" mapstructure:"processors,omitempty"`
 
 	// Sampler corresponds to the JSON schema field "sampler".
-	Sampler *Sampler `mapstructure:"sampler,omitempty"`
+	Sampler *Sampler `json:"sampler,omitempty" yaml:"sampler,omitempty" mapstructure:"sampler,omitempty"`
 }
 
 type View struct {
 	// Selector corresponds to the JSON schema field "selector".
-	Selector *ViewSelector `mapstructure:"selector,omitempty"`
+	Selector *ViewSelector `json:"selector,omitempty" yaml:"selector,omitempty" mapstructure:"selector,omitempty"`
 
 	// Stream corresponds to the JSON schema field "stream".
-	Stream *ViewStream `mapstructure:"stream,omitempty"`
+	Stream *ViewStream `json:"stream,omitempty" yaml:"stream,omitempty" mapstructure:"stream,omitempty"`
 }
 
 type ViewSelector struct {
 	// InstrumentName corresponds to the JSON schema field "instrument_name".
-	InstrumentName *string `mapstructure:"instrument_name,omitempty"`
+	InstrumentName *string `json:"instrument_name,omitempty" yaml:"instrument_name,omitempty" mapstructure:"instrument_name,omitempty"`
 
 	// InstrumentType corresponds to the JSON schema field "instrument_type".
-	InstrumentType *ViewSelectorInstrumentType `mapstructure:"instrument_type,omitempty"`
+	InstrumentType *ViewSelectorInstrumentType `json:"instrument_type,omitempty" yaml:"instrument_type,omitempty" mapstructure:"instrument_type,omitempty"`
 
 	// MeterName corresponds to the JSON schema field "meter_name".
-	MeterName *string `mapstructure:"meter_name,omitempty"`
+	MeterName *string `json:"meter_name,omitempty" yaml:"meter_name,omitempty" mapstructure:"meter_name,omitempty"`
 
 	// MeterSchemaUrl corresponds to the JSON schema field "meter_schema_url".
-	MeterSchemaUrl *string `mapstructure:"meter_schema_url,omitempty"`
+	MeterSchemaUrl *string `json:"meter_schema_url,omitempty" yaml:"meter_schema_url,omitempty" mapstructure:"meter_schema_url,omitempty"`
 
 	// MeterVersion corresponds to the JSON schema field "meter_version".
-	MeterVersion *string `mapstructure:"meter_version,omitempty"`
+	MeterVersion *string `json:"meter_version,omitempty" yaml:"meter_version,omitempty" mapstructure:"meter_version,omitempty"`
 
 	// Unit corresponds to the JSON schema field "unit".
-	Unit *string `mapstructure:"unit,omitempty"`
+	Unit *string `json:"unit,omitempty" yaml:"unit,omitempty" mapstructure:"unit,omitempty"`
 }
 
 type ViewSelectorInstrumentType string
@@ -418,49 +448,49 @@ const ViewSelectorInstrumentTypeUpDownCounter ViewSelectorInstrumentType = "up_d
 
 type ViewStream struct {
 	// Aggregation corresponds to the JSON schema field "aggregation".
-	Aggregation *ViewStreamAggregation `mapstructure:"aggregation,omitempty"`
+	Aggregation *ViewStreamAggregation `json:"aggregation,omitempty" yaml:"aggregation,omitempty" mapstructure:"aggregation,omitempty"`
 
 	// AttributeKeys corresponds to the JSON schema field "attribute_keys".
-	AttributeKeys []string `mapstructure:"attribute_keys,omitempty"`
+	AttributeKeys []string `json:"attribute_keys,omitempty" yaml:"attribute_keys,omitempty" mapstructure:"attribute_keys,omitempty"`
 
 	// Description corresponds to the JSON schema field "description".
-	Description *string `mapstructure:"description,omitempty"`
+	Description *string `json:"description,omitempty" yaml:"description,omitempty" mapstructure:"description,omitempty"`
 
 	// Name corresponds to the JSON schema field "name".
-	Name *string `mapstructure:"name,omitempty"`
+	Name *string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name,omitempty"`
 }
 
 type ViewStreamAggregation struct {
 	// Base2ExponentialBucketHistogram corresponds to the JSON schema field
 	// "base2_exponential_bucket_histogram".
-	Base2ExponentialBucketHistogram *ViewStreamAggregationBase2ExponentialBucketHistogram `mapstructure:"base2_exponential_bucket_histogram,omitempty"`
+	Base2ExponentialBucketHistogram *ViewStreamAggregationBase2ExponentialBucketHistogram `json:"base2_exponential_bucket_histogram,omitempty" yaml:"base2_exponential_bucket_histogram,omitempty" mapstructure:"base2_exponential_bucket_histogram,omitempty"`
 
 	// Default corresponds to the JSON schema field "default".
-	Default ViewStreamAggregationDefault `mapstructure:"default,omitempty"`
+	Default ViewStreamAggregationDefault `json:"default,omitempty" yaml:"default,omitempty" mapstructure:"default,omitempty"`
 
 	// Drop corresponds to the JSON schema field "drop".
-	Drop ViewStreamAggregationDrop `mapstructure:"drop,omitempty"`
+	Drop ViewStreamAggregationDrop `json:"drop,omitempty" yaml:"drop,omitempty" mapstructure:"drop,omitempty"`
 
 	// ExplicitBucketHistogram corresponds to the JSON schema field
 	// "explicit_bucket_histogram".
-	ExplicitBucketHistogram *ViewStreamAggregationExplicitBucketHistogram `mapstructure:"explicit_bucket_histogram,omitempty"`
+	ExplicitBucketHistogram *ViewStreamAggregationExplicitBucketHistogram `json:"explicit_bucket_histogram,omitempty" yaml:"explicit_bucket_histogram,omitempty" mapstructure:"explicit_bucket_histogram,omitempty"`
 
 	// LastValue corresponds to the JSON schema field "last_value".
-	LastValue ViewStreamAggregationLastValue `mapstructure:"last_value,omitempty"`
+	LastValue ViewStreamAggregationLastValue `json:"last_value,omitempty" yaml:"last_value,omitempty" mapstructure:"last_value,omitempty"`
 
 	// Sum corresponds to the JSON schema field "sum".
-	Sum ViewStreamAggregationSum `mapstructure:"sum,omitempty"`
+	Sum ViewStreamAggregationSum `json:"sum,omitempty" yaml:"sum,omitempty" mapstructure:"sum,omitempty"`
 }
 
 type ViewStreamAggregationBase2ExponentialBucketHistogram struct {
 	// MaxScale corresponds to the JSON schema field "max_scale".
-	MaxScale *int `mapstructure:"max_scale,omitempty"`
+	MaxScale *int `json:"max_scale,omitempty" yaml:"max_scale,omitempty" mapstructure:"max_scale,omitempty"`
 
 	// MaxSize corresponds to the JSON schema field "max_size".
-	MaxSize *int `mapstructure:"max_size,omitempty"`
+	MaxSize *int `json:"max_size,omitempty" yaml:"max_size,omitempty" mapstructure:"max_size,omitempty"`
 
 	// RecordMinMax corresponds to the JSON schema field "record_min_max".
-	RecordMinMax *bool `mapstructure:"record_min_max,omitempty"`
+	RecordMinMax *bool `json:"record_min_max,omitempty" yaml:"record_min_max,omitempty" mapstructure:"record_min_max,omitempty"`
 }
 
 type ViewStreamAggregationDefault map[string]interface{}
@@ -469,10 +499,10 @@ type ViewStreamAggregationDrop map[string]interface{}
 
 type ViewStreamAggregationExplicitBucketHistogram struct {
 	// Boundaries corresponds to the JSON schema field "boundaries".
-	Boundaries []float64 `mapstructure:"boundaries,omitempty"`
+	Boundaries []float64 `json:"boundaries,omitempty" yaml:"boundaries,omitempty" mapstructure:"boundaries,omitempty"`
 
 	// RecordMinMax corresponds to the JSON schema field "record_min_max".
-	RecordMinMax *bool `mapstructure:"record_min_max,omitempty"`
+	RecordMinMax *bool `json:"record_min_max,omitempty" yaml:"record_min_max,omitempty" mapstructure:"record_min_max,omitempty"`
 }
 
 type ViewStreamAggregationLastValue map[string]interface{}
@@ -481,10 +511,10 @@ type ViewStreamAggregationSum map[string]interface{}
 
 type Zipkin struct {
 	// Endpoint corresponds to the JSON schema field "endpoint".
-	Endpoint string `mapstructure:"endpoint"`
+	Endpoint string `json:"endpoint" yaml:"endpoint" mapstructure:"endpoint"`
 
 	// Timeout corresponds to the JSON schema field "timeout".
-	Timeout *int `mapstructure:"timeout,omitempty"`
+	Timeout *int `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
 }
 
 var enumValues_OTLPMetricDefaultHistogramAggregation = []interface{}{
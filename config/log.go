@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config // import "go.opentelemetry.io/contrib/config"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func loggerProvider(cfg configOptions, res *resource.Resource) (log.LoggerProvider, shutdownFunc, error) {
+	if cfg.opentelemetryConfig.LoggerProvider == nil {
+		return noop.NewLoggerProvider(), noopShutdown, nil
+	}
+	opts := []sdklog.LoggerProviderOption{
+		sdklog.WithResource(res),
+	}
+	limitOpts, errs := logRecordLimits(cfg.opentelemetryConfig.LoggerProvider.Limits)
+	opts = append(opts, limitOpts...)
+	for _, processor := range cfg.opentelemetryConfig.LoggerProvider.Processors {
+		sp, err := logProcessor(cfg.ctx, processor)
+		if err == nil {
+			opts = append(opts, sdklog.WithProcessor(sp))
+		} else {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return noop.NewLoggerProvider(), noopShutdown, errors.Join(errs...)
+	}
+	lp := sdklog.NewLoggerProvider(opts...)
+	return lp, lp.Shutdown, nil
+}
+
+// logRecordLimits returns the options setting the limits of the log records.
+// The limits must not be negative, zero drops all the attributes or
+// truncates all the string values. The limits are applied by the log SDK.
+func logRecordLimits(limits *LogRecordLimits) ([]sdklog.LoggerProviderOption, []error) {
+	if limits == nil {
+		return nil, nil
+	}
+	var opts []sdklog.LoggerProviderOption
+	var errs []error
+	if limits.AttributeCountLimit != nil {
+		if *limits.AttributeCountLimit < 0 {
+			errs = append(errs, fmt.Errorf("invalid attribute count limit %d", *limits.AttributeCountLimit))
+		} else {
+			opts = append(opts, sdklog.WithAttributeCountLimit(*limits.AttributeCountLimit))
+		}
+	}
+	if limits.AttributeValueLengthLimit != nil {
+		if *limits.AttributeValueLengthLimit < 0 {
+			errs = append(errs, fmt.Errorf("invalid attribute value length limit %d", *limits.AttributeValueLengthLimit))
+		} else {
+			opts = append(opts, sdklog.WithAttributeValueLengthLimit(*limits.AttributeValueLengthLimit))
+		}
+	}
+	return opts, errs
+}
+
+func logExporter(ctx context.Context, exporter LogRecordExporter) (sdklog.Exporter, error) {
+	if exporter.Console != nil && exporter.OTLP != nil {
+		return nil, errors.New("must not specify multiple exporters")
+	}
+
+	if exporter.Console != nil {
+		return stdoutlog.New(
+			stdoutlog.WithWriter(os.Stdout),
+			stdoutlog.WithPrettyPrint(),
+		)
+	}
+	if exporter.OTLP != nil {
+		protocol, err := otlpExporterProtocol(exporter.OTLP.Protocol, exporter.OTLP.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		switch protocol {
+		case protocolProtobufHTTP:
+			return otlpHTTPLogExporter(ctx, exporter.OTLP)
+		default:
+			return nil, fmt.Errorf("unsupported protocol %q", protocol)
+		}
+	}
+	return nil, errors.New("no valid log exporter")
+}
+
+func logProcessor(ctx context.Context, processor LogRecordProcessor) (sdklog.Processor, error) {
+	if processor.Batch != nil && processor.Simple != nil {
+		return nil, errors.New("must not specify multiple log processor type")
+	}
+	if processor.Batch != nil {
+		exp, err := logExporter(ctx, processor.Batch.Exporter)
+		if err != nil {
+			return nil, err
+		}
+		return batchLogProcessor(processor.Batch, exp)
+	}
+	if processor.Simple != nil {
+		exp, err := logExporter(ctx, processor.Simple.Exporter)
+		if err != nil {
+			return nil, err
+		}
+		return sdklog.NewSimpleProcessor(exp), nil
+	}
+	return nil, fmt.Errorf("unsupported log processor type %v", processor)
+}
+
+func otlpHTTPLogExporter(ctx context.Context, otlpConfig *OTLP) (sdklog.Exporter, error) {
+	var opts []otlploghttp.Option
+
+	if len(otlpConfig.Endpoint) > 0 {
+		u, err := url.ParseRequestURI(otlpConfig.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlploghttp.WithEndpoint(u.Host))
+
+		if u.Scheme == "http" {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+		if len(u.Path) > 0 {
+			opts = append(opts, otlploghttp.WithURLPath(u.Path))
+		}
+	}
+	if otlpConfig.Compression != nil {
+		switch *otlpConfig.Compression {
+		case compressionGzip:
+			opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+		case compressionNone:
+			opts = append(opts, otlploghttp.WithCompression(otlploghttp.NoCompression))
+		default:
+			return nil, fmt.Errorf("unsupported compression %q", *otlpConfig.Compression)
+		}
+	}
+	if otlpConfig.Timeout != nil && *otlpConfig.Timeout > 0 {
+		opts = append(opts, otlploghttp.WithTimeout(time.Millisecond*time.Duration(*otlpConfig.Timeout)))
+	}
+	if len(otlpConfig.Headers) > 0 {
+		opts = append(opts, otlploghttp.WithHeaders(otlpConfig.Headers))
+	}
+	if otlpConfig.Retry != nil {
+		retry, err := otlpRetry(otlpConfig.Retry)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlploghttp.WithRetry(otlploghttp.RetryConfig(retry)))
+	}
+
+	if isDryRun(ctx) {
+		return dryRunLogExporter{}, nil
+	}
+	return otlploghttp.New(ctx, opts...)
+}
+
+// defaultLogMaxQueueSize is the default maximum queue size of the batch
+// processor of the log SDK.
+const defaultLogMaxQueueSize = 2048
+
+func batchLogProcessor(blp *BatchLogRecordProcessor, exp sdklog.Exporter) (*sdklog.BatchProcessor, error) {
+	var opts []sdklog.BatchProcessorOption
+	if blp.ExportTimeout != nil {
+		if *blp.ExportTimeout <= 0 {
+			return nil, fmt.Errorf("invalid export timeout %d", *blp.ExportTimeout)
+		}
+		opts = append(opts, sdklog.WithExportTimeout(time.Millisecond*time.Duration(*blp.ExportTimeout)))
+	}
+	if blp.MaxExportBatchSize != nil {
+		if *blp.MaxExportBatchSize <= 0 {
+			return nil, fmt.Errorf("invalid batch size %d", *blp.MaxExportBatchSize)
+		}
+		opts = append(opts, sdklog.WithExportMaxBatchSize(*blp.MaxExportBatchSize))
+	}
+	if blp.MaxQueueSize != nil {
+		if *blp.MaxQueueSize <= 0 {
+			return nil, fmt.Errorf("invalid queue size %d", *blp.MaxQueueSize)
+		}
+		opts = append(opts, sdklog.WithMaxQueueSize(*blp.MaxQueueSize))
+	}
+	if blp.ScheduleDelay != nil {
+		if *blp.ScheduleDelay <= 0 {
+			return nil, fmt.Errorf("invalid schedule delay %d", *blp.ScheduleDelay)
+		}
+		opts = append(opts, sdklog.WithExportInterval(time.Millisecond*time.Duration(*blp.ScheduleDelay)))
+	}
+	queueSize := defaultLogMaxQueueSize
+	if blp.MaxQueueSize != nil {
+		queueSize = *blp.MaxQueueSize
+	}
+	if blp.MaxExportBatchSize != nil && *blp.MaxExportBatchSize > queueSize {
+		return nil, fmt.Errorf("invalid batch size %d: greater than the queue size %d", *blp.MaxExportBatchSize, queueSize)
+	}
+	return sdklog.NewBatchProcessor(exp, opts...), nil
+}
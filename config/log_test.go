@@ -0,0 +1,441 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestLoggerProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          configOptions
+		wantProvider log.LoggerProvider
+		wantErr      error
+	}{
+		{
+			name:         "no-logger-provider-configured",
+			wantProvider: noop.NewLoggerProvider(),
+		},
+		{
+			name: "error-in-config",
+			cfg: configOptions{
+				opentelemetryConfig: OpenTelemetryConfiguration{
+					LoggerProvider: &LoggerProvider{
+						Processors: []LogRecordProcessor{
+							{
+								Batch:  &BatchLogRecordProcessor{},
+								Simple: &SimpleLogRecordProcessor{},
+							},
+						},
+					},
+				},
+			},
+			wantProvider: noop.NewLoggerProvider(),
+			wantErr:      errors.Join(errors.New("must not specify multiple log processor type")),
+		},
+		{
+			name: "multiple-errors-in-config",
+			cfg: configOptions{
+				opentelemetryConfig: OpenTelemetryConfiguration{
+					LoggerProvider: &LoggerProvider{
+						Processors: []LogRecordProcessor{
+							{
+								Batch:  &BatchLogRecordProcessor{},
+								Simple: &SimpleLogRecordProcessor{},
+							},
+							{
+								Simple: &SimpleLogRecordProcessor{
+									Exporter: LogRecordExporter{
+										Console: Console{},
+										OTLP:    &OTLP{},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantProvider: noop.NewLoggerProvider(),
+			wantErr:      errors.Join(errors.New("must not specify multiple log processor type"), errors.New("must not specify multiple exporters")),
+		},
+	}
+	for _, tt := range tests {
+		lp, shutdown, err := loggerProvider(tt.cfg, resource.Default())
+		require.Equal(t, tt.wantProvider, lp)
+		assert.Equal(t, tt.wantErr, err)
+		require.NoError(t, shutdown(context.Background()))
+	}
+}
+
+func TestLogProcessor(t *testing.T) {
+	testCases := []struct {
+		name          string
+		processor     LogRecordProcessor
+		wantErr       error
+		wantProcessor sdklog.Processor
+	}{
+		{
+			name:    "no processor",
+			wantErr: errors.New("unsupported log processor type {<nil> <nil>}"),
+		},
+		{
+			name: "multiple processor types",
+			processor: LogRecordProcessor{
+				Batch:  &BatchLogRecordProcessor{},
+				Simple: &SimpleLogRecordProcessor{},
+			},
+			wantErr: errors.New("must not specify multiple log processor type"),
+		},
+		{
+			name: "batch processor invalid exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{},
+			},
+			wantErr: errors.New("no valid log exporter"),
+		},
+		{
+			name: "batch processor invalid queue size console exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					MaxQueueSize: ptr(-1),
+					Exporter: LogRecordExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid queue size -1"),
+		},
+		{
+			name: "batch processor batch size greater than queue size console exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					MaxExportBatchSize: ptr(20),
+					MaxQueueSize:       ptr(10),
+					Exporter: LogRecordExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid batch size 20: greater than the queue size 10"),
+		},
+		{
+			name: "batch processor zero batch size console exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					MaxExportBatchSize: ptr(0),
+					Exporter: LogRecordExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid batch size 0"),
+		},
+		{
+			name: "batch processor zero export timeout console exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					ExportTimeout: ptr(0),
+					Exporter: LogRecordExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid export timeout 0"),
+		},
+		{
+			name: "batch processor zero queue size console exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					MaxQueueSize: ptr(0),
+					Exporter: LogRecordExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid queue size 0"),
+		},
+		{
+			name: "batch processor zero schedule delay console exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					ScheduleDelay: ptr(0),
+					Exporter: LogRecordExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid schedule delay 0"),
+		},
+		{
+			name: "batch processor batch size greater than the default queue size console exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					MaxExportBatchSize: ptr(4096),
+					Exporter: LogRecordExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid batch size 4096: greater than the queue size 2048"),
+		},
+		{
+			name: "batch processor console exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					MaxExportBatchSize: ptr(1),
+					MaxQueueSize:       ptr(10),
+					ScheduleDelay:      ptr(100),
+					ExportTimeout:      ptr(1000),
+					Exporter: LogRecordExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantProcessor: &sdklog.BatchProcessor{},
+		},
+		{
+			name: "batch processor otlp/http exporter",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					Exporter: LogRecordExporter{
+						OTLP: &OTLP{
+							Protocol:    "http/protobuf",
+							Endpoint:    "http://localhost:4318/v1/logs",
+							Compression: ptr("gzip"),
+							Timeout:     ptr(1000),
+							Headers: map[string]string{
+								"test": "test1",
+							},
+						},
+					},
+				},
+			},
+			wantProcessor: &sdklog.BatchProcessor{},
+		},
+		{
+			name: "batch processor otlp/http exporter invalid compression",
+			processor: LogRecordProcessor{
+				Batch: &BatchLogRecordProcessor{
+					Exporter: LogRecordExporter{
+						OTLP: &OTLP{
+							Protocol:    "http/protobuf",
+							Compression: ptr("invalid"),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("unsupported compression \"invalid\""),
+		},
+		{
+			name: "simple processor otlp/grpc exporter",
+			processor: LogRecordProcessor{
+				Simple: &SimpleLogRecordProcessor{
+					Exporter: LogRecordExporter{
+						OTLP: &OTLP{
+							Protocol: "grpc/protobuf",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("unsupported protocol \"grpc/protobuf\""),
+		},
+		{
+			name: "simple processor console exporter",
+			processor: LogRecordProcessor{
+				Simple: &SimpleLogRecordProcessor{
+					Exporter: LogRecordExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantProcessor: &sdklog.SimpleProcessor{},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := logProcessor(context.Background(), tt.processor)
+			require.Equal(t, tt.wantErr, err)
+			if tt.wantProcessor == nil {
+				require.Nil(t, got)
+			} else {
+				require.Equal(t, reflect.TypeOf(tt.wantProcessor), reflect.TypeOf(got))
+				require.NoError(t, got.Shutdown(context.Background()))
+			}
+		})
+	}
+}
+
+// batchSizeExporter records the size of the exported batches.
+type batchSizeExporter struct {
+	mu    sync.Mutex
+	sizes []int
+}
+
+func (e *batchSizeExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sizes = append(e.sizes, len(records))
+	return nil
+}
+
+func (e *batchSizeExporter) Shutdown(context.Context) error   { return nil }
+func (e *batchSizeExporter) ForceFlush(context.Context) error { return nil }
+
+func TestBatchLogProcessorParameters(t *testing.T) {
+	exp := &batchSizeExporter{}
+	p, err := batchLogProcessor(&BatchLogRecordProcessor{
+		ExportTimeout:      ptr(1000),
+		MaxExportBatchSize: ptr(2),
+		MaxQueueSize:       ptr(10),
+		// Only export on flush.
+		ScheduleDelay: ptr(int(time.Hour / time.Millisecond)),
+	}, exp)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, p.OnEmit(ctx, sdklog.Record{}))
+	}
+	require.NoError(t, p.ForceFlush(ctx))
+	require.NoError(t, p.Shutdown(ctx))
+
+	exp.mu.Lock()
+	defer exp.mu.Unlock()
+	var total int
+	for _, size := range exp.sizes {
+		assert.LessOrEqual(t, size, 2)
+		total += size
+	}
+	assert.Equal(t, 5, total)
+}
+
+type recordingLogProcessor struct {
+	records []sdklog.Record
+}
+
+func (p *recordingLogProcessor) OnEmit(_ context.Context, r sdklog.Record) error {
+	p.records = append(p.records, r.Clone())
+	return nil
+}
+
+func (p *recordingLogProcessor) Enabled(context.Context, sdklog.Record) bool { return true }
+func (p *recordingLogProcessor) Shutdown(context.Context) error              { return nil }
+func (p *recordingLogProcessor) ForceFlush(context.Context) error            { return nil }
+
+func TestLogRecordLimits(t *testing.T) {
+	two, negative := 2, -1
+
+	opts, errs := logRecordLimits(nil)
+	assert.Empty(t, opts)
+	assert.Empty(t, errs)
+
+	_, errs = logRecordLimits(&LogRecordLimits{
+		AttributeCountLimit:       &negative,
+		AttributeValueLengthLimit: &negative,
+	})
+	assert.Equal(t, []error{
+		errors.New("invalid attribute count limit -1"),
+		errors.New("invalid attribute value length limit -1"),
+	}, errs)
+
+	opts, errs = logRecordLimits(&LogRecordLimits{
+		AttributeCountLimit:       &two,
+		AttributeValueLengthLimit: &two,
+	})
+	require.Empty(t, errs)
+	p := &recordingLogProcessor{}
+	lp := sdklog.NewLoggerProvider(append(opts, sdklog.WithProcessor(p))...)
+
+	var record log.Record
+	record.AddAttributes(
+		log.String("a", "value"),
+		log.String("b", "value"),
+		log.String("c", "value"),
+	)
+	lp.Logger("test").Emit(context.Background(), record)
+
+	require.Len(t, p.records, 1)
+	got := p.records[0]
+	assert.Equal(t, 2, got.AttributesLen())
+	assert.Equal(t, 1, got.DroppedAttributes())
+	// The attribute value length limit is set on the records by the log SDK,
+	// which truncates the values from go.opentelemetry.io/otel/sdk/log v0.3.0.
+}
+
+func TestLoggerProviderInvalidLimits(t *testing.T) {
+	negative := -1
+	lp, shutdown, err := loggerProvider(configOptions{
+		opentelemetryConfig: OpenTelemetryConfiguration{
+			LoggerProvider: &LoggerProvider{
+				Limits: &LogRecordLimits{AttributeCountLimit: &negative},
+				Processors: []LogRecordProcessor{
+					{Simple: &SimpleLogRecordProcessor{Exporter: LogRecordExporter{Console: Console{}}}},
+				},
+			},
+		},
+	}, resource.Default())
+	assert.Equal(t, noop.NewLoggerProvider(), lp)
+	assert.Equal(t, errors.Join(errors.New("invalid attribute count limit -1")), err)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestNewSDKLoggerProviderFromYAML(t *testing.T) {
+	cfg, err := ParseYAML([]byte(`
+file_format: "0.2"
+logger_provider:
+  limits:
+    attribute_count_limit: 8
+  processors:
+    - simple:
+        exporter:
+          console: {}
+`))
+	require.NoError(t, err)
+
+	// The console exporter writes to the standard output it is created with.
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = stdout })
+	output := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		output <- string(b)
+	}()
+
+	sdk, err := NewSDK(WithOpenTelemetryConfiguration(*cfg))
+	os.Stdout = stdout
+	require.NoError(t, err)
+	require.IsType(t, &sdklog.LoggerProvider{}, sdk.LoggerProvider())
+
+	var record log.Record
+	record.SetSeverity(log.SeverityInfo)
+	record.SetSeverityText("INFO")
+	record.SetBody(log.StringValue("hello from declarative config"))
+	sdk.LoggerProvider().Logger("test").Emit(context.Background(), record)
+
+	require.NoError(t, sdk.Shutdown(context.Background()))
+	require.NoError(t, w.Close())
+	got := <-output
+	assert.Contains(t, got, `"Severity": 9`)
+	assert.Contains(t, got, `"SeverityText": "INFO"`)
+	assert.Contains(t, got, `"Name": "test"`)
+}
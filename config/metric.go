@@ -12,18 +12,21 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
@@ -45,6 +48,14 @@ func meterProvider(cfg configOptions, res *resource.Resource) (metric.MeterProvi
 			errs = append(errs, err)
 		}
 	}
+	for _, v := range cfg.opentelemetryConfig.MeterProvider.Views {
+		sv, err := view(v)
+		if err == nil {
+			opts = append(opts, sdkmetric.WithView(sv))
+		} else {
+			errs = append(errs, err)
+		}
+	}
 	if len(errs) > 0 {
 		return noop.NewMeterProvider(), noopShutdown, errors.Join(errs...)
 	}
@@ -92,15 +103,18 @@ func periodicExporter(ctx context.Context, exporter MetricExporter, opts ...sdkm
 		return sdkmetric.NewPeriodicReader(exp, opts...), nil
 	}
 	if exporter.OTLP != nil {
-		var err error
+		protocol, err := otlpExporterProtocol(exporter.OTLP.Protocol, exporter.OTLP.Endpoint)
+		if err != nil {
+			return nil, err
+		}
 		var exp sdkmetric.Exporter
-		switch exporter.OTLP.Protocol {
+		switch protocol {
 		case protocolProtobufHTTP:
 			exp, err = otlpHTTPMetricExporter(ctx, exporter.OTLP)
 		case protocolProtobufGRPC:
 			exp, err = otlpGRPCMetricExporter(ctx, exporter.OTLP)
 		default:
-			return nil, fmt.Errorf("unsupported protocol %q", exporter.OTLP.Protocol)
+			return nil, fmt.Errorf("unsupported protocol %q", protocol)
 		}
 		if err != nil {
 			return nil, err
@@ -143,7 +157,17 @@ func otlpHTTPMetricExporter(ctx context.Context, otlpConfig *OTLPMetric) (sdkmet
 	if len(otlpConfig.Headers) > 0 {
 		opts = append(opts, otlpmetrichttp.WithHeaders(otlpConfig.Headers))
 	}
+	if otlpConfig.Retry != nil {
+		retry, err := otlpRetry(otlpConfig.Retry)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig(retry)))
+	}
 
+	if isDryRun(ctx) {
+		return dryRunMetricExporter{}, nil
+	}
 	return otlpmetrichttp.New(ctx, opts...)
 }
 
@@ -186,7 +210,17 @@ func otlpGRPCMetricExporter(ctx context.Context, otlpConfig *OTLPMetric) (sdkmet
 	if len(otlpConfig.Headers) > 0 {
 		opts = append(opts, otlpmetricgrpc.WithHeaders(otlpConfig.Headers))
 	}
+	if otlpConfig.Retry != nil {
+		retry, err := otlpRetry(otlpConfig.Retry)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig(retry)))
+	}
 
+	if isDryRun(ctx) {
+		return dryRunMetricExporter{}, nil
+	}
 	return otlpmetricgrpc.New(ctx, opts...)
 }
 
@@ -230,6 +264,9 @@ func prometheusReader(ctx context.Context, prometheusConfig *Prometheus) (sdkmet
 	if err != nil {
 		return nil, fmt.Errorf("error creating otel prometheus exporter: %w", err)
 	}
+	if isDryRun(ctx) {
+		return reader, nil
+	}
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return nil, errors.Join(
@@ -258,3 +295,200 @@ func (rws readerWithServer) Shutdown(ctx context.Context) error {
 		rws.server.Shutdown(ctx),
 	)
 }
+
+func view(v View) (sdkmetric.View, error) {
+	if v.Selector == nil {
+		return nil, errors.New("view: no selector provided")
+	}
+	inst, err := instrument(*v.Selector)
+	if err != nil {
+		return nil, err
+	}
+	s, err := stream(v.Stream)
+	if err != nil {
+		return nil, err
+	}
+	if s.Name != "" && strings.ContainsAny(inst.Name, "*?") {
+		return nil, errors.New("view: stream name must not be set for a selector with a wildcard instrument name")
+	}
+	if s.Aggregation != nil && v.Selector.InstrumentType != nil {
+		if err := aggregationCompatible(inst.Kind, s.Aggregation); err != nil {
+			return nil, err
+		}
+	}
+	return sdkmetric.NewView(inst, s), nil
+}
+
+func instrument(vs ViewSelector) (sdkmetric.Instrument, error) {
+	kind, err := instrumentKind(vs.InstrumentType)
+	if err != nil {
+		return sdkmetric.Instrument{}, err
+	}
+	inst := sdkmetric.Instrument{
+		Name: strOrEmpty(vs.InstrumentName),
+		Unit: strOrEmpty(vs.Unit),
+		Kind: kind,
+		Scope: instrumentation.Scope{
+			Name:      strOrEmpty(vs.MeterName),
+			Version:   strOrEmpty(vs.MeterVersion),
+			SchemaURL: strOrEmpty(vs.MeterSchemaUrl),
+		},
+	}
+	if inst.Name == "" && inst.Unit == "" && inst.Kind == 0 && inst.Scope == (instrumentation.Scope{}) {
+		return sdkmetric.Instrument{}, errors.New("view_selector: empty selector not supported")
+	}
+	return inst, nil
+}
+
+func instrumentKind(vsit *ViewSelectorInstrumentType) (sdkmetric.InstrumentKind, error) {
+	if vsit == nil {
+		// Equivalent to instrumentKindUndefined.
+		return sdkmetric.InstrumentKind(0), nil
+	}
+
+	switch *vsit {
+	case ViewSelectorInstrumentTypeCounter:
+		return sdkmetric.InstrumentKindCounter, nil
+	case ViewSelectorInstrumentTypeUpDownCounter:
+		return sdkmetric.InstrumentKindUpDownCounter, nil
+	case ViewSelectorInstrumentTypeHistogram:
+		return sdkmetric.InstrumentKindHistogram, nil
+	case ViewSelectorInstrumentTypeObservableCounter:
+		return sdkmetric.InstrumentKindObservableCounter, nil
+	case ViewSelectorInstrumentTypeObservableUpDownCounter:
+		return sdkmetric.InstrumentKindObservableUpDownCounter, nil
+	case ViewSelectorInstrumentTypeObservableGauge:
+		return sdkmetric.InstrumentKindObservableGauge, nil
+	}
+
+	return sdkmetric.InstrumentKind(0), fmt.Errorf("view_selector: unsupported instrument type %q", *vsit)
+}
+
+func stream(vs *ViewStream) (sdkmetric.Stream, error) {
+	if vs == nil {
+		return sdkmetric.Stream{}, nil
+	}
+
+	agg, err := aggregation(vs.Aggregation)
+	if err != nil {
+		return sdkmetric.Stream{}, err
+	}
+	return sdkmetric.Stream{
+		Name:            strOrEmpty(vs.Name),
+		Description:     strOrEmpty(vs.Description),
+		Aggregation:     agg,
+		AttributeFilter: attributeKeysFilter(vs.AttributeKeys),
+	}, nil
+}
+
+// attributeKeysFilter returns a filter keeping only the attributes with one
+// of keys, or nil to keep all the attributes if keys is empty.
+func attributeKeysFilter(keys []string) attribute.Filter {
+	if len(keys) == 0 {
+		return nil
+	}
+	allowed := make(map[attribute.Key]struct{}, len(keys))
+	for _, k := range keys {
+		allowed[attribute.Key(k)] = struct{}{}
+	}
+	return func(kv attribute.KeyValue) bool {
+		_, ok := allowed[kv.Key]
+		return ok
+	}
+}
+
+func aggregation(aggr *ViewStreamAggregation) (sdkmetric.Aggregation, error) {
+	if aggr == nil {
+		return nil, nil
+	}
+
+	var aggs []sdkmetric.Aggregation
+	if aggr.Base2ExponentialBucketHistogram != nil {
+		// Defaults of the configuration schema.
+		maxSize, maxScale := 160, 20
+		if aggr.Base2ExponentialBucketHistogram.MaxSize != nil {
+			maxSize = *aggr.Base2ExponentialBucketHistogram.MaxSize
+		}
+		if aggr.Base2ExponentialBucketHistogram.MaxScale != nil {
+			maxScale = *aggr.Base2ExponentialBucketHistogram.MaxScale
+		}
+		if maxSize <= 0 {
+			return nil, fmt.Errorf("view_stream: invalid base2_exponential_bucket_histogram max_size %d", maxSize)
+		}
+		if maxScale < -10 || maxScale > 20 {
+			return nil, fmt.Errorf("view_stream: invalid base2_exponential_bucket_histogram max_scale %d", maxScale)
+		}
+		agg := sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  int32(maxSize),
+			MaxScale: int32(maxScale),
+			// Need to negate because config has the positive action RecordMinMax.
+			NoMinMax: !boolOrFalse(aggr.Base2ExponentialBucketHistogram.RecordMinMax),
+		}
+		aggs = append(aggs, agg)
+	}
+	if aggr.Default != nil {
+		aggs = append(aggs, sdkmetric.AggregationDefault{})
+	}
+	if aggr.Drop != nil {
+		aggs = append(aggs, sdkmetric.AggregationDrop{})
+	}
+	if aggr.ExplicitBucketHistogram != nil {
+		boundaries := aggr.ExplicitBucketHistogram.Boundaries
+		for i := 1; i < len(boundaries); i++ {
+			if boundaries[i] <= boundaries[i-1] {
+				return nil, errors.New("view_stream: explicit_bucket_histogram boundaries must be strictly increasing")
+			}
+		}
+		aggs = append(aggs, sdkmetric.AggregationExplicitBucketHistogram{
+			Boundaries: boundaries,
+			// Need to negate because config has the positive action RecordMinMax.
+			NoMinMax: !boolOrFalse(aggr.ExplicitBucketHistogram.RecordMinMax),
+		})
+	}
+	if aggr.LastValue != nil {
+		aggs = append(aggs, sdkmetric.AggregationLastValue{})
+	}
+	if aggr.Sum != nil {
+		aggs = append(aggs, sdkmetric.AggregationSum{})
+	}
+
+	switch len(aggs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return aggs[0], nil
+	default:
+		return nil, errors.New("view_stream: must not specify multiple aggregations")
+	}
+}
+
+// aggregationCompatible returns an error if agg cannot be used for the
+// instruments of kind, the sum aggregation is not supported for gauges and
+// the last value aggregation is only supported for gauges.
+func aggregationCompatible(kind sdkmetric.InstrumentKind, agg sdkmetric.Aggregation) error {
+	switch agg.(type) {
+	case sdkmetric.AggregationSum:
+		if kind == sdkmetric.InstrumentKindObservableGauge {
+			return errors.New("view: sum aggregation is not compatible with observable_gauge instruments")
+		}
+	case sdkmetric.AggregationLastValue:
+		if kind != sdkmetric.InstrumentKindObservableGauge {
+			return errors.New("view: last_value aggregation is only compatible with observable_gauge instruments")
+		}
+	}
+	return nil
+}
+
+func strOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func boolOrFalse(b *bool) bool {
+	if b == nil {
+		return false
+	}
+	return *b
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
@@ -20,6 +21,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -76,6 +78,18 @@ func TestMeterProvider(t *testing.T) {
 			wantProvider: noop.NewMeterProvider(),
 			wantErr:      errors.Join(errors.New("must not specify multiple metric reader type"), errors.New("must not specify multiple exporters")),
 		},
+		{
+			name: "invalid-view",
+			cfg: configOptions{
+				opentelemetryConfig: OpenTelemetryConfiguration{
+					MeterProvider: &MeterProvider{
+						Views: []View{{}},
+					},
+				},
+			},
+			wantProvider: noop.NewMeterProvider(),
+			wantErr:      errors.Join(errors.New("view: no selector provided")),
+		},
 	}
 	for _, tt := range tests {
 		mp, shutdown, err := meterProvider(tt.cfg, resource.Default())
@@ -476,3 +490,152 @@ func TestReader(t *testing.T) {
 		})
 	}
 }
+
+func TestView(t *testing.T) {
+	histogram := ViewSelectorInstrumentTypeHistogram
+	gauge := ViewSelectorInstrumentTypeObservableGauge
+	tests := []struct {
+		name    string
+		view    View
+		wantErr string
+	}{
+		{
+			name:    "no-selector",
+			view:    View{},
+			wantErr: "view: no selector provided",
+		},
+		{
+			name:    "empty-selector",
+			view:    View{Selector: &ViewSelector{}},
+			wantErr: "view_selector: empty selector not supported",
+		},
+		{
+			name: "unsupported-instrument-type",
+			view: View{Selector: &ViewSelector{
+				InstrumentType: ptr(ViewSelectorInstrumentType("gauge")),
+			}},
+			wantErr: `view_selector: unsupported instrument type "gauge"`,
+		},
+		{
+			name: "multiple-aggregations",
+			view: View{
+				Selector: &ViewSelector{InstrumentName: ptr("requests")},
+				Stream: &ViewStream{Aggregation: &ViewStreamAggregation{
+					Drop: ViewStreamAggregationDrop{},
+					Sum:  ViewStreamAggregationSum{},
+				}},
+			},
+			wantErr: "view_stream: must not specify multiple aggregations",
+		},
+		{
+			name: "unsorted-boundaries",
+			view: View{
+				Selector: &ViewSelector{InstrumentName: ptr("latency")},
+				Stream: &ViewStream{Aggregation: &ViewStreamAggregation{
+					ExplicitBucketHistogram: &ViewStreamAggregationExplicitBucketHistogram{
+						Boundaries: []float64{10, 5},
+					},
+				}},
+			},
+			wantErr: "view_stream: explicit_bucket_histogram boundaries must be strictly increasing",
+		},
+		{
+			name: "invalid-max-scale",
+			view: View{
+				Selector: &ViewSelector{InstrumentName: ptr("latency")},
+				Stream: &ViewStream{Aggregation: &ViewStreamAggregation{
+					Base2ExponentialBucketHistogram: &ViewStreamAggregationBase2ExponentialBucketHistogram{
+						MaxScale: ptr(21),
+					},
+				}},
+			},
+			wantErr: "view_stream: invalid base2_exponential_bucket_histogram max_scale 21",
+		},
+		{
+			name: "wildcard-with-stream-name",
+			view: View{
+				Selector: &ViewSelector{InstrumentName: ptr("http.*")},
+				Stream:   &ViewStream{Name: ptr("renamed")},
+			},
+			wantErr: "view: stream name must not be set for a selector with a wildcard instrument name",
+		},
+		{
+			name: "last-value-for-histogram",
+			view: View{
+				Selector: &ViewSelector{InstrumentType: &histogram},
+				Stream: &ViewStream{Aggregation: &ViewStreamAggregation{
+					LastValue: ViewStreamAggregationLastValue{},
+				}},
+			},
+			wantErr: "view: last_value aggregation is only compatible with observable_gauge instruments",
+		},
+		{
+			name: "sum-for-gauge",
+			view: View{
+				Selector: &ViewSelector{InstrumentType: &gauge},
+				Stream: &ViewStream{Aggregation: &ViewStreamAggregation{
+					Sum: ViewStreamAggregationSum{},
+				}},
+			},
+			wantErr: "view: sum aggregation is not compatible with observable_gauge instruments",
+		},
+		{
+			name: "valid",
+			view: View{
+				Selector: &ViewSelector{
+					InstrumentName: ptr("latency"),
+					InstrumentType: &histogram,
+					MeterName:      ptr("meter"),
+				},
+				Stream: &ViewStream{
+					Name: ptr("renamed"),
+					Aggregation: &ViewStreamAggregation{
+						ExplicitBucketHistogram: &ViewStreamAggregationExplicitBucketHistogram{
+							Boundaries:   []float64{1, 5, 10},
+							RecordMinMax: ptr(true),
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := view(tt.view)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, v)
+		})
+	}
+}
+
+func TestViewAttributeKeys(t *testing.T) {
+	v, err := view(View{
+		Selector: &ViewSelector{InstrumentName: ptr("requests")},
+		Stream: &ViewStream{
+			AttributeKeys: []string{"http.method"},
+		},
+	})
+	require.NoError(t, err)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithView(v))
+	counter, err := mp.Meter("test").Int64Counter("requests")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("http.method", "GET"),
+		attribute.String("user.id", "42"),
+	))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, attribute.NewSet(attribute.String("http.method", "GET")), sum.DataPoints[0].Attributes)
+}
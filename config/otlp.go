@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config // import "go.opentelemetry.io/contrib/config"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// otlpPortGRPC and otlpPortHTTP are the default ports of the OTLP
+	// receivers.
+	otlpPortGRPC = "4317"
+	otlpPortHTTP = "4318"
+)
+
+// OTLPRetry configures the retry of the exports of an OTLP exporter failing
+// with a retryable error, with an exponential backoff. The intervals are in
+// milliseconds. The unset fields keep the defaults of the exporters: the
+// retry is enabled, the initial interval is 5s, the maximum interval is 30s,
+// and the maximum elapsed time is 1m.
+type OTLPRetry struct {
+	// Enabled enables the retry of the failed exports.
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty" mapstructure:"enabled,omitempty"`
+
+	// InitialInterval is the time to wait after the first failure before
+	// retrying.
+	InitialInterval *int `json:"initial_interval,omitempty" yaml:"initial_interval,omitempty" mapstructure:"initial_interval,omitempty"`
+
+	// MaxElapsedTime is the maximum time spent trying to export a batch,
+	// including the retries. The batch is dropped once it is reached.
+	MaxElapsedTime *int `json:"max_elapsed_time,omitempty" yaml:"max_elapsed_time,omitempty" mapstructure:"max_elapsed_time,omitempty"`
+
+	// MaxInterval is the upper bound of the backoff interval between the
+	// retries.
+	MaxInterval *int `json:"max_interval,omitempty" yaml:"max_interval,omitempty" mapstructure:"max_interval,omitempty"`
+}
+
+// otlpRetryConfig is the retry configuration of the OTLP exporters. It has
+// the fields of their RetryConfig, and can be converted to them.
+type otlpRetryConfig struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// defaultOTLPRetryConfig is the default retry configuration of the OTLP
+// exporters.
+var defaultOTLPRetryConfig = otlpRetryConfig{
+	Enabled:         true,
+	InitialInterval: 5 * time.Second,
+	MaxInterval:     30 * time.Second,
+	MaxElapsedTime:  time.Minute,
+}
+
+// otlpRetry returns the retry configuration of the OTLP exporters configured
+// with retry, the defaults for its unset fields. An error is returned if an
+// interval is negative, or if the initial interval is greater than the
+// maximum interval.
+func otlpRetry(retry *OTLPRetry) (otlpRetryConfig, error) {
+	cfg := defaultOTLPRetryConfig
+	if retry.Enabled != nil {
+		cfg.Enabled = *retry.Enabled
+	}
+	var errs []error
+	interval := func(name string, ms *int, d *time.Duration) {
+		if ms == nil {
+			return
+		}
+		if *ms < 0 {
+			errs = append(errs, fmt.Errorf("invalid retry %s %d", name, *ms))
+			return
+		}
+		*d = time.Millisecond * time.Duration(*ms)
+	}
+	interval("initial interval", retry.InitialInterval, &cfg.InitialInterval)
+	interval("max interval", retry.MaxInterval, &cfg.MaxInterval)
+	interval("max elapsed time", retry.MaxElapsedTime, &cfg.MaxElapsedTime)
+	if err := errors.Join(errs...); err != nil {
+		return otlpRetryConfig{}, err
+	}
+	if cfg.InitialInterval > cfg.MaxInterval {
+		return otlpRetryConfig{}, fmt.Errorf("invalid retry initial interval %s: greater than the max interval %s", cfg.InitialInterval, cfg.MaxInterval)
+	}
+	return cfg, nil
+}
+
+// otlpExporterProtocol returns the protocol of the OTLP exporter configured
+// with protocol and endpoint. When protocol is empty, it is inferred from
+// endpoint, see inferOTLPProtocol. An error is returned if it cannot be
+// inferred. A warning is passed to the global error handler if an explicit
+// protocol conflicts with the default OTLP port of endpoint, for example
+// http/protobuf with a :4317 endpoint.
+func otlpExporterProtocol(protocol, endpoint string) (string, error) {
+	inferred := inferOTLPProtocol(endpoint)
+	if protocol == "" {
+		if inferred == "" {
+			return "", fmt.Errorf("unable to infer the protocol of endpoint %q", endpoint)
+		}
+		return inferred, nil
+	}
+	if p := endpointPort(endpoint); (p == otlpPortGRPC && protocol == protocolProtobufHTTP) ||
+		(p == otlpPortHTTP && protocol == protocolProtobufGRPC) {
+		otel.Handle(fmt.Errorf("the OTLP exporter protocol %q conflicts with the port of endpoint %q", protocol, endpoint))
+	}
+	return protocol, nil
+}
+
+// otlpProtocol returns protocol, or the protocol inferred from endpoint if it
+// is empty.
+func otlpProtocol(protocol, endpoint string) string {
+	if protocol != "" {
+		return protocol
+	}
+	return inferOTLPProtocol(endpoint)
+}
+
+// inferOTLPProtocol infers the protocol of an OTLP exporter from its
+// endpoint: the default OTLP ports 4317 and 4318 are gRPC and HTTP
+// respectively, otherwise an endpoint with an http or https scheme is HTTP and
+// an endpoint without scheme (host:port) is gRPC. An empty string is returned
+// if endpoint is empty or invalid.
+func inferOTLPProtocol(endpoint string) string {
+	switch endpointPort(endpoint) {
+	case otlpPortGRPC:
+		return protocolProtobufGRPC
+	case otlpPortHTTP:
+		return protocolProtobufHTTP
+	}
+	if !strings.Contains(endpoint, "://") {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			return ""
+		}
+		return protocolProtobufGRPC
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return protocolProtobufHTTP
+	}
+	return ""
+}
+
+// endpointPort returns the port of endpoint, which is either a URL or a
+// host:port pair. An empty string is returned if it has no port.
+func endpointPort(endpoint string) string {
+	if !strings.Contains(endpoint, "://") {
+		_, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return ""
+		}
+		return port
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	return u.Port()
+}
@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInferOTLPProtocol(t *testing.T) {
+	for endpoint, want := range map[string]string{
+		"https://localhost:4318":          protocolProtobufHTTP,
+		"http://localhost:4318/v1/traces": protocolProtobufHTTP,
+		"localhost:4318":                  protocolProtobufHTTP,
+		"localhost:4317":                  protocolProtobufGRPC,
+		"http://localhost:4317":           protocolProtobufGRPC,
+		"https://collector.example.com":   protocolProtobufHTTP,
+		"http://localhost:8080":           protocolProtobufHTTP,
+		"localhost:8080":                  protocolProtobufGRPC,
+		"unix:///tmp/otlp.sock":           "",
+		"localhost":                       "",
+		"":                                "",
+	} {
+		assert.Equal(t, want, inferOTLPProtocol(endpoint), endpoint)
+	}
+}
+
+func TestOTLPExporterProtocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol string
+		endpoint string
+		want     string
+		wantErr  string
+		wantWarn bool
+	}{
+		{
+			name:     "inferred http",
+			endpoint: "https://localhost:4318",
+			want:     protocolProtobufHTTP,
+		},
+		{
+			name:     "inferred grpc",
+			endpoint: "localhost:4317",
+			want:     protocolProtobufGRPC,
+		},
+		{
+			name:     "not inferred",
+			endpoint: "localhost",
+			wantErr:  `unable to infer the protocol of endpoint "localhost"`,
+		},
+		{
+			name:     "explicit override",
+			protocol: protocolProtobufGRPC,
+			endpoint: "https://localhost:8080",
+			want:     protocolProtobufGRPC,
+		},
+		{
+			name:     "explicit http conflict",
+			protocol: protocolProtobufHTTP,
+			endpoint: "localhost:4317",
+			want:     protocolProtobufHTTP,
+			wantWarn: true,
+		},
+		{
+			name:     "explicit grpc conflict",
+			protocol: protocolProtobufGRPC,
+			endpoint: "http://localhost:4318",
+			want:     protocolProtobufGRPC,
+			wantWarn: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warned bool
+			otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) { warned = true }))
+			t.Cleanup(func() { otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {})) })
+
+			got, err := otlpExporterProtocol(tt.protocol, tt.endpoint)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantWarn, warned)
+		})
+	}
+}
+
+func TestOTLPRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		retry   OTLPRetry
+		want    otlpRetryConfig
+		wantErr error
+	}{
+		{
+			name: "defaults",
+			want: defaultOTLPRetryConfig,
+		},
+		{
+			name: "custom",
+			retry: OTLPRetry{
+				Enabled:         ptr(true),
+				InitialInterval: ptr(100),
+				MaxInterval:     ptr(1000),
+				MaxElapsedTime:  ptr(10000),
+			},
+			want: otlpRetryConfig{
+				Enabled:         true,
+				InitialInterval: 100 * time.Millisecond,
+				MaxInterval:     time.Second,
+				MaxElapsedTime:  10 * time.Second,
+			},
+		},
+		{
+			name:  "disabled",
+			retry: OTLPRetry{Enabled: ptr(false)},
+			want: otlpRetryConfig{
+				InitialInterval: 5 * time.Second,
+				MaxInterval:     30 * time.Second,
+				MaxElapsedTime:  time.Minute,
+			},
+		},
+		{
+			name: "negative intervals",
+			retry: OTLPRetry{
+				InitialInterval: ptr(-1),
+				MaxInterval:     ptr(-2),
+				MaxElapsedTime:  ptr(-3),
+			},
+			wantErr: errors.Join(
+				errors.New("invalid retry initial interval -1"),
+				errors.New("invalid retry max interval -2"),
+				errors.New("invalid retry max elapsed time -3"),
+			),
+		},
+		{
+			name:    "initial interval greater than max interval",
+			retry:   OTLPRetry{InitialInterval: ptr(60000)},
+			wantErr: errors.New("invalid retry initial interval 1m0s: greater than the max interval 30s"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := otlpRetry(&tt.retry)
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOTLPRetryExport(t *testing.T) {
+	// The server fails the first failures requests of each export.
+	const failures = 2
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	export := func(t *testing.T, retry *OTLPRetry) (int64, error) {
+		requests.Store(0)
+		exp, err := spanExporter(context.Background(), SpanExporter{
+			OTLP: &OTLP{
+				Protocol: protocolProtobufHTTP,
+				Endpoint: srv.URL,
+				Retry:    retry,
+			},
+		})
+		require.NoError(t, err)
+		stubs := tracetest.SpanStubs{{Name: "span"}}
+		err = exp.ExportSpans(context.Background(), stubs.Snapshots())
+		require.NoError(t, exp.Shutdown(context.Background()))
+		return requests.Load(), err
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		got, err := export(t, &OTLPRetry{
+			InitialInterval: ptr(1),
+			MaxInterval:     ptr(1),
+			MaxElapsedTime:  ptr(60000),
+		})
+		assert.NoError(t, err, "the failed export is retried")
+		assert.Equal(t, int64(failures+1), got)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		got, err := export(t, &OTLPRetry{Enabled: ptr(false)})
+		assert.Error(t, err)
+		assert.Equal(t, int64(1), got)
+	})
+}
+
+func TestParseYAMLOTLPRetry(t *testing.T) {
+	cfg, err := ParseYAML([]byte(`
+file_format: "0.1"
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: http://localhost:4318
+            retry:
+              enabled: true
+              initial_interval: 100
+              max_interval: 1000
+              max_elapsed_time: 10000
+`))
+	require.NoError(t, err)
+	want := &OTLPRetry{
+		Enabled:         ptr(true),
+		InitialInterval: ptr(100),
+		MaxInterval:     ptr(1000),
+		MaxElapsedTime:  ptr(10000),
+	}
+	assert.Equal(t, want, cfg.TracerProvider.Processors[0].Batch.Exporter.OTLP.Retry)
+}
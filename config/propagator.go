@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config // import "go.opentelemetry.io/contrib/config"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/contrib/propagators/autoprop"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// propagator returns the TextMapPropagator composed of the propagators of
+// the composite list of cfg, in order. The names are the ones of the
+// OTEL_PROPAGATORS environment variable: tracecontext, baggage, b3, b3multi,
+// jaeger, xray, ottrace, and none, or the names registered with
+// autoprop.RegisterTextMapPropagator.
+//
+// A nil TextMapPropagator is returned if cfg is nil or has no composite
+// list. An error is returned if it contains an unknown name.
+func propagator(cfg *Propagator) (propagation.TextMapPropagator, error) {
+	if cfg == nil || len(cfg.Composite) == 0 {
+		return nil, nil
+	}
+	p, err := autoprop.TextMapPropagator(cfg.Composite...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid propagator: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPropagator(t *testing.T) {
+	testCases := []struct {
+		name       string
+		propagator *Propagator
+		wantFields []string
+		wantErr    string
+	}{
+		{
+			name: "no propagator",
+		},
+		{
+			name:       "empty composite",
+			propagator: &Propagator{},
+		},
+		{
+			name:       "tracecontext",
+			propagator: &Propagator{Composite: []string{"tracecontext"}},
+			wantFields: []string{"traceparent", "tracestate"},
+		},
+		{
+			name:       "composite",
+			propagator: &Propagator{Composite: []string{"tracecontext", "baggage", "b3"}},
+			wantFields: []string{"traceparent", "tracestate", "baggage", "x-b3-traceid", "x-b3-spanid", "x-b3-sampled", "x-b3-flags"},
+		},
+		{
+			name:       "none",
+			propagator: &Propagator{Composite: []string{"tracecontext", "none"}},
+			wantFields: []string{},
+		},
+		{
+			name:       "unknown",
+			propagator: &Propagator{Composite: []string{"tracecontext", "unknown"}},
+			wantErr:    "invalid propagator: unknown propagator: unknown",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := propagator(tt.propagator)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantFields == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.ElementsMatch(t, tt.wantFields, got.Fields())
+		})
+	}
+}
+
+func TestNewSDKPropagatorFromYAML(t *testing.T) {
+	global := otel.GetTextMapPropagator()
+	t.Cleanup(func() { otel.SetTextMapPropagator(global) })
+
+	cfg, err := ParseYAML([]byte(`
+file_format: "0.2"
+propagator:
+  composite: [tracecontext, baggage, b3]
+`))
+	require.NoError(t, err)
+
+	sdk, err := NewSDK(WithOpenTelemetryConfiguration(*cfg))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sdk.Shutdown(context.Background())) }()
+	require.NotNil(t, sdk.Propagator())
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	assert.Contains(t, carrier.Keys(), "traceparent")
+	assert.Contains(t, carrier.Keys(), "b3")
+}
+
+func TestNewSDKPropagatorErrors(t *testing.T) {
+	_, err := NewSDK(WithOpenTelemetryConfiguration(OpenTelemetryConfiguration{
+		Propagator: &Propagator{Composite: []string{"unknown"}},
+	}))
+	assert.EqualError(t, err, "invalid propagator: unknown propagator: unknown")
+}
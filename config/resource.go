@@ -4,16 +4,87 @@
 package config // import "go.opentelemetry.io/contrib/config"
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
-func newResource(res *Resource) (*resource.Resource, error) {
+const (
+	resourceDetectorContainer = "container"
+	resourceDetectorEnv       = "env"
+	resourceDetectorHost      = "host"
+	resourceDetectorOS        = "os"
+	resourceDetectorProcess   = "process"
+)
+
+// newResource returns the resource described by res, merged with the
+// resource passed with WithResource, custom. The attributes of custom take
+// precedence over the default and detected ones, and the declared attributes
+// of res take precedence over all the others.
+func newResource(ctx context.Context, res *Resource, custom *resource.Resource) (*resource.Resource, error) {
+	base := resource.Default()
+	if res != nil && len(res.Detectors) > 0 {
+		detected, err := detectResource(ctx, res.Detectors)
+		if err != nil {
+			return nil, err
+		}
+		if base, err = resource.Merge(base, detected); err != nil {
+			return base, err
+		}
+	}
+
+	if custom != nil {
+		var err error
+		if base, err = resource.Merge(base, custom); err != nil {
+			return base, err
+		}
+	}
+
 	if res == nil || res.Attributes == nil {
-		return resource.Default(), nil
+		return base, nil
+	}
+	var schemaURL string
+	if res.SchemaUrl != nil {
+		schemaURL = *res.SchemaUrl
+	}
+	var attrs []attribute.KeyValue
+	if res.Attributes.ServiceName != nil {
+		attrs = append(attrs, semconv.ServiceName(*res.Attributes.ServiceName))
+	}
+	// Declared attributes are merged last so they take precedence over
+	// any detected or injected attributes.
+	return resource.Merge(base, resource.NewWithAttributes(schemaURL, attrs...))
+}
+
+// detectResource runs the named resource detectors and returns the merged
+// result. Detectors are run in the order they are provided.
+func detectResource(ctx context.Context, detectors []string) (*resource.Resource, error) {
+	opts := make([]resource.Option, 0, len(detectors))
+	for _, d := range detectors {
+		switch d {
+		case resourceDetectorContainer:
+			opts = append(opts, resource.WithContainer())
+		case resourceDetectorEnv:
+			opts = append(opts, resource.WithFromEnv())
+		case resourceDetectorHost:
+			opts = append(opts, resource.WithHost())
+		case resourceDetectorOS:
+			opts = append(opts, resource.WithOS())
+		case resourceDetectorProcess:
+			opts = append(opts, resource.WithProcess())
+		default:
+			return nil, fmt.Errorf("unsupported resource detector %q", d)
+		}
+	}
+	r, err := resource.New(ctx, opts...)
+	// Detectors may partially fail (e.g. missing permissions to read the
+	// process owner) while still returning useful attributes.
+	if errors.Is(err, resource.ErrPartialResource) {
+		return r, nil
 	}
-	return resource.Merge(resource.Default(),
-		resource.NewWithAttributes(*res.SchemaUrl,
-			semconv.ServiceName(*res.Attributes.ServiceName),
-		))
+	return r, err
 }
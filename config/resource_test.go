@@ -4,6 +4,7 @@
 package config // import "go.opentelemetry.io/contrib/config"
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,12 +56,100 @@ func TestNewResource(t *testing.T) {
 			},
 			wantResource: res,
 		},
+		{
+			name:         "resource-with-empty-attributes",
+			config:       &Resource{Attributes: &Attributes{}},
+			wantResource: resource.Default(),
+		},
+		{
+			name: "resource-with-attributes-no-schema",
+			config: &Resource{
+				Attributes: &Attributes{
+					ServiceName: ptr("service-a"),
+				},
+			},
+			wantResource: resource.NewWithAttributes(resource.Default().SchemaURL(), res.Attributes()...),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := newResource(tt.config)
+			got, err := newResource(context.Background(), tt.config, nil)
 			assert.ErrorIs(t, err, tt.wantErr)
 			assert.Equal(t, tt.wantResource, got)
 		})
 	}
 }
+
+func TestNewResourceWithDetectors(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.name=from-env,deployment.environment=test")
+
+	t.Run("env", func(t *testing.T) {
+		got, err := newResource(context.Background(), &Resource{
+			Detectors: []string{"env"},
+		}, nil)
+		require.NoError(t, err)
+		assert.Contains(t, got.Attributes(), semconv.ServiceName("from-env"))
+		assert.Contains(t, got.Attributes(), semconv.DeploymentEnvironment("test"))
+	})
+
+	t.Run("declared-attributes-win", func(t *testing.T) {
+		got, err := newResource(context.Background(), &Resource{
+			Detectors: []string{"env", "process"},
+			Attributes: &Attributes{
+				ServiceName: ptr("service-a"),
+			},
+			SchemaUrl: ptr(semconv.SchemaURL),
+		}, nil)
+		require.NoError(t, err)
+		assert.Contains(t, got.Attributes(), semconv.ServiceName("service-a"))
+		assert.Contains(t, got.Attributes(), semconv.DeploymentEnvironment("test"))
+		_, ok := got.Set().Value(semconv.ProcessPIDKey)
+		assert.True(t, ok, "process detector attributes missing")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := newResource(context.Background(), &Resource{
+			Detectors: []string{"unknown"},
+		}, nil)
+		assert.EqualError(t, err, `unsupported resource detector "unknown"`)
+	})
+}
+
+func TestNewResourceWithCustomResource(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.name=from-env,deployment.environment=from-env")
+	custom := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("custom"),
+		semconv.DeploymentEnvironment("custom"),
+		semconv.HostName("my-host"),
+	)
+
+	t.Run("no-resource-configuration", func(t *testing.T) {
+		got, err := newResource(context.Background(), nil, custom)
+		require.NoError(t, err)
+		want, err := resource.Merge(resource.Default(), custom)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		got, err := newResource(context.Background(), &Resource{
+			Detectors: []string{"env"},
+			Attributes: &Attributes{
+				ServiceName: ptr("declared"),
+			},
+			SchemaUrl: ptr(semconv.SchemaURL),
+		}, custom)
+		require.NoError(t, err)
+		// Declared attributes win over the custom ones, which win over
+		// the detected ones.
+		assert.Contains(t, got.Attributes(), semconv.ServiceName("declared"))
+		assert.Contains(t, got.Attributes(), semconv.DeploymentEnvironment("custom"))
+		assert.Contains(t, got.Attributes(), semconv.HostName("my-host"))
+	})
+
+	t.Run("schema-conflict", func(t *testing.T) {
+		_, err := newResource(context.Background(), nil,
+			resource.NewWithAttributes("https://opentelemetry.io/invalid-schema", semconv.HostName("my-host")))
+		assert.ErrorIs(t, err, resource.ErrSchemaURLConflict)
+	})
+}
@@ -8,13 +8,16 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/contrib/samplers/jaegerremote"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 )
@@ -26,24 +29,210 @@ func tracerProvider(cfg configOptions, res *resource.Resource) (trace.TracerProv
 	opts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
 	}
-	var errs []error
-	for _, processor := range cfg.opentelemetryConfig.TracerProvider.Processors {
-		sp, err := spanProcessor(cfg.ctx, processor)
-		if err == nil {
-			opts = append(opts, sdktrace.WithSpanProcessor(sp))
-		} else {
-			errs = append(errs, err)
-		}
+	sps, errs := spanProcessors(cfg.ctx, cfg.opentelemetryConfig.TracerProvider.Processors)
+	for _, sp := range sps {
+		opts = append(opts, sdktrace.WithSpanProcessor(sp))
+	}
+	sb := &samplerBuilder{serviceName: serviceName(res), dryRun: cfg.dryRun}
+	s, err := sb.sampler(cfg.opentelemetryConfig.TracerProvider.Sampler)
+	if err == nil {
+		opts = append(opts, sdktrace.WithSampler(s))
+	} else {
+		errs = append(errs, err)
 	}
 	if len(errs) > 0 {
+		sb.close()
 		return noop.NewTracerProvider(), noopShutdown, errors.Join(errs...)
 	}
 	tp := sdktrace.NewTracerProvider(opts...)
-	return tp, tp.Shutdown, nil
+	return tp, func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		sb.close()
+		return err
+	}, nil
+}
+
+// serviceName returns the service.name of res, which is used to request the
+// sampling strategies of remote samplers.
+func serviceName(res *resource.Resource) string {
+	if v, ok := res.Set().Value(semconv.ServiceNameKey); ok {
+		return v.AsString()
+	}
+	return ""
+}
+
+// samplerBuilder creates the samplers of the configuration. It keeps track
+// of the remote samplers created, as their polling needs to be stopped when
+// the tracer provider is shut down.
+type samplerBuilder struct {
+	serviceName string
+	// dryRun is true if the remote samplers must not poll their sampling
+	// strategies. Their initial sampler is used instead.
+	dryRun bool
+	remote []*jaegerremote.Sampler
+}
+
+func (b *samplerBuilder) sampler(s *Sampler) (sdktrace.Sampler, error) {
+	if s == nil {
+		// The default sampler of the SDK.
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	}
+
+	var n int
+	for _, set := range []bool{
+		s.AlwaysOff != nil, s.AlwaysOn != nil, s.JaegerRemote != nil,
+		s.ParentBased != nil, s.TraceIDRatioBased != nil,
+	} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
+		return nil, errors.New("must not specify multiple samplers")
+	}
+
+	switch {
+	case s.AlwaysOff != nil:
+		return sdktrace.NeverSample(), nil
+	case s.AlwaysOn != nil:
+		return sdktrace.AlwaysSample(), nil
+	case s.JaegerRemote != nil:
+		return b.jaegerRemoteSampler(s.JaegerRemote)
+	case s.ParentBased != nil:
+		return b.parentBasedSampler(s.ParentBased)
+	case s.TraceIDRatioBased != nil:
+		if s.TraceIDRatioBased.Ratio == nil {
+			return sdktrace.TraceIDRatioBased(1), nil
+		}
+		return sdktrace.TraceIDRatioBased(*s.TraceIDRatioBased.Ratio), nil
+	}
+	return nil, errors.New("no valid sampler")
+}
+
+func (b *samplerBuilder) parentBasedSampler(pb *SamplerParentBased) (sdktrace.Sampler, error) {
+	root := sdktrace.AlwaysSample()
+	if pb.Root != nil {
+		var err error
+		if root, err = b.sampler(pb.Root); err != nil {
+			return nil, err
+		}
+	}
+
+	var opts []sdktrace.ParentBasedSamplerOption
+	for _, delegate := range []struct {
+		sampler *Sampler
+		option  func(sdktrace.Sampler) sdktrace.ParentBasedSamplerOption
+	}{
+		{pb.RemoteParentSampled, sdktrace.WithRemoteParentSampled},
+		{pb.RemoteParentNotSampled, sdktrace.WithRemoteParentNotSampled},
+		{pb.LocalParentSampled, sdktrace.WithLocalParentSampled},
+		{pb.LocalParentNotSampled, sdktrace.WithLocalParentNotSampled},
+	} {
+		if delegate.sampler == nil {
+			continue
+		}
+		s, err := b.sampler(delegate.sampler)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, delegate.option(s))
+	}
+	return sdktrace.ParentBased(root, opts...), nil
+}
+
+func (b *samplerBuilder) jaegerRemoteSampler(jr *SamplerJaegerRemote) (sdktrace.Sampler, error) {
+	if jr.Endpoint == nil || *jr.Endpoint == "" {
+		return nil, errors.New("jaeger remote sampler endpoint is required")
+	}
+	u, err := url.ParseRequestURI(*jr.Endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid jaeger remote sampler endpoint %q", *jr.Endpoint)
+	}
+	opts := []jaegerremote.Option{jaegerremote.WithSamplingServerURL(*jr.Endpoint)}
+
+	if jr.Interval != nil {
+		if *jr.Interval <= 0 {
+			return nil, fmt.Errorf("invalid jaeger remote sampler interval %d", *jr.Interval)
+		}
+		opts = append(opts, jaegerremote.WithSamplingRefreshInterval(time.Millisecond*time.Duration(*jr.Interval)))
+	}
+	var initial sdktrace.Sampler
+	if jr.InitialSampler != nil {
+		initial, err = b.sampler(jr.InitialSampler)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, jaegerremote.WithInitialSampler(initial))
+	}
+	if b.dryRun {
+		if initial == nil {
+			// The default sampler of the SDK.
+			initial = sdktrace.ParentBased(sdktrace.AlwaysSample())
+		}
+		return initial, nil
+	}
+
+	s := jaegerremote.New(b.serviceName, opts...)
+	b.remote = append(b.remote, s)
+	return s, nil
+}
+
+// close stops the polling of all remote samplers created.
+func (b *samplerBuilder) close() {
+	for _, s := range b.remote {
+		s.Close()
+	}
+	b.remote = nil
+}
+
+// SpanExporterFactory creates a span exporter for a custom exporter of the
+// configuration. It is passed the properties of the custom exporter.
+type SpanExporterFactory func(ctx context.Context, properties map[string]interface{}) (sdktrace.SpanExporter, error)
+
+var spanExporterFactories = struct {
+	mu    sync.Mutex
+	names map[string]SpanExporterFactory
+}{names: make(map[string]SpanExporterFactory)}
+
+// RegisterSpanExporterFactory registers factory to create the span exporter
+// of custom exporters configured with name. This allows exporters that are not
+// part of the configuration schema to be used by NewSDK.
+//
+// An error is returned if a factory is already registered with name.
+func RegisterSpanExporterFactory(name string, factory SpanExporterFactory) error {
+	if factory == nil {
+		return fmt.Errorf("nil span exporter factory %q", name)
+	}
+	spanExporterFactories.mu.Lock()
+	defer spanExporterFactories.mu.Unlock()
+	if _, ok := spanExporterFactories.names[name]; ok {
+		return fmt.Errorf("span exporter factory %q already registered", name)
+	}
+	spanExporterFactories.names[name] = factory
+	return nil
+}
+
+func customSpanExporter(ctx context.Context, custom *CustomExporter) (sdktrace.SpanExporter, error) {
+	spanExporterFactories.mu.Lock()
+	factory, ok := spanExporterFactories.names[custom.Name]
+	spanExporterFactories.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported span exporter %q", custom.Name)
+	}
+	if isDryRun(ctx) {
+		return dryRunSpanExporter{}, nil
+	}
+	return factory(ctx, custom.Properties)
 }
 
 func spanExporter(ctx context.Context, exporter SpanExporter) (sdktrace.SpanExporter, error) {
-	if exporter.Console != nil && exporter.OTLP != nil {
+	var n int
+	for _, set := range []bool{exporter.Console != nil, exporter.OTLP != nil, exporter.Custom != nil} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
 		return nil, errors.New("must not specify multiple exporters")
 	}
 
@@ -53,18 +242,48 @@ func spanExporter(ctx context.Context, exporter SpanExporter) (sdktrace.SpanExpo
 		)
 	}
 	if exporter.OTLP != nil {
-		switch exporter.OTLP.Protocol {
+		protocol, err := otlpExporterProtocol(exporter.OTLP.Protocol, exporter.OTLP.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		switch protocol {
 		case protocolProtobufHTTP:
 			return otlpHTTPSpanExporter(ctx, exporter.OTLP)
 		case protocolProtobufGRPC:
 			return otlpGRPCSpanExporter(ctx, exporter.OTLP)
 		default:
-			return nil, fmt.Errorf("unsupported protocol %q", exporter.OTLP.Protocol)
+			return nil, fmt.Errorf("unsupported protocol %q", protocol)
 		}
 	}
+	if exporter.Custom != nil {
+		return customSpanExporter(ctx, exporter.Custom)
+	}
 	return nil, errors.New("no valid span exporter")
 }
 
+// spanProcessors returns the span processors of the configuration, in the
+// order they are configured. The spans are passed to the processors in this
+// order once registered. At least one processor must be configured, all of
+// them export the spans they process.
+func spanProcessors(ctx context.Context, processors []SpanProcessor) ([]sdktrace.SpanProcessor, []error) {
+	if len(processors) == 0 {
+		return nil, []error{errors.New("must specify at least one span processor")}
+	}
+	var (
+		sps  []sdktrace.SpanProcessor
+		errs []error
+	)
+	for _, processor := range processors {
+		sp, err := spanProcessor(ctx, processor)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sps = append(sps, sp)
+	}
+	return sps, errs
+}
+
 func spanProcessor(ctx context.Context, processor SpanProcessor) (sdktrace.SpanProcessor, error) {
 	if processor.Batch != nil && processor.Simple != nil {
 		return nil, errors.New("must not specify multiple span processor type")
@@ -126,7 +345,17 @@ func otlpGRPCSpanExporter(ctx context.Context, otlpConfig *OTLP) (sdktrace.SpanE
 	if len(otlpConfig.Headers) > 0 {
 		opts = append(opts, otlptracegrpc.WithHeaders(otlpConfig.Headers))
 	}
+	if otlpConfig.Retry != nil {
+		retry, err := otlpRetry(otlpConfig.Retry)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig(retry)))
+	}
 
+	if isDryRun(ctx) {
+		return dryRunSpanExporter{}, nil
+	}
 	return otlptracegrpc.New(ctx, opts...)
 }
 
@@ -163,35 +392,52 @@ func otlpHTTPSpanExporter(ctx context.Context, otlpConfig *OTLP) (sdktrace.SpanE
 	if len(otlpConfig.Headers) > 0 {
 		opts = append(opts, otlptracehttp.WithHeaders(otlpConfig.Headers))
 	}
+	if otlpConfig.Retry != nil {
+		retry, err := otlpRetry(otlpConfig.Retry)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlptracehttp.WithRetry(otlptracehttp.RetryConfig(retry)))
+	}
 
+	if isDryRun(ctx) {
+		return dryRunSpanExporter{}, nil
+	}
 	return otlptracehttp.New(ctx, opts...)
 }
 
 func batchSpanProcessor(bsp *BatchSpanProcessor, exp sdktrace.SpanExporter) (sdktrace.SpanProcessor, error) {
 	var opts []sdktrace.BatchSpanProcessorOption
 	if bsp.ExportTimeout != nil {
-		if *bsp.ExportTimeout < 0 {
+		if *bsp.ExportTimeout <= 0 {
 			return nil, fmt.Errorf("invalid export timeout %d", *bsp.ExportTimeout)
 		}
 		opts = append(opts, sdktrace.WithExportTimeout(time.Millisecond*time.Duration(*bsp.ExportTimeout)))
 	}
 	if bsp.MaxExportBatchSize != nil {
-		if *bsp.MaxExportBatchSize < 0 {
+		if *bsp.MaxExportBatchSize <= 0 {
 			return nil, fmt.Errorf("invalid batch size %d", *bsp.MaxExportBatchSize)
 		}
 		opts = append(opts, sdktrace.WithMaxExportBatchSize(*bsp.MaxExportBatchSize))
 	}
 	if bsp.MaxQueueSize != nil {
-		if *bsp.MaxQueueSize < 0 {
+		if *bsp.MaxQueueSize <= 0 {
 			return nil, fmt.Errorf("invalid queue size %d", *bsp.MaxQueueSize)
 		}
 		opts = append(opts, sdktrace.WithMaxQueueSize(*bsp.MaxQueueSize))
 	}
 	if bsp.ScheduleDelay != nil {
-		if *bsp.ScheduleDelay < 0 {
+		if *bsp.ScheduleDelay <= 0 {
 			return nil, fmt.Errorf("invalid schedule delay %d", *bsp.ScheduleDelay)
 		}
 		opts = append(opts, sdktrace.WithBatchTimeout(time.Millisecond*time.Duration(*bsp.ScheduleDelay)))
 	}
+	queueSize := sdktrace.DefaultMaxQueueSize
+	if bsp.MaxQueueSize != nil {
+		queueSize = *bsp.MaxQueueSize
+	}
+	if bsp.MaxExportBatchSize != nil && *bsp.MaxExportBatchSize > queueSize {
+		return nil, fmt.Errorf("invalid batch size %d: greater than the queue size %d", *bsp.MaxExportBatchSize, queueSize)
+	}
 	return sdktrace.NewBatchSpanProcessor(exp, opts...), nil
 }
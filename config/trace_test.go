@@ -6,9 +6,14 @@ package config
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +23,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 )
@@ -33,6 +39,16 @@ func TestTracerPovider(t *testing.T) {
 			name:         "no-tracer-provider-configured",
 			wantProvider: noop.NewTracerProvider(),
 		},
+		{
+			name: "no-span-processor",
+			cfg: configOptions{
+				opentelemetryConfig: OpenTelemetryConfiguration{
+					TracerProvider: &TracerProvider{},
+				},
+			},
+			wantProvider: noop.NewTracerProvider(),
+			wantErr:      errors.Join(errors.New("must specify at least one span processor")),
+		},
 		{
 			name: "error-in-config",
 			cfg: configOptions{
@@ -172,6 +188,79 @@ func TestSpanProcessor(t *testing.T) {
 			},
 			wantErr: errors.New("invalid schedule delay -4"),
 		},
+		{
+			name: "batch processor batch size greater than queue size console exporter",
+			processor: SpanProcessor{
+				Batch: &BatchSpanProcessor{
+					MaxExportBatchSize: ptr(20),
+					MaxQueueSize:       ptr(10),
+					Exporter: SpanExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid batch size 20: greater than the queue size 10"),
+		},
+		{
+			name: "batch processor zero batch size console exporter",
+			processor: SpanProcessor{
+				Batch: &BatchSpanProcessor{
+					MaxExportBatchSize: ptr(0),
+					Exporter: SpanExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid batch size 0"),
+		},
+		{
+			name: "batch processor zero export timeout console exporter",
+			processor: SpanProcessor{
+				Batch: &BatchSpanProcessor{
+					ExportTimeout: ptr(0),
+					Exporter: SpanExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid export timeout 0"),
+		},
+		{
+			name: "batch processor zero queue size console exporter",
+			processor: SpanProcessor{
+				Batch: &BatchSpanProcessor{
+					MaxQueueSize: ptr(0),
+					Exporter: SpanExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid queue size 0"),
+		},
+		{
+			name: "batch processor zero schedule delay console exporter",
+			processor: SpanProcessor{
+				Batch: &BatchSpanProcessor{
+					ScheduleDelay: ptr(0),
+					Exporter: SpanExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid schedule delay 0"),
+		},
+		{
+			name: "batch processor batch size greater than the default queue size console exporter",
+			processor: SpanProcessor{
+				Batch: &BatchSpanProcessor{
+					MaxExportBatchSize: ptr(4096),
+					Exporter: SpanExporter{
+						Console: Console{},
+					},
+				},
+			},
+			wantErr: errors.New("invalid batch size 4096: greater than the queue size 2048"),
+		},
 		{
 			name: "batch processor with multiple exporters",
 			processor: SpanProcessor{
@@ -188,10 +277,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch processor console exporter",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						Console: Console{},
 					},
@@ -203,10 +292,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-exporter-invalid-protocol",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol: "http/invalid",
@@ -220,10 +309,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-grpc-exporter-no-endpoint",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "grpc/protobuf",
@@ -242,10 +331,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-grpc-exporter",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "grpc/protobuf",
@@ -265,10 +354,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-grpc-exporter-no-scheme",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "grpc/protobuf",
@@ -288,10 +377,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-grpc-invalid-endpoint",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "grpc/protobuf",
@@ -311,10 +400,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-grpc-invalid-compression",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "grpc/protobuf",
@@ -334,10 +423,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-http-exporter",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "http/protobuf",
@@ -357,10 +446,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-http-exporter-with-path",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "http/protobuf",
@@ -380,10 +469,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-http-exporter-no-endpoint",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "http/protobuf",
@@ -402,10 +491,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-http-exporter-no-scheme",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "http/protobuf",
@@ -425,10 +514,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-http-invalid-endpoint",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "http/protobuf",
@@ -448,10 +537,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-http-none-compression",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "http/protobuf",
@@ -471,10 +560,10 @@ func TestSpanProcessor(t *testing.T) {
 			name: "batch/otlp-http-invalid-compression",
 			processor: SpanProcessor{
 				Batch: &BatchSpanProcessor{
-					MaxExportBatchSize: ptr(0),
-					ExportTimeout:      ptr(0),
-					MaxQueueSize:       ptr(0),
-					ScheduleDelay:      ptr(0),
+					MaxExportBatchSize: ptr(512),
+					ExportTimeout:      ptr(30000),
+					MaxQueueSize:       ptr(2048),
+					ScheduleDelay:      ptr(5000),
 					Exporter: SpanExporter{
 						OTLP: &OTLP{
 							Protocol:    "http/protobuf",
@@ -490,6 +579,45 @@ func TestSpanProcessor(t *testing.T) {
 			},
 			wantErr: errors.New("unsupported compression \"invalid\""),
 		},
+		{
+			name: "batch/otlp-exporter-inferred-http",
+			processor: SpanProcessor{
+				Batch: &BatchSpanProcessor{
+					Exporter: SpanExporter{
+						OTLP: &OTLP{
+							Endpoint: "https://localhost:4318",
+						},
+					},
+				},
+			},
+			wantProcessor: sdktrace.NewBatchSpanProcessor(otlpHTTPExporter),
+		},
+		{
+			name: "batch/otlp-exporter-inferred-grpc",
+			processor: SpanProcessor{
+				Batch: &BatchSpanProcessor{
+					Exporter: SpanExporter{
+						OTLP: &OTLP{
+							Endpoint: "localhost:4317",
+						},
+					},
+				},
+			},
+			wantProcessor: sdktrace.NewBatchSpanProcessor(otlpGRPCExporter),
+		},
+		{
+			name: "batch/otlp-exporter-inferred-invalid-endpoint",
+			processor: SpanProcessor{
+				Batch: &BatchSpanProcessor{
+					Exporter: SpanExporter{
+						OTLP: &OTLP{
+							Endpoint: "localhost",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("unable to infer the protocol of endpoint \"localhost\""),
+		},
 		{
 			name: "simple/no-exporter",
 			processor: SpanProcessor{
@@ -533,3 +661,299 @@ func TestSpanProcessor(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchSpanProcessorParameters(t *testing.T) {
+	exp, err := stdouttrace.New()
+	require.NoError(t, err)
+	p, err := batchSpanProcessor(&BatchSpanProcessor{
+		ExportTimeout:      ptr(1000),
+		MaxExportBatchSize: ptr(5),
+		MaxQueueSize:       ptr(10),
+		ScheduleDelay:      ptr(200),
+	}, exp)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, p.Shutdown(context.Background())) })
+
+	o := reflect.Indirect(reflect.ValueOf(p)).FieldByName("o")
+	assert.Equal(t, time.Second, time.Duration(o.FieldByName("ExportTimeout").Int()))
+	assert.Equal(t, int64(5), o.FieldByName("MaxExportBatchSize").Int())
+	assert.Equal(t, int64(10), o.FieldByName("MaxQueueSize").Int())
+	assert.Equal(t, 200*time.Millisecond, time.Duration(o.FieldByName("BatchTimeout").Int()))
+}
+
+// orderExporter records the name of the exporters in the order the spans
+// are exported.
+type orderExporter struct {
+	tracetest.InMemoryExporter
+
+	name  string
+	mu    *sync.Mutex
+	order *[]string
+}
+
+func (e *orderExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for range spans {
+		*e.order = append(*e.order, e.name)
+	}
+	return nil
+}
+
+func TestNewSDKSpanProcessorsOrder(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	for _, name := range []string{"test-order-first", "test-order-second"} {
+		name := name
+		registerSpanExporterFactory(t, name, func(context.Context, map[string]interface{}) (sdktrace.SpanExporter, error) {
+			return &orderExporter{name: name, mu: &mu, order: &order}, nil
+		})
+	}
+
+	cfg, err := ParseYAML([]byte(`
+file_format: "0.2"
+tracer_provider:
+  processors:
+    - simple:
+        exporter:
+          custom:
+            name: test-order-second
+    - simple:
+        exporter:
+          custom:
+            name: test-order-first
+`))
+	require.NoError(t, err)
+
+	sdk, err := NewSDK(WithOpenTelemetryConfiguration(*cfg))
+	require.NoError(t, err)
+
+	_, span := sdk.TracerProvider().Tracer("test").Start(context.Background(), "span")
+	span.End()
+	require.NoError(t, sdk.Shutdown(context.Background()))
+
+	// The simple processors export the span when it ends, in the order they
+	// are registered.
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"test-order-second", "test-order-first"}, order)
+}
+
+// registerSpanExporterFactory registers factory with name for the duration of
+// the test.
+func registerSpanExporterFactory(t *testing.T, name string, factory SpanExporterFactory) {
+	t.Helper()
+	require.NoError(t, RegisterSpanExporterFactory(name, factory))
+	t.Cleanup(func() {
+		spanExporterFactories.mu.Lock()
+		defer spanExporterFactories.mu.Unlock()
+		delete(spanExporterFactories.names, name)
+	})
+}
+
+func TestCustomSpanExporter(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	var gotProps map[string]interface{}
+	registerSpanExporterFactory(t, "test-custom", func(_ context.Context, props map[string]interface{}) (sdktrace.SpanExporter, error) {
+		gotProps = props
+		return exp, nil
+	})
+
+	err := RegisterSpanExporterFactory("test-custom", func(context.Context, map[string]interface{}) (sdktrace.SpanExporter, error) {
+		return nil, nil
+	})
+	assert.EqualError(t, err, `span exporter factory "test-custom" already registered`)
+
+	props := map[string]interface{}{"endpoint": "localhost:1234"}
+	sdk, err := NewSDK(WithOpenTelemetryConfiguration(OpenTelemetryConfiguration{
+		TracerProvider: &TracerProvider{
+			Processors: []SpanProcessor{{
+				Simple: &SimpleSpanProcessor{
+					Exporter: SpanExporter{
+						Custom: &CustomExporter{Name: "test-custom", Properties: props},
+					},
+				},
+			}},
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, props, gotProps)
+
+	_, span := sdk.TracerProvider().Tracer("test").Start(context.Background(), "span")
+	span.End()
+	require.Len(t, exp.GetSpans(), 1)
+	assert.Equal(t, "span", exp.GetSpans()[0].Name)
+	require.NoError(t, sdk.Shutdown(context.Background()))
+
+	_, err = spanExporter(context.Background(), SpanExporter{Custom: &CustomExporter{Name: "test-unknown"}})
+	assert.EqualError(t, err, `unsupported span exporter "test-unknown"`)
+
+	_, err = spanExporter(context.Background(), SpanExporter{
+		Console: Console{},
+		Custom:  &CustomExporter{Name: "test-custom"},
+	})
+	assert.EqualError(t, err, "must not specify multiple exporters")
+}
+
+func TestSampler(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sampler     *Sampler
+		wantSampler sdktrace.Sampler
+		wantErr     error
+	}{
+		{
+			name:        "no sampler",
+			wantSampler: sdktrace.ParentBased(sdktrace.AlwaysSample()),
+		},
+		{
+			name:    "empty sampler",
+			sampler: &Sampler{},
+			wantErr: errors.New("no valid sampler"),
+		},
+		{
+			name: "multiple samplers",
+			sampler: &Sampler{
+				AlwaysOn:  SamplerAlwaysOn{},
+				AlwaysOff: SamplerAlwaysOff{},
+			},
+			wantErr: errors.New("must not specify multiple samplers"),
+		},
+		{
+			name:        "always on",
+			sampler:     &Sampler{AlwaysOn: SamplerAlwaysOn{}},
+			wantSampler: sdktrace.AlwaysSample(),
+		},
+		{
+			name:        "always off",
+			sampler:     &Sampler{AlwaysOff: SamplerAlwaysOff{}},
+			wantSampler: sdktrace.NeverSample(),
+		},
+		{
+			name:        "trace id ratio based",
+			sampler:     &Sampler{TraceIDRatioBased: &SamplerTraceIDRatioBased{Ratio: ptr(0.5)}},
+			wantSampler: sdktrace.TraceIDRatioBased(0.5),
+		},
+		{
+			name:        "trace id ratio based default",
+			sampler:     &Sampler{TraceIDRatioBased: &SamplerTraceIDRatioBased{}},
+			wantSampler: sdktrace.TraceIDRatioBased(1),
+		},
+		{
+			name: "parent based",
+			sampler: &Sampler{
+				ParentBased: &SamplerParentBased{
+					Root:                   &Sampler{TraceIDRatioBased: &SamplerTraceIDRatioBased{Ratio: ptr(0.25)}},
+					RemoteParentNotSampled: &Sampler{AlwaysOn: SamplerAlwaysOn{}},
+					LocalParentSampled:     &Sampler{AlwaysOff: SamplerAlwaysOff{}},
+				},
+			},
+			wantSampler: sdktrace.ParentBased(
+				sdktrace.TraceIDRatioBased(0.25),
+				sdktrace.WithRemoteParentNotSampled(sdktrace.AlwaysSample()),
+				sdktrace.WithLocalParentSampled(sdktrace.NeverSample()),
+			),
+		},
+		{
+			name: "parent based invalid root",
+			sampler: &Sampler{
+				ParentBased: &SamplerParentBased{Root: &Sampler{}},
+			},
+			wantErr: errors.New("no valid sampler"),
+		},
+		{
+			name:    "jaeger remote no endpoint",
+			sampler: &Sampler{JaegerRemote: &SamplerJaegerRemote{}},
+			wantErr: errors.New("jaeger remote sampler endpoint is required"),
+		},
+		{
+			name:    "jaeger remote invalid endpoint",
+			sampler: &Sampler{JaegerRemote: &SamplerJaegerRemote{Endpoint: ptr("localhost:5778")}},
+			wantErr: errors.New(`invalid jaeger remote sampler endpoint "localhost:5778"`),
+		},
+		{
+			name: "jaeger remote invalid interval",
+			sampler: &Sampler{JaegerRemote: &SamplerJaegerRemote{
+				Endpoint: ptr("http://localhost:5778/sampling"),
+				Interval: ptr(-1),
+			}},
+			wantErr: errors.New("invalid jaeger remote sampler interval -1"),
+		},
+		{
+			name: "jaeger remote invalid initial sampler",
+			sampler: &Sampler{JaegerRemote: &SamplerJaegerRemote{
+				Endpoint:       ptr("http://localhost:5778/sampling"),
+				InitialSampler: &Sampler{},
+			}},
+			wantErr: errors.New("no valid sampler"),
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &samplerBuilder{}
+			defer b.close()
+			got, err := b.sampler(tt.sampler)
+			require.Equal(t, tt.wantErr, err)
+			if tt.wantSampler == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.wantSampler.Description(), got.Description())
+		})
+	}
+}
+
+func TestNewSDKJaegerRemoteSamplerFromYAML(t *testing.T) {
+	var service atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		service.Store(r.URL.Query().Get("service"))
+		_, _ = w.Write([]byte(`{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":1}}`))
+	}))
+	defer srv.Close()
+
+	cfg, err := ParseYAML([]byte(`
+file_format: "0.2"
+tracer_provider:
+  processors:
+    - simple:
+        exporter:
+          console: {}
+  sampler:
+    parent_based:
+      root:
+        jaeger_remote:
+          endpoint: ` + srv.URL + `/sampling
+          interval: 10
+          initial_sampler:
+            always_off: {}
+`))
+	require.NoError(t, err)
+
+	sdk, err := NewSDK(WithOpenTelemetryConfiguration(*cfg))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sdk.Shutdown(context.Background())) }()
+
+	tracer := sdk.TracerProvider().Tracer("test")
+	// Spans are sampled once the strategy of the remote sampler is fetched,
+	// replacing the initial sampler that drops all spans.
+	assert.Eventually(t, func() bool {
+		_, span := tracer.Start(context.Background(), "span")
+		defer span.End()
+		return span.SpanContext().IsSampled()
+	}, 5*time.Second, 10*time.Millisecond)
+	// The strategy is requested for the service of the resource.
+	assert.Equal(t, serviceName(resource.Default()), service.Load())
+
+	// The remote sampler is the root of the parent based sampler: the
+	// decision of a parent span is respected.
+	parent := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+		Remote:  true,
+	}))
+	_, span := tracer.Start(parent, "child")
+	defer span.End()
+	assert.False(t, span.SpanContext().IsSampled())
+}
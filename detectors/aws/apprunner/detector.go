@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package apprunner provides a resource detector for the AWS App Runner
+// environment.
+package apprunner // import "go.opentelemetry.io/contrib/detectors/aws/apprunner"
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// The environment variables of the App Runner services.
+const (
+	serviceIDEnvVar   = "AWS_APP_RUNNER_SERVICE_ID"
+	serviceNameEnvVar = "AWS_APP_RUNNER_SERVICE_NAME"
+	serviceARNEnvVar  = "AWS_APP_RUNNER_SERVICE_ARN"
+	awsRegionEnvVar   = "AWS_REGION"
+)
+
+var (
+	empty             = resource.Empty()
+	errNotOnAppRunner = errors.New("process is not on App Runner, cannot detect environment variables from App Runner")
+)
+
+// resource detector collects resource information from App Runner environment.
+type resourceDetector struct{}
+
+// compile time assertion that resource detector implements the resource.Detector interface.
+var _ resource.Detector = (*resourceDetector)(nil)
+
+// NewResourceDetector returns a resource detector that will detect AWS App
+// Runner resources.
+func NewResourceDetector() resource.Detector {
+	return &resourceDetector{}
+}
+
+// Detect collects resource attributes available when running on App Runner,
+// from its environment variables. The process is on App Runner if the
+// AWS_APP_RUNNER_SERVICE_ID environment variable is set.
+func (detector *resourceDetector) Detect(context.Context) (*resource.Resource, error) {
+	if os.Getenv(serviceIDEnvVar) == "" {
+		return empty, errNotOnAppRunner
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSAppRunner,
+	}
+	if region := os.Getenv(awsRegionEnvVar); region != "" {
+		attrs = append(attrs, semconv.CloudRegion(region))
+	}
+	if arn := os.Getenv(serviceARNEnvVar); arn != "" {
+		attrs = append(attrs, semconv.CloudResourceID(arn))
+	}
+	if name := os.Getenv(serviceNameEnvVar); name != "" {
+		attrs = append(attrs, semconv.ServiceName(name))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apprunner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+func setenv(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, k := range []string{serviceIDEnvVar, serviceNameEnvVar, serviceARNEnvVar, awsRegionEnvVar} {
+		t.Setenv(k, env[k])
+	}
+}
+
+// successfully return resource when process is running on AWS App Runner.
+func TestDetectSuccess(t *testing.T) {
+	setenv(t, map[string]string{
+		serviceIDEnvVar:   "8fe1e10304f84fd2b0df550fe98a71fa",
+		serviceNameEnvVar: "python-app",
+		serviceARNEnvVar:  "arn:aws:apprunner:us-east-1:123456789012:service/python-app/8fe1e10304f84fd2b0df550fe98a71fa",
+		awsRegionEnvVar:   "us-east-1",
+	})
+
+	want := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSAppRunner,
+		semconv.CloudRegion("us-east-1"),
+		semconv.CloudResourceID("arn:aws:apprunner:us-east-1:123456789012:service/python-app/8fe1e10304f84fd2b0df550fe98a71fa"),
+		semconv.ServiceName("python-app"),
+	)
+	res, err := NewResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, want, res)
+}
+
+// the attributes of the unset environment variables are not recorded.
+func TestDetectServiceIDOnly(t *testing.T) {
+	setenv(t, map[string]string{serviceIDEnvVar: "8fe1e10304f84fd2b0df550fe98a71fa"})
+
+	want := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSAppRunner,
+	)
+	res, err := NewResourceDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, want, res)
+}
+
+// return empty resource when not running on App Runner.
+func TestReturnsIfNoEnvVars(t *testing.T) {
+	setenv(t, map[string]string{awsRegionEnvVar: "us-east-1"})
+
+	res, err := NewResourceDetector().Detect(context.Background())
+	assert.Equal(t, errNotOnAppRunner, err)
+	assert.Equal(t, 0, len(res.Attributes()))
+}
@@ -0,0 +1,11 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apprunner // import "go.opentelemetry.io/contrib/detectors/aws/apprunner"
+
+// Version is the current release version of the App Runner resource
+// detector.
+func Version() string {
+	return "0.51.0"
+	// This string is updated by the pre_release.sh script during release
+}
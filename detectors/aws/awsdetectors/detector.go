@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package awsdetectors provides a resource detector combining the EC2, ECS,
+// EKS, Lambda, and App Runner resource detectors.
+package awsdetectors // import "go.opentelemetry.io/contrib/detectors/aws/awsdetectors"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/contrib/detectors/aws/apprunner"
+	"go.opentelemetry.io/contrib/detectors/aws/ec2"
+	"go.opentelemetry.io/contrib/detectors/aws/ecs"
+	"go.opentelemetry.io/contrib/detectors/aws/eks"
+	"go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	lambdaFunctionNameEnvVar = "AWS_LAMBDA_FUNCTION_NAME" //nolint:gosec // False positive G101: Potential hardcoded credentials. This is the name of an environment variable.
+	ecsMetadataV3EnvVar      = "ECS_CONTAINER_METADATA_URI"
+	ecsMetadataV4EnvVar      = "ECS_CONTAINER_METADATA_URI_V4"
+	k8sServiceHostEnvVar     = "KUBERNETES_SERVICE_HOST"
+	appRunnerServiceIDEnvVar = "AWS_APP_RUNNER_SERVICE_ID"
+
+	k8sTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token" //nolint:gosec // False positive G101: Potential hardcoded credentials. The detector only check if the token exists.
+	imdsEndpoint = "http://169.254.169.254"
+
+	defaultProbeTimeout = 200 * time.Millisecond
+)
+
+type config struct {
+	probeTimeout time.Duration
+}
+
+// Option applies an option to the detector returned by NewAll.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(c *config) {
+	fn(c)
+}
+
+// WithProbeTimeout sets the maximum duration of each probe of the
+// environment. It is only used for the probes requiring a network request,
+// which is the EC2 instance metadata service probe. The default is 200
+// milliseconds.
+func WithProbeTimeout(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		if d > 0 {
+			c.probeTimeout = d
+		}
+	})
+}
+
+// detector runs the AWS resource detectors matching the environment.
+type detector struct {
+	probeTimeout time.Duration
+	imdsEndpoint string
+	k8sTokenPath string
+
+	// The sub-detectors are created only if their environment matches, as
+	// some of them fail to be created outside of their environment.
+	ec2       func() resource.Detector
+	ecs       func() resource.Detector
+	eks       func() resource.Detector
+	lambda    func() resource.Detector
+	appRunner func() resource.Detector
+}
+
+// compile time assertion that detector implements the resource.Detector interface.
+var _ resource.Detector = (*detector)(nil)
+
+// NewAll returns a resource detector that detects the AWS environment the
+// process runs in, and returns the resource of the matching EC2, ECS, EKS,
+// Lambda, and App Runner resource detectors merged together.
+//
+// The environment is probed as follows:
+//   - Lambda if the AWS_LAMBDA_FUNCTION_NAME environment variable is set. The
+//     other environments are not probed in this case.
+//   - App Runner if the AWS_APP_RUNNER_SERVICE_ID environment variable is
+//     set. The other environments are not probed in this case either.
+//   - ECS if the ECS_CONTAINER_METADATA_URI_V4 or ECS_CONTAINER_METADATA_URI
+//     environment variable is set.
+//   - EKS if the process runs in a Kubernetes cluster, that is if the
+//     KUBERNETES_SERVICE_HOST environment variable is set and the service
+//     account token is mounted.
+//   - EC2 if the instance metadata service responds within the probe
+//     timeout, see WithProbeTimeout.
+//
+// If several environments match, for example ECS tasks running on EC2, the
+// attributes of the most specific environment take precedence: ECS and EKS
+// over EC2.
+func NewAll(opts ...Option) resource.Detector {
+	c := config{probeTimeout: defaultProbeTimeout}
+	for _, o := range opts {
+		o.apply(&c)
+	}
+	return &detector{
+		probeTimeout: c.probeTimeout,
+		imdsEndpoint: imdsEndpoint,
+		k8sTokenPath: k8sTokenPath,
+		ec2:          func() resource.Detector { return ec2.NewResourceDetector() },
+		ecs:          ecs.NewResourceDetector,
+		eks:          eks.NewResourceDetector,
+		lambda:       lambda.NewResourceDetector,
+		appRunner:    apprunner.NewResourceDetector,
+	}
+}
+
+// Detect detects the resource of the AWS environment the process runs in.
+// It returns an empty resource if it does not run in AWS.
+func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if os.Getenv(lambdaFunctionNameEnvVar) != "" {
+		return d.lambda().Detect(ctx)
+	}
+	if os.Getenv(appRunnerServiceIDEnvVar) != "" {
+		return d.appRunner().Detect(ctx)
+	}
+
+	var detectors []resource.Detector
+	// In order of precedence, the attributes of the last detectors override
+	// the ones of the first.
+	if d.onEC2(ctx) {
+		detectors = append(detectors, d.ec2())
+	}
+	if os.Getenv(ecsMetadataV4EnvVar) != "" || os.Getenv(ecsMetadataV3EnvVar) != "" {
+		detectors = append(detectors, d.ecs())
+	}
+	if d.onK8s() {
+		detectors = append(detectors, d.eks())
+	}
+
+	res := resource.Empty()
+	var errs []error
+	for _, det := range detectors {
+		r, err := det.Detect(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			if !errors.Is(err, resource.ErrPartialResource) {
+				continue
+			}
+		}
+		if r == nil {
+			continue
+		}
+		merged, err := resource.Merge(res, r)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		res = merged
+	}
+	return res, errors.Join(errs...)
+}
+
+// onEC2 returns true if the EC2 instance metadata service responds. Any
+// response is accepted, as the service can be configured to only accept
+// some requests.
+func (d *detector) onEC2(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, d.probeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, d.imdsEndpoint+"/latest/api/token", http.NoBody)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return true
+}
+
+// onK8s returns true if the process runs in a Kubernetes cluster.
+func (d *detector) onK8s() bool {
+	if os.Getenv(k8sServiceHostEnvVar) == "" {
+		return false
+	}
+	info, err := os.Stat(d.k8sTokenPath)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awsdetectors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+type fakeDetector struct {
+	res *resource.Resource
+	err error
+}
+
+func (d fakeDetector) Detect(context.Context) (*resource.Resource, error) {
+	return d.res, d.err
+}
+
+func fake(res *resource.Resource, err error) func() resource.Detector {
+	return func() resource.Detector { return fakeDetector{res: res, err: err} }
+}
+
+func platform(p attribute.KeyValue, attrs ...attribute.KeyValue) *resource.Resource {
+	return resource.NewWithAttributes(semconv.SchemaURL, append([]attribute.KeyValue{semconv.CloudProviderAWS, p}, attrs...)...)
+}
+
+var (
+	ec2Res       = platform(semconv.CloudPlatformAWSEC2, semconv.HostID("i-123"))
+	ecsRes       = platform(semconv.CloudPlatformAWSECS, semconv.AWSECSTaskFamily("family"))
+	eksRes       = platform(semconv.CloudPlatformAWSEKS, semconv.K8SClusterName("cluster"))
+	lambdaRes    = platform(semconv.CloudPlatformAWSLambda, semconv.FaaSName("function"))
+	appRunnerRes = platform(semconv.CloudPlatformAWSAppRunner, semconv.ServiceName("service"))
+)
+
+// newTestDetector returns a detector with fake sub-detectors, whose
+// environment is not matched unless set up by the test.
+func newTestDetector(t *testing.T) *detector {
+	t.Helper()
+	for _, k := range []string{lambdaFunctionNameEnvVar, appRunnerServiceIDEnvVar, ecsMetadataV3EnvVar, ecsMetadataV4EnvVar, k8sServiceHostEnvVar} {
+		t.Setenv(k, "")
+	}
+
+	// Nothing listens on a closed server, the EC2 probe fails immediately.
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	return &detector{
+		probeTimeout: defaultProbeTimeout,
+		imdsEndpoint: srv.URL,
+		k8sTokenPath: filepath.Join(t.TempDir(), "token"),
+		ec2:          fake(ec2Res, nil),
+		ecs:          fake(ecsRes, nil),
+		eks:          fake(eksRes, nil),
+		lambda:       fake(lambdaRes, nil),
+		appRunner:    fake(appRunnerRes, nil),
+	}
+}
+
+// onEC2 sets up the instance metadata service of d.
+func onEC2(t *testing.T, d *detector) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/latest/api/token", r.URL.Path)
+		_, _ = w.Write([]byte("token"))
+	}))
+	t.Cleanup(srv.Close)
+	d.imdsEndpoint = srv.URL
+}
+
+// onEKS sets up the Kubernetes environment of d.
+func onEKS(t *testing.T, d *detector) {
+	t.Setenv(k8sServiceHostEnvVar, "10.0.0.1")
+	require.NoError(t, os.WriteFile(d.k8sTokenPath, []byte("token"), 0o600))
+}
+
+func TestDetectNotOnAWS(t *testing.T) {
+	d := newTestDetector(t)
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestDetectLambda(t *testing.T) {
+	d := newTestDetector(t)
+	t.Setenv(lambdaFunctionNameEnvVar, "function")
+	// The other environments are not probed on Lambda.
+	onEC2(t, d)
+	d.ec2 = func() resource.Detector {
+		t.Error("EC2 detector used on Lambda")
+		return fakeDetector{}
+	}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, lambdaRes, res)
+}
+
+func TestDetectAppRunner(t *testing.T) {
+	d := newTestDetector(t)
+	t.Setenv(appRunnerServiceIDEnvVar, "8fe1e10304f84fd2b0df550fe98a71fa")
+	// The other environments are not probed on App Runner.
+	onEC2(t, d)
+	d.ec2 = func() resource.Detector {
+		t.Error("EC2 detector used on App Runner")
+		return fakeDetector{}
+	}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, appRunnerRes, res)
+}
+
+func TestDetectEC2(t *testing.T) {
+	d := newTestDetector(t)
+	onEC2(t, d)
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ec2Res, res)
+}
+
+func TestDetectECS(t *testing.T) {
+	for _, env := range []string{ecsMetadataV3EnvVar, ecsMetadataV4EnvVar} {
+		t.Run(env, func(t *testing.T) {
+			d := newTestDetector(t)
+			t.Setenv(env, "http://169.254.170.2/v4/container")
+
+			res, err := d.Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, ecsRes, res)
+		})
+	}
+}
+
+func TestDetectECSOnEC2(t *testing.T) {
+	d := newTestDetector(t)
+	onEC2(t, d)
+	t.Setenv(ecsMetadataV4EnvVar, "http://169.254.170.2/v4/container")
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	want := platform(semconv.CloudPlatformAWSECS, semconv.HostID("i-123"), semconv.AWSECSTaskFamily("family"))
+	assert.Equal(t, want, res)
+}
+
+func TestDetectEKS(t *testing.T) {
+	d := newTestDetector(t)
+	onEKS(t, d)
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, eksRes, res)
+}
+
+func TestDetectEKSWithoutToken(t *testing.T) {
+	d := newTestDetector(t)
+	t.Setenv(k8sServiceHostEnvVar, "10.0.0.1")
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestDetectErrors(t *testing.T) {
+	d := newTestDetector(t)
+	onEC2(t, d)
+	onEKS(t, d)
+	partial := resource.NewWithAttributes(semconv.SchemaURL, semconv.CloudProviderAWS, semconv.CloudPlatformAWSEC2)
+	partialErr := errors.Join(resource.ErrPartialResource, errors.New("hostname"))
+	d.ec2 = fake(partial, partialErr)
+	eksErr := errors.New("eks")
+	d.eks = fake(nil, eksErr)
+
+	res, err := d.Detect(context.Background())
+	assert.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorIs(t, err, eksErr)
+	// The partial resource is kept, the failed detection is skipped.
+	assert.Equal(t, partial, res)
+}
+
+func TestDetectEC2ProbeTimeout(t *testing.T) {
+	d := newTestDetector(t)
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(done) })
+	d.imdsEndpoint = srv.URL
+	d.probeTimeout = 10 * time.Millisecond
+
+	start := time.Now()
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWithProbeTimeout(t *testing.T) {
+	d := NewAll(WithProbeTimeout(time.Second)).(*detector)
+	assert.Equal(t, time.Second, d.probeTimeout)
+
+	d = NewAll(WithProbeTimeout(0)).(*detector)
+	assert.Equal(t, defaultProbeTimeout, d.probeTimeout)
+}
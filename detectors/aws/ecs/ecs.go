@@ -84,8 +84,6 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSECS,
-		semconv.ContainerName(hostName),
-		semconv.ContainerID(containerID),
 	}
 
 	if len(metadataURIV4) > 0 {
@@ -99,6 +97,16 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 			return empty, err
 		}
 
+		// The container metadata is more reliable than the hostname and
+		// cgroup file (e.g. when using cgroup v2).
+		if containerMetadata.DockerName != "" {
+			hostName = containerMetadata.DockerName
+		}
+		if containerMetadata.DockerID != "" {
+			containerID = containerMetadata.DockerID
+		}
+		attributes = append(attributes, containerImageAttributes(containerMetadata.Image)...)
+
 		baseArn := detector.getBaseArn(
 			taskMetadata.TaskARN,
 			containerMetadata.ContainerARN,
@@ -129,6 +137,11 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 			semconv.CloudAccountID(arnParts[4]),
 		)
 
+		// The launch type is not reported by older ECS container agents.
+		if launchType := strings.ToLower(taskMetadata.LaunchType); launchType != "" {
+			attributes = append(attributes, semconv.AWSECSLaunchtypeKey.String(launchType))
+		}
+
 		availabilityZone := taskMetadata.AvailabilityZone
 		if len(availabilityZone) > 0 {
 			attributes = append(
@@ -151,16 +164,49 @@ func (detector *resourceDetector) Detect(ctx context.Context) (*resource.Resourc
 			semconv.CloudResourceID(containerMetadata.ContainerARN),
 			semconv.AWSECSContainerARN(containerMetadata.ContainerARN),
 			semconv.AWSECSClusterARN(taskMetadata.Cluster),
-			semconv.AWSECSLaunchtypeKey.String(strings.ToLower(taskMetadata.LaunchType)),
 			semconv.AWSECSTaskARN(taskMetadata.TaskARN),
 			semconv.AWSECSTaskFamily(taskMetadata.Family),
 			semconv.AWSECSTaskRevision(taskMetadata.Revision),
 		)
 	}
 
+	attributes = append(
+		attributes,
+		semconv.ContainerName(hostName),
+		semconv.ContainerID(containerID),
+	)
+
 	return resource.NewWithAttributes(semconv.SchemaURL, attributes...), nil
 }
 
+// containerImageAttributes returns the container image name and tag
+// attributes parsed from the image reference (e.g.
+// "111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest:latest").
+//
+// If the reference does not contain a tag, the "latest" tag is assumed unless
+// the image is referenced by digest, in which case no tag attribute is
+// returned.
+func containerImageAttributes(image string) []attribute.KeyValue {
+	if image == "" {
+		return nil
+	}
+
+	name, digest, _ := strings.Cut(image, "@")
+	var tag string
+	// A colon before the last slash separates a registry host from its port.
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	} else if digest == "" {
+		tag = "latest"
+	}
+
+	attrs := []attribute.KeyValue{semconv.ContainerImageName(name)}
+	if tag != "" {
+		attrs = append(attrs, semconv.ContainerImageTags(tag))
+	}
+	return attrs
+}
+
 func (detector *resourceDetector) getBaseArn(arns ...string) string {
 	for _, arn := range arns {
 		if i := strings.LastIndex(arn, ":"); i >= 0 {
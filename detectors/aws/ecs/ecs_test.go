@@ -117,6 +117,33 @@ func TestDetectV4(t *testing.T) {
 	assert.Equal(t, expectedResource, res, "Resource returned is incorrect")
 }
 
+// successfully returns resource without the launch type when it is not
+// reported by the Metadata v4 endpoint.
+func TestDetectV4NoLaunchType(t *testing.T) {
+	t.Setenv(metadataV4EnvVar, "4")
+
+	detectorUtils := new(MockDetectorUtils)
+
+	detectorUtils.On("getContainerName").Return("container-Name", nil)
+	detectorUtils.On("getContainerID").Return("0123456789A", nil)
+	detectorUtils.On("getContainerMetadataV4").Return(&metadata.ContainerMetadataV4{
+		ContainerARN: "arn:aws:ecs:us-west-2:111122223333:container/05966557-f16c-49cb-9352-24b3a0dcd0e1",
+	}, nil)
+	detectorUtils.On("getTaskMetadataV4").Return(&metadata.TaskMetadataV4{
+		Cluster:  "arn:aws:ecs:us-west-2:111122223333:cluster/default",
+		TaskARN:  "arn:aws:ecs:us-west-2:111122223333:task/default/e9028f8d5d8e4f258373e7b93ce9a3c3",
+		Family:   "curltest",
+		Revision: "3",
+	}, nil)
+
+	detector := &resourceDetector{utils: detectorUtils}
+	res, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+
+	_, ok := res.Set().Value(semconv.AWSECSLaunchtypeKey)
+	assert.False(t, ok, "launch type should not be set")
+}
+
 // returns empty resource when detector receives a bad task ARN from the Metadata v4 endpoint.
 func TestDetectBadARNsv4(t *testing.T) {
 	t.Setenv(metadataV4EnvVar, "4")
@@ -230,3 +257,53 @@ func TestLogsAttributesAlternatePartition(t *testing.T) {
 	}
 	assert.Equal(t, expectedAttributes, actualAttributes, "logs attributes are incorrect")
 }
+
+func TestContainerImageAttributes(t *testing.T) {
+	tests := []struct {
+		image string
+		want  []attribute.KeyValue
+	}{
+		{
+			image: "111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest:1.2.3",
+			want: []attribute.KeyValue{
+				semconv.ContainerImageName("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+				semconv.ContainerImageTags("1.2.3"),
+			},
+		},
+		{
+			image: "amazon/amazon-ecs-pause",
+			want: []attribute.KeyValue{
+				semconv.ContainerImageName("amazon/amazon-ecs-pause"),
+				semconv.ContainerImageTags("latest"),
+			},
+		},
+		{
+			image: "localhost:5000/curltest",
+			want: []attribute.KeyValue{
+				semconv.ContainerImageName("localhost:5000/curltest"),
+				semconv.ContainerImageTags("latest"),
+			},
+		},
+		{
+			image: "localhost:5000/curltest:edge",
+			want: []attribute.KeyValue{
+				semconv.ContainerImageName("localhost:5000/curltest"),
+				semconv.ContainerImageTags("edge"),
+			},
+		},
+		{
+			image: "curltest@sha256:d691691e9652791a60114e67b365688d20d19940dde7c4736ea30e660d8d3553",
+			want: []attribute.KeyValue{
+				semconv.ContainerImageName("curltest"),
+			},
+		},
+		{
+			image: "",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, containerImageAttributes(tt.image), tt.image)
+	}
+}
@@ -49,9 +49,6 @@ func TestDetectV4LaunchTypeEc2(t *testing.T) {
 
 	t.Setenv(metadataV4EnvVar, testServer.URL)
 
-	hostname, err := os.Hostname()
-	assert.NoError(t, err, "Error")
-
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSECS,
@@ -59,11 +56,10 @@ func TestDetectV4LaunchTypeEc2(t *testing.T) {
 		semconv.CloudRegion("us-west-2"),
 		semconv.CloudAvailabilityZone("us-west-2d"),
 		semconv.CloudResourceID("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
-		semconv.ContainerName(hostname),
-		// We are not running the test in an actual container,
-		// the container id is tested with mocks of the cgroup
-		// file in the unit tests
-		semconv.ContainerID(""),
+		semconv.ContainerName("ecs-curltest-24-curl-cca48e8dcadd97805600"),
+		semconv.ContainerID("ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"),
+		semconv.ContainerImageName("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+		semconv.ContainerImageTags("latest"),
 		semconv.AWSECSContainerARN("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
 		semconv.AWSECSClusterARN("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
 		semconv.AWSECSLaunchtypeKey.String("ec2"),
@@ -109,9 +105,6 @@ func TestDetectV4LaunchTypeEc2BadContainerArn(t *testing.T) {
 
 	t.Setenv(metadataV4EnvVar, testServer.URL)
 
-	hostname, err := os.Hostname()
-	assert.NoError(t, err, "Error")
-
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSECS,
@@ -119,11 +112,10 @@ func TestDetectV4LaunchTypeEc2BadContainerArn(t *testing.T) {
 		semconv.CloudRegion("us-west-2"),
 		semconv.CloudAvailabilityZone("us-west-2d"),
 		semconv.CloudResourceID("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
-		semconv.ContainerName(hostname),
-		// We are not running the test in an actual container,
-		// the container id is tested with mocks of the cgroup
-		// file in the unit tests
-		semconv.ContainerID(""),
+		semconv.ContainerName("ecs-curltest-24-curl-cca48e8dcadd97805600"),
+		semconv.ContainerID("ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"),
+		semconv.ContainerImageName("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+		semconv.ContainerImageTags("latest"),
 		semconv.AWSECSContainerARN("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
 		semconv.AWSECSClusterARN("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
 		semconv.AWSECSLaunchtypeKey.String("ec2"),
@@ -169,21 +161,17 @@ func TestDetectV4LaunchTypeEc2BadTaskArn(t *testing.T) {
 
 	t.Setenv(metadataV4EnvVar, testServer.URL)
 
-	hostname, err := os.Hostname()
-	assert.NoError(t, err, "Error")
-
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSECS,
-		semconv.ContainerName(hostname),
+		semconv.ContainerName("ecs-curltest-24-curl-cca48e8dcadd97805600"),
 		semconv.CloudAccountID("111122223333"),
 		semconv.CloudRegion("us-west-2"),
 		semconv.CloudAvailabilityZone("us-west-2d"),
 		semconv.CloudResourceID("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
-		// We are not running the test in an actual container,
-		// the container id is tested with mocks of the cgroup
-		// file in the unit tests
-		semconv.ContainerID(""),
+		semconv.ContainerID("ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"),
+		semconv.ContainerImageName("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+		semconv.ContainerImageTags("latest"),
 		semconv.AWSECSContainerARN("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
 		semconv.AWSECSClusterARN("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
 		semconv.AWSECSLaunchtypeKey.String("ec2"),
@@ -229,21 +217,17 @@ func TestDetectV4LaunchTypeFargate(t *testing.T) {
 
 	t.Setenv(metadataV4EnvVar, testServer.URL)
 
-	hostname, err := os.Hostname()
-	assert.NoError(t, err, "Error")
-
 	attributes := []attribute.KeyValue{
 		semconv.CloudProviderAWS,
 		semconv.CloudPlatformAWSECS,
-		semconv.ContainerName(hostname),
+		semconv.ContainerName("curl"),
 		semconv.CloudAccountID("111122223333"),
 		semconv.CloudRegion("us-west-2"),
 		semconv.CloudAvailabilityZone("us-west-2a"),
 		semconv.CloudResourceID("arn:aws:ecs:us-west-2:111122223333:container/05966557-f16c-49cb-9352-24b3a0dcd0e1"),
-		// We are not running the test in an actual container,
-		// the container id is tested with mocks of the cgroup
-		// file in the unit tests
-		semconv.ContainerID(""),
+		semconv.ContainerID("cd189a933e5849daa93386466019ab50-2495160603"),
+		semconv.ContainerImageName("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+		semconv.ContainerImageTags("latest"),
 		semconv.AWSECSContainerARN("arn:aws:ecs:us-west-2:111122223333:container/05966557-f16c-49cb-9352-24b3a0dcd0e1"),
 		semconv.AWSECSClusterARN("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
 		semconv.AWSECSLaunchtypeKey.String("fargate"),
@@ -5,7 +5,10 @@ package gcp // import "go.opentelemetry.io/contrib/detectors/gcp"
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"cloud.google.com/go/compute/metadata"
 	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/detectors/gcp"
@@ -22,11 +25,46 @@ import (
 // * Cloud Run.
 // * Cloud Functions.
 func NewDetector() resource.Detector {
-	return &detector{detector: gcp.NewDetector()}
+	return &detector{detector: gcp.NewDetector(), lookupEnv: os.LookupEnv}
 }
 
 type detector struct {
-	detector gcpDetector
+	detector  gcpDetector
+	lookupEnv func(string) (string, bool)
+}
+
+// Environment variables set by the legacy runtimes of 1st gen Cloud
+// Functions. The runtimes of 2nd gen Cloud Functions, which run on Cloud Run,
+// and the newer runtimes of 1st gen instead set the K_SERVICE, K_REVISION and
+// FUNCTION_TARGET variables, which are handled by the GCP detection library.
+const (
+	legacyFunctionNameEnv    = "FUNCTION_NAME"
+	legacyFunctionVersionEnv = "X_GOOGLE_FUNCTION_VERSION"
+	legacyFunctionRegionEnv  = "FUNCTION_REGION"
+)
+
+var errEnvVarNotFound = errors.New("environment variable not found")
+
+// env returns a function returning the value of the environment variable
+// key, to be used with the resourceBuilder.
+func (d *detector) env(key string) func() (string, error) {
+	lookupEnv := d.lookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	return func() (string, error) {
+		if v, ok := lookupEnv(key); ok {
+			return v, nil
+		}
+		return "", fmt.Errorf("%w: %s", errEnvVarNotFound, key)
+	}
+}
+
+// onLegacyCloudFunctions reports whether the process runs on a legacy
+// runtime of 1st gen Cloud Functions.
+func (d *detector) onLegacyCloudFunctions() bool {
+	_, err := d.env(legacyFunctionNameEnv)()
+	return err == nil
 }
 
 // Detect detects associated resources when running on GCE, GKE, GAE,
@@ -39,7 +77,19 @@ func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
 	b.attrs = append(b.attrs, semconv.CloudProviderGCP)
 	b.add(semconv.CloudAccountIDKey, d.detector.ProjectID)
 
-	switch d.detector.CloudPlatform() {
+	platform := d.detector.CloudPlatform()
+	if (platform == gcp.GCE || platform == gcp.UnknownPlatform) && d.onLegacyCloudFunctions() {
+		// The legacy 1st gen runtimes are not detected by the GCP
+		// detection library, they are reported as GCE.
+		b.attrs = append(b.attrs, semconv.CloudPlatformGCPCloudFunctions)
+		b.add(semconv.FaaSNameKey, d.env(legacyFunctionNameEnv))
+		b.add(semconv.FaaSVersionKey, d.env(legacyFunctionVersionEnv))
+		b.add(semconv.FaaSInstanceKey, d.detector.FaaSID)
+		b.add(semconv.CloudRegionKey, d.env(legacyFunctionRegionEnv))
+		return b.build()
+	}
+
+	switch platform {
 	case gcp.GKE:
 		b.attrs = append(b.attrs, semconv.CloudPlatformGCPKubernetesEngine)
 		b.addZoneOrRegion(d.detector.GKEAvailabilityZoneOrRegion)
@@ -52,6 +102,8 @@ func (d *detector) Detect(ctx context.Context) (*resource.Resource, error) {
 		b.add(semconv.FaaSInstanceKey, d.detector.FaaSID)
 		b.add(semconv.CloudRegionKey, d.detector.FaaSCloudRegion)
 	case gcp.CloudFunctions:
+		// Both 2nd gen Cloud Functions and the newer 1st gen runtimes set
+		// the same environment variables as Cloud Run services.
 		b.attrs = append(b.attrs, semconv.CloudPlatformGCPCloudFunctions)
 		b.add(semconv.FaaSNameKey, d.detector.FaaSName)
 		b.add(semconv.FaaSVersionKey, d.detector.FaaSVersion)
@@ -114,6 +166,9 @@ func (r *resourceBuilder) addZoneOrRegion(detect func() (string, gcp.LocationTyp
 		switch locType {
 		case gcp.Zone:
 			r.attrs = append(r.attrs, semconv.CloudAvailabilityZone(v))
+			if region, ok := regionFromZone(v); ok {
+				r.attrs = append(r.attrs, semconv.CloudRegion(region))
+			}
 		case gcp.Region:
 			r.attrs = append(r.attrs, semconv.CloudRegion(v))
 		default:
@@ -124,6 +179,20 @@ func (r *resourceBuilder) addZoneOrRegion(detect func() (string, gcp.LocationTyp
 	}
 }
 
+// regionFromZone returns the region of zone, zone without its trailing
+// "-<letter>" (e.g. "us-central1" for "us-central1-a"). It returns false if
+// zone does not have this form.
+func regionFromZone(zone string) (string, bool) {
+	i := strings.LastIndexByte(zone, '-')
+	if i <= 0 || i != len(zone)-2 {
+		return "", false
+	}
+	if c := zone[i+1]; c < 'a' || c > 'z' {
+		return "", false
+	}
+	return zone[:i], true
+}
+
 func (r *resourceBuilder) build() (*resource.Resource, error) {
 	var err error
 	if len(r.errs) > 0 {
@@ -40,6 +40,7 @@ func TestDetect(t *testing.T) {
 				semconv.CloudPlatformGCPKubernetesEngine,
 				semconv.K8SClusterName("my-cluster"),
 				semconv.CloudAvailabilityZone("us-central1-c"),
+				semconv.CloudRegion("us-central1"),
 				semconv.HostID("1472385723456792345"),
 			),
 		},
@@ -205,6 +206,114 @@ func TestDetect(t *testing.T) {
 	}
 }
 
+// envGCPDetector detects the platform and FaaS attributes from the
+// environment variables using the GCP detection library. The attributes read
+// from the metadata server are faked.
+type envGCPDetector struct {
+	*gcp.Detector
+}
+
+func (envGCPDetector) ProjectID() (string, error)       { return "my-project", nil }
+func (envGCPDetector) FaaSID() (string, error)          { return "1472385723456792345", nil }
+func (envGCPDetector) FaaSCloudRegion() (string, error) { return "us-central1", nil }
+
+func TestDetectCloudFunctions(t *testing.T) {
+	// Set this before all tests to ensure metadata.onGCE() returns true
+	t.Setenv("GCE_METADATA_HOST", "169.254.169.254")
+
+	functionResource := func(name, version string) *resource.Resource {
+		return resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.CloudProviderGCP,
+			semconv.CloudAccountID("my-project"),
+			semconv.CloudPlatformGCPCloudFunctions,
+			semconv.FaaSName(name),
+			semconv.FaaSVersion(version),
+			semconv.FaaSInstance("1472385723456792345"),
+			semconv.CloudRegion("us-central1"),
+		)
+	}
+
+	t.Run("gen2", func(t *testing.T) {
+		// 2nd gen functions run on Cloud Run, K_CONFIGURATION is set and
+		// K_REVISION is the name of the Cloud Run revision.
+		t.Setenv("K_SERVICE", "my-gen2-function")
+		t.Setenv("K_REVISION", "my-gen2-function-00002-abc")
+		t.Setenv("K_CONFIGURATION", "my-gen2-function")
+		t.Setenv("FUNCTION_TARGET", "HelloWorld")
+		t.Setenv("FUNCTION_SIGNATURE_TYPE", "http")
+
+		d := &detector{detector: envGCPDetector{gcp.NewDetector()}}
+		res, err := d.Detect(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, functionResource("my-gen2-function", "my-gen2-function-00002-abc"), res)
+	})
+
+	t.Run("gen1", func(t *testing.T) {
+		// The newer 1st gen runtimes do not set K_CONFIGURATION and
+		// K_REVISION is the version number of the function.
+		t.Setenv("K_SERVICE", "my-gen1-function")
+		t.Setenv("K_REVISION", "3")
+		t.Setenv("FUNCTION_TARGET", "HelloWorld")
+		t.Setenv("FUNCTION_SIGNATURE_TYPE", "event")
+
+		d := &detector{detector: envGCPDetector{gcp.NewDetector()}}
+		res, err := d.Detect(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, functionResource("my-gen1-function", "3"), res)
+	})
+
+	legacyEnv := func(key string) (string, bool) {
+		v, ok := map[string]string{
+			"FUNCTION_NAME":             "my-legacy-function",
+			"X_GOOGLE_FUNCTION_VERSION": "7",
+			"FUNCTION_REGION":           "us-central1",
+			"GCP_PROJECT":               "my-project",
+		}[key]
+		return v, ok
+	}
+
+	t.Run("gen1 legacy runtime", func(t *testing.T) {
+		d := &detector{
+			// The legacy runtimes are not detected by the GCP
+			// detection library, the instance is detected as GCE.
+			detector: &fakeGCPDetector{
+				projectID:     "my-project",
+				cloudPlatform: gcp.GCE,
+				faaSID:        "1472385723456792345",
+			},
+			lookupEnv: legacyEnv,
+		}
+		res, err := d.Detect(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, functionResource("my-legacy-function", "7"), res)
+	})
+
+	t.Run("legacy variables on GKE", func(t *testing.T) {
+		// The legacy variables do not override the detected platform.
+		d := &detector{
+			detector: &fakeGCPDetector{
+				projectID:           "my-project",
+				cloudPlatform:       gcp.GKE,
+				gkeHostID:           "1472385723456792345",
+				gkeClusterName:      "my-cluster",
+				gkeAvailabilityZone: "us-central1-c",
+			},
+			lookupEnv: legacyEnv,
+		}
+		res, err := d.Detect(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.CloudProviderGCP,
+			semconv.CloudAccountID("my-project"),
+			semconv.CloudPlatformGCPKubernetesEngine,
+			semconv.K8SClusterName("my-cluster"),
+			semconv.CloudAvailabilityZone("us-central1-c"),
+			semconv.CloudRegion("us-central1"),
+			semconv.HostID("1472385723456792345"),
+		), res)
+	})
+}
+
 // fakeGCPDetector implements gcpDetector and uses fake values.
 type fakeGCPDetector struct {
 	err                       error
@@ -386,3 +495,26 @@ func (f *fakeGCPDetector) GCEInstanceHostname() (string, error) {
 	}
 	return f.gcpGceInstanceHostname, nil
 }
+
+func TestRegionFromZone(t *testing.T) {
+	for _, tc := range []struct {
+		zone       string
+		wantRegion string
+		wantOK     bool
+	}{
+		{zone: "us-central1-a", wantRegion: "us-central1", wantOK: true},
+		{zone: "europe-west4-c", wantRegion: "europe-west4", wantOK: true},
+		{zone: "us-central1"},
+		{zone: "us-central1-"},
+		{zone: "us-central1-ab"},
+		{zone: "us-central1-1"},
+		{zone: "-a"},
+		{zone: ""},
+	} {
+		t.Run(tc.zone, func(t *testing.T) {
+			region, ok := regionFromZone(tc.zone)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantRegion, region)
+		})
+	}
+}
@@ -6,13 +6,17 @@
 package otelgin // import "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin/internal/semconvutil"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
@@ -57,29 +61,73 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 		defer func() {
 			c.Request = c.Request.WithContext(savedCtx)
 		}()
-		ctx := cfg.Propagators.Extract(savedCtx, propagation.HeaderCarrier(c.Request.Header))
+		var ctx context.Context
+		if cfg.ContextExtractor != nil {
+			ctx = cfg.ContextExtractor(c)
+		}
+		if ctx == nil {
+			ctx = cfg.Propagators.Extract(savedCtx, propagation.HeaderCarrier(c.Request.Header))
+		}
 		opts := []oteltrace.SpanStartOption{
 			oteltrace.WithAttributes(semconvutil.HTTPServerRequest(service, c.Request)...),
 			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 		}
+		route := c.FullPath()
 		var spanName string
-		if cfg.SpanNameFormatter == nil {
-			spanName = c.FullPath()
-		} else {
+		switch {
+		case cfg.GinSpanNameFormatter != nil:
+			spanName = cfg.GinSpanNameFormatter(c)
+		case cfg.SpanNameFormatter != nil:
 			spanName = cfg.SpanNameFormatter(c.Request)
+		case route != "":
+			spanName = c.Request.Method + " " + route
 		}
 		if spanName == "" {
 			spanName = fmt.Sprintf("HTTP %s route not found", c.Request.Method)
-		} else {
-			rAttr := semconv.HTTPRoute(spanName)
-			opts = append(opts, oteltrace.WithAttributes(rAttr))
+		}
+		if route != "" {
+			opts = append(opts, oteltrace.WithAttributes(semconv.HTTPRoute(route)))
+		}
+		if cfg.HandlerNameAttribute {
+			opts = append(opts, oteltrace.WithAttributes(handlerNameAttrs(c.HandlerName())...))
+		}
+		if len(cfg.CapturedRequestHeaders) > 0 {
+			opts = append(opts, oteltrace.WithAttributes(headerAttrs("http.request.header.", c.Request.Header, cfg.CapturedRequestHeaders)...))
 		}
 		ctx, span := tracer.Start(ctx, spanName, opts...)
 		defer span.End()
 
-		// pass the span through the request context
+		if !cfg.DisablePanicRecording {
+			// Record the panic and end the span before re-panicking so any
+			// recovery middleware registered before this one still handles
+			// it.
+			defer func() {
+				if r := recover(); r != nil {
+					err, ok := r.(error)
+					if !ok {
+						err = fmt.Errorf("%v", r)
+					}
+					span.RecordError(err, oteltrace.WithStackTrace(true))
+					span.SetStatus(codes.Error, err.Error())
+					span.SetAttributes(semconv.HTTPStatusCode(http.StatusInternalServerError))
+					span.End()
+					panic(r)
+				}
+			}()
+		}
+
+		// pass the span and baggage through the request context
 		c.Request = c.Request.WithContext(ctx)
 
+		if len(cfg.BaggageKeys) > 0 {
+			bag := baggage.FromContext(ctx)
+			for _, k := range cfg.BaggageKeys {
+				if m := bag.Member(k); m.Key() != "" {
+					c.Set(k, m.Value())
+				}
+			}
+		}
+
 		// serve the request to the next middleware
 		c.Next()
 
@@ -91,9 +139,20 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 		if len(c.Errors) > 0 {
 			span.SetAttributes(attribute.String("gin.errors", c.Errors.String()))
 		}
+		if len(cfg.CapturedResponseHeaders) > 0 {
+			span.SetAttributes(headerAttrs("http.response.header.", c.Writer.Header(), cfg.CapturedResponseHeaders)...)
+		}
 	}
 }
 
+// Baggage returns the baggage of the request handled by c. The baggage is
+// extracted from the request by the Middleware, using the configured
+// propagators, which must include a baggage propagator such as
+// propagation.Baggage. An empty baggage is returned if there is none.
+func Baggage(c *gin.Context) baggage.Baggage {
+	return baggage.FromContext(c.Request.Context())
+}
+
 // HTML will trace the rendering of the template as a child of the
 // span in the given context. This is a replacement for
 // gin.Context.HTML function - it invokes the original function after
@@ -128,3 +187,39 @@ func HTML(c *gin.Context, code int, name string, obj interface{}) {
 	}()
 	c.HTML(code, name, obj)
 }
+
+// handlerNameAttrs returns the code.namespace and code.function attributes of
+// the handler named name, the package path of the handler and the rest of its
+// name.
+func handlerNameAttrs(name string) []attribute.KeyValue {
+	if name == "" {
+		return nil
+	}
+	// The package path can contain dots, only look for the separator after
+	// its last element.
+	start := strings.LastIndexByte(name, '/') + 1
+	i := strings.IndexByte(name[start:], '.')
+	if i < 0 {
+		return []attribute.KeyValue{semconv.CodeFunction(name)}
+	}
+	i += start
+	return []attribute.KeyValue{
+		semconv.CodeNamespace(name[:i]),
+		semconv.CodeFunction(name[i+1:]),
+	}
+}
+
+// headerAttrs returns the attributes of the headers names present in h, the
+// prefix followed by the lowercase name of a header, and its values joined
+// with commas.
+func headerAttrs(prefix string, h http.Header, names []string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(names))
+	for _, name := range names {
+		values := h.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		attrs = append(attrs, attribute.String(prefix+strings.ToLower(name), strings.Join(values, ",")))
+	}
+	return attrs
+}
@@ -13,8 +13,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
@@ -95,3 +98,172 @@ func TestPropagationWithCustomPropagators(t *testing.T) {
 
 	router.ServeHTTP(w, r)
 }
+
+func TestPropagationWithCustomPropagatorsOverridesGlobal(t *testing.T) {
+	global := otel.GetTextMapPropagator()
+	t.Cleanup(func() { otel.SetTextMapPropagator(global) })
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	provider := noop.NewTracerProvider()
+	b3 := b3prop.New(b3prop.WithInjectEncoding(b3prop.B3SingleHeader))
+
+	b3SC := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	})
+	w3cSC := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x02},
+		SpanID:  trace.SpanID{0x02},
+	})
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	b3.Inject(trace.ContextWithRemoteSpanContext(context.Background(), b3SC), propagation.HeaderCarrier(r.Header))
+	// A W3C header the global propagator would extract must be ignored.
+	otel.GetTextMapPropagator().Inject(trace.ContextWithRemoteSpanContext(context.Background(), w3cSC), propagation.HeaderCarrier(r.Header))
+	require.NotEmpty(t, r.Header.Get("b3"))
+	require.NotEmpty(t, r.Header.Get("traceparent"))
+
+	router := gin.New()
+	router.Use(Middleware("foobar", WithTracerProvider(provider), WithPropagators(b3)))
+	var called bool
+	router.GET("/user/:id", func(c *gin.Context) {
+		called = true
+		span := trace.SpanFromContext(c.Request.Context())
+		assert.Equal(t, b3SC.TraceID(), span.SpanContext().TraceID())
+		assert.Equal(t, b3SC.SpanID(), span.SpanContext().SpanID())
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), r)
+	assert.True(t, called, "handler not called")
+}
+
+func TestPropagationWithContextExtractor(t *testing.T) {
+	provider := noop.NewTracerProvider()
+	b3 := b3prop.New()
+
+	headerSC := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	})
+	ginSC := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x02},
+		SpanID:  trace.SpanID{0x02},
+	})
+	const parentKey = "parent"
+
+	extractor := func(c *gin.Context) context.Context {
+		v, ok := c.Get(parentKey)
+		if !ok {
+			return nil
+		}
+		return trace.ContextWithRemoteSpanContext(c.Request.Context(), v.(trace.SpanContext))
+	}
+
+	tests := []struct {
+		name string
+		set  bool
+		want trace.SpanContext
+	}{
+		{name: "Extractor", set: true, want: ginSC},
+		{name: "Fallback", set: false, want: headerSC},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/user/123", nil)
+			w := httptest.NewRecorder()
+
+			ctx := trace.ContextWithRemoteSpanContext(context.Background(), headerSC)
+			b3.Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.set {
+					c.Set(parentKey, ginSC)
+				}
+			})
+			router.Use(Middleware(
+				"foobar",
+				WithTracerProvider(provider),
+				WithPropagators(b3),
+				WithContextExtractor(extractor),
+			))
+			var got trace.SpanContext
+			router.GET("/user/:id", func(c *gin.Context) {
+				got = trace.SpanContextFromContext(c.Request.Context())
+			})
+
+			router.ServeHTTP(w, r)
+			assert.Equal(t, tt.want.TraceID(), got.TraceID())
+			assert.Equal(t, tt.want.SpanID(), got.SpanID())
+		})
+	}
+}
+
+func TestBaggage(t *testing.T) {
+	provider := noop.NewTracerProvider()
+	prop := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	w := httptest.NewRecorder()
+
+	tenant, err := baggage.NewMember("tenant", "acme")
+	require.NoError(t, err)
+	bag, err := baggage.New(tenant)
+	require.NoError(t, err)
+	prop.Inject(baggage.ContextWithBaggage(context.Background(), bag), propagation.HeaderCarrier(r.Header))
+
+	var called bool
+	router := gin.New()
+	router.Use(Middleware("foobar",
+		WithTracerProvider(provider),
+		WithPropagators(prop),
+		WithBaggageKeys("tenant", "missing"),
+	))
+	router.GET("/user/:id", func(c *gin.Context) {
+		called = true
+		assert.Equal(t, "acme", Baggage(c).Member("tenant").Value())
+
+		v, ok := c.Get("tenant")
+		assert.True(t, ok, "baggage key should be copied")
+		assert.Equal(t, "acme", v)
+		_, ok = c.Get("missing")
+		assert.False(t, ok, "missing baggage key should not be set")
+	})
+
+	router.ServeHTTP(w, r)
+	assert.True(t, called)
+}
+
+func TestBaggageNotInstrumented(t *testing.T) {
+	router := gin.New()
+	router.GET("/ping", func(c *gin.Context) {
+		assert.Equal(t, 0, Baggage(c).Len())
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ping", nil))
+}
+
+func TestHandlerNameAttrs(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want []attribute.KeyValue
+	}{
+		{name: "", want: nil},
+		{name: "main.getUser", want: []attribute.KeyValue{
+			attribute.String("code.namespace", "main"),
+			attribute.String("code.function", "getUser"),
+		}},
+		{name: "github.com/example/app.v2.getUser.func1", want: []attribute.KeyValue{
+			attribute.String("code.namespace", "github.com/example/app"),
+			attribute.String("code.function", "v2.getUser.func1"),
+		}},
+		{name: "github.com/example/app.(*Server).getUser-fm", want: []attribute.KeyValue{
+			attribute.String("code.namespace", "github.com/example/app"),
+			attribute.String("code.function", "(*Server).getUser-fm"),
+		}},
+		{name: "getUser", want: []attribute.KeyValue{
+			attribute.String("code.function", "getUser"),
+		}},
+	} {
+		assert.Equal(t, tc.want, handlerNameAttrs(tc.name), tc.name)
+	}
+}
@@ -6,17 +6,29 @@
 package otelgin // import "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/gin-gonic/gin"
+
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type config struct {
-	TracerProvider    oteltrace.TracerProvider
-	Propagators       propagation.TextMapPropagator
-	Filters           []Filter
-	SpanNameFormatter SpanNameFormatter
+	TracerProvider       oteltrace.TracerProvider
+	Propagators          propagation.TextMapPropagator
+	Filters              []Filter
+	SpanNameFormatter    SpanNameFormatter
+	GinSpanNameFormatter GinSpanNameFormatter
+	ContextExtractor     ContextExtractor
+	BaggageKeys          []string
+
+	CapturedRequestHeaders  []string
+	CapturedResponseHeaders []string
+
+	DisablePanicRecording bool
+	HandlerNameAttribute  bool
 }
 
 // Filter is a predicate used to determine whether a given http.request should
@@ -26,6 +38,15 @@ type Filter func(*http.Request) bool
 // SpanNameFormatter is used to set span name by http.request.
 type SpanNameFormatter func(r *http.Request) string
 
+// GinSpanNameFormatter is used to set the span name of the request handled
+// by c. The full path of the route of the request, including the prefixes of
+// its router groups, is returned by c.FullPath.
+type GinSpanNameFormatter func(c *gin.Context) string
+
+// ContextExtractor returns the context, containing the parent span context,
+// used to start the span of the request handled by c.
+type ContextExtractor func(c *gin.Context) context.Context
+
 // Option specifies instrumentation configuration options.
 type Option interface {
 	apply(*config)
@@ -77,3 +98,91 @@ func WithSpanNameFormatter(f func(r *http.Request) string) Option {
 		c.SpanNameFormatter = f
 	})
 }
+
+// WithGinSpanNameFormatter takes a function that will be called on every
+// request with its gin.Context and the returned string will become the Span
+// Name. Unlike WithSpanNameFormatter, the function has access to the full
+// path of the route matched by the request, with c.FullPath, and to the
+// values set by the previous middlewares. It takes precedence over
+// WithSpanNameFormatter.
+//
+// By default, the span name is the request method followed by the full path
+// of the route, e.g. "GET /api/v1/users/:id" for the "/users/:id" route of
+// the "/api/v1" router group.
+func WithGinSpanNameFormatter(f func(c *gin.Context) string) Option {
+	return optionFunc(func(c *config) {
+		c.GinSpanNameFormatter = f
+	})
+}
+
+// WithoutPanicRecording disables recording panics raised by handlers on the
+// request span. By default, a panic is recorded as an error on the span, the
+// span status is set to Error, and the panic is then re-raised so recovery
+// middleware registered before this middleware can handle it.
+func WithoutPanicRecording() Option {
+	return optionFunc(func(c *config) {
+		c.DisablePanicRecording = true
+	})
+}
+
+// WithContextExtractor specifies a function that supplies the parent context
+// of the request span from the gin.Context. This is useful when the trace
+// context has already been parsed, for example by a custom router, and stored
+// in the gin.Context.
+//
+// If none is specified, or it returns nil, the trace context is extracted
+// from the request headers using the configured propagators.
+func WithContextExtractor(extractor ContextExtractor) Option {
+	return optionFunc(func(c *config) {
+		c.ContextExtractor = extractor
+	})
+}
+
+// WithBaggageKeys specifies the keys of the baggage members that are copied
+// from the baggage of the request into the gin.Context, using the same keys.
+// This makes them accessible with c.Get, for example from templates. Keys
+// absent from the baggage are not set.
+func WithBaggageKeys(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		c.BaggageKeys = append(c.BaggageKeys, keys...)
+	})
+}
+
+// WithHandlerNameAttribute records the name of the handler of the request,
+// the last handler of the chain, on the request span. Its package path is
+// recorded with the code.namespace attribute and the rest of its name with
+// the code.function attribute, e.g. "github.com/example/app" and
+// "getUser" for a function, or "(*Server).getUser-fm" for a method value.
+//
+// The name is looked up with reflection for every request, this option is
+// disabled by default.
+func WithHandlerNameAttribute() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.HandlerNameAttribute = true
+	})
+}
+
+// WithCapturedRequestHeaders specifies the request headers recorded on the
+// request span, with the http.request.header.<name> attributes where name is
+// the lowercase header name, e.g. http.request.header.x-forwarded-for. The
+// values of a header sent multiple times are joined with commas. Headers
+// absent from the request are not recorded.
+//
+// Headers can contain sensitive information, only capture the needed ones.
+func WithCapturedRequestHeaders(headers ...string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CapturedRequestHeaders = append(cfg.CapturedRequestHeaders, headers...)
+	})
+}
+
+// WithCapturedResponseHeaders specifies the response headers recorded on the
+// request span, with the http.response.header.<name> attributes where name
+// is the lowercase header name, e.g. http.response.header.content-type. The
+// headers are read once the handlers returned, as they were written. The
+// values of a header sent multiple times are joined with commas. Headers
+// absent from the response are not recorded.
+func WithCapturedResponseHeaders(headers ...string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CapturedResponseHeaders = append(cfg.CapturedResponseHeaders, headers...)
+	})
+}
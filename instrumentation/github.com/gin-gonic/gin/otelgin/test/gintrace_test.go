@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -84,7 +85,7 @@ func TestTrace200(t *testing.T) {
 	spans := sr.Ended()
 	require.Len(t, spans, 1)
 	span := spans[0]
-	assert.Equal(t, "/user/:id", span.Name())
+	assert.Equal(t, "GET /user/:id", span.Name())
 	assert.Equal(t, oteltrace.SpanKindServer, span.SpanKind())
 	attr := span.Attributes()
 	assert.Contains(t, attr, attribute.String("net.host.name", "foobar"))
@@ -116,7 +117,7 @@ func TestError(t *testing.T) {
 	spans := sr.Ended()
 	require.Len(t, spans, 1)
 	span := spans[0]
-	assert.Equal(t, "/server_err", span.Name())
+	assert.Equal(t, "GET /server_err", span.Name())
 	attr := span.Attributes()
 	assert.Contains(t, attr, attribute.String("net.host.name", "foobar"))
 	assert.Contains(t, attr, attribute.Int("http.status_code", http.StatusInternalServerError))
@@ -125,6 +126,56 @@ func TestError(t *testing.T) {
 	assert.Equal(t, codes.Error, span.Status().Code)
 }
 
+func TestPanic(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	router := gin.New()
+	// The recovery middleware is registered first so it handles the panic
+	// re-raised by otelgin.
+	router.Use(gin.Recovery())
+	router.Use(otelgin.Middleware("foobar", otelgin.WithTracerProvider(provider)))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("oh no")
+	})
+	r := httptest.NewRequest("GET", "/panic", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "oh no", span.Status().Description)
+	assert.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusInternalServerError))
+	require.Len(t, span.Events(), 1)
+	event := span.Events()[0]
+	assert.Equal(t, "exception", event.Name)
+	assert.Contains(t, event.Attributes, attribute.String("exception.message", "oh no"))
+}
+
+func TestWithoutPanicRecording(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(otelgin.Middleware("foobar", otelgin.WithTracerProvider(provider), otelgin.WithoutPanicRecording()))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("oh no")
+	})
+	r := httptest.NewRequest("GET", "/panic", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.NotContains(t, spans[0].Attributes(), attribute.Int("http.status_code", http.StatusInternalServerError))
+}
+
 func TestSpanStatus(t *testing.T) {
 	testCases := []struct {
 		httpStatusCode int
@@ -159,7 +210,7 @@ func TestSpanName(t *testing.T) {
 		spanNameFormatter otelgin.SpanNameFormatter
 		wantSpanName      string
 	}{
-		{"/user/1", nil, "/user/:id"},
+		{"/user/1", nil, "GET /user/:id"},
 		{"/user/1", func(r *http.Request) string { return r.URL.Path }, "/user/1"},
 	}
 	for _, tc := range testCases {
@@ -179,6 +230,69 @@ func TestSpanName(t *testing.T) {
 	}
 }
 
+func TestGinSpanNameFormatter(t *testing.T) {
+	testCases := []struct {
+		name         string
+		opts         []otelgin.Option
+		requestPath  string
+		wantFullPath string
+		wantSpanName string
+	}{
+		{
+			name:         "default",
+			requestPath:  "/api/v1/users/1",
+			wantSpanName: "GET /api/v1/users/:id",
+		},
+		{
+			name: "formatter",
+			opts: []otelgin.Option{otelgin.WithGinSpanNameFormatter(func(c *gin.Context) string {
+				return "users " + strings.TrimPrefix(c.FullPath(), "/api/v1")
+			})},
+			requestPath:  "/api/v1/users/1",
+			wantFullPath: "/api/v1/users/:id",
+			wantSpanName: "users /users/:id",
+		},
+		{
+			name: "precedence",
+			opts: []otelgin.Option{
+				otelgin.WithGinSpanNameFormatter(func(c *gin.Context) string { return c.Request.Method + " " + c.FullPath() }),
+				otelgin.WithSpanNameFormatter(func(r *http.Request) string { return r.URL.Path }),
+			},
+			requestPath:  "/api/v1/users/1",
+			wantFullPath: "/api/v1/users/:id",
+			wantSpanName: "GET /api/v1/users/:id",
+		},
+		{
+			name:         "not found",
+			requestPath:  "/api/v2/users/1",
+			wantSpanName: "HTTP GET route not found",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			var gotFullPath string
+			opts := append([]otelgin.Option{otelgin.WithTracerProvider(provider)}, tc.opts...)
+			router := gin.New()
+			router.Use(otelgin.Middleware("foobar", opts...))
+			v1 := router.Group("/api").Group("/v1")
+			v1.GET("/users/:id", func(c *gin.Context) { gotFullPath = c.FullPath() })
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", tc.requestPath, nil))
+
+			require.Len(t, sr.Ended(), 1, "should emit a span")
+			span := sr.Ended()[0]
+			assert.Equal(t, tc.wantSpanName, span.Name())
+			if tc.wantFullPath != "" {
+				assert.Equal(t, tc.wantFullPath, gotFullPath)
+				// The route is recorded regardless of the span name.
+				assert.Contains(t, span.Attributes(), attribute.String("http.route", tc.wantFullPath))
+			}
+		})
+	}
+}
+
 func TestHTML(t *testing.T) {
 	sr := tracetest.NewSpanRecorder()
 	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
@@ -251,3 +365,69 @@ func TestWithFilter(t *testing.T) {
 		assert.Len(t, sr.Ended(), 1)
 	})
 }
+
+func getUser(c *gin.Context) {
+	c.Status(http.StatusOK)
+}
+
+func TestHandlerNameAttribute(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		t.Run(strconv.FormatBool(enabled), func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			opts := []otelgin.Option{otelgin.WithTracerProvider(provider)}
+			if enabled {
+				opts = append(opts, otelgin.WithHandlerNameAttribute())
+			}
+
+			router := gin.New()
+			router.Use(otelgin.Middleware("foobar", opts...))
+			router.GET("/user/:id", getUser)
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/user/123", nil))
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			attrs := spans[0].Attributes()
+			namespace := attribute.String("code.namespace", "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin/test")
+			function := attribute.String("code.function", "getUser")
+			if enabled {
+				assert.Contains(t, attrs, namespace)
+				assert.Contains(t, attrs, function)
+			} else {
+				assert.NotContains(t, attrs, namespace)
+				assert.NotContains(t, attrs, function)
+			}
+		})
+	}
+}
+
+func TestCapturedHeaders(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	router := gin.New()
+	router.Use(otelgin.Middleware("foobar",
+		otelgin.WithTracerProvider(provider),
+		otelgin.WithCapturedRequestHeaders("X-Request-ID", "X-Absent"),
+		otelgin.WithCapturedResponseHeaders("X-Response-Id", "X-Absent"),
+	))
+	router.GET("/user/:id", func(c *gin.Context) {
+		c.Writer.Header().Add("X-Response-ID", "a")
+		c.Writer.Header().Add("X-Response-ID", "b")
+		c.Status(http.StatusNoContent)
+	})
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	r.Header.Set("X-Request-ID", "123")
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	attrs := spans[0].Attributes()
+	assert.Contains(t, attrs, attribute.String("http.request.header.x-request-id", "123"))
+	assert.Contains(t, attrs, attribute.String("http.response.header.x-response-id", "a,b"))
+	for _, kv := range attrs {
+		assert.NotContains(t, string(kv.Key), "x-absent")
+	}
+}
@@ -6,6 +6,7 @@ package otelecho // import "go.opentelemetry.io/contrib/instrumentation/github.c
 import (
 	"github.com/labstack/echo/v4/middleware"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -13,6 +14,7 @@ import (
 // config is used to configure the mux middleware.
 type config struct {
 	TracerProvider oteltrace.TracerProvider
+	MeterProvider  metric.MeterProvider
 	Propagators    propagation.TextMapPropagator
 	Skipper        middleware.Skipper
 }
@@ -49,6 +51,24 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for recording the HTTP
+// server metrics of the requests:
+//
+//   - http.server.request.duration, with the http.request.method,
+//     http.response.status_code and http.route attributes.
+//   - http.server.active_requests, with the http.request.method attribute.
+//
+// The http.route attribute is the route registered for the request, as
+// returned by echo.Context.Path, so that its cardinality is bounded by the
+// number of routes. No metrics are recorded if this option is not used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithSkipper specifies a skipper for allowing requests to skip generating spans.
 func WithSkipper(skipper middleware.Skipper) Option {
 	return optionFunc(func(cfg *config) {
@@ -5,6 +5,7 @@ package otelecho // import "go.opentelemetry.io/contrib/instrumentation/github.c
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -13,6 +14,7 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho/internal/semconvutil"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -45,12 +47,18 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 		cfg.Skipper = middleware.DefaultSkipper
 	}
 
+	var metrics *serverMetrics
+	if cfg.MeterProvider != nil {
+		metrics = newServerMetrics(cfg.MeterProvider)
+	}
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			if cfg.Skipper(c) {
 				return next(c)
 			}
 
+			start := time.Now()
 			c.Set(tracerKey, tracer)
 			request := c.Request()
 			savedCtx := request.Context()
@@ -75,6 +83,13 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 			ctx, span := tracer.Start(ctx, spanName, opts...)
 			defer span.End()
 
+			var method attribute.KeyValue
+			if metrics != nil {
+				method = requestMethod(request.Method)
+				metrics.active.Add(ctx, 1, metric.WithAttributes(method))
+				defer metrics.active.Add(ctx, -1, metric.WithAttributes(method))
+			}
+
 			// pass the span through the request context
 			c.SetRequest(request.WithContext(ctx))
 
@@ -92,6 +107,10 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 				span.SetAttributes(semconv.HTTPStatusCode(status))
 			}
 
+			if metrics != nil {
+				metrics.recordDuration(ctx, time.Since(start), method, status, c.Path())
+			}
+
 			return err
 		}
 	}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelecho // import "go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// durationBoundaries are the explicit bucket boundaries, in seconds, of the
+// http.server.request.duration histogram recommended by the semantic
+// conventions.
+var durationBoundaries = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10,
+}
+
+// serverMetrics are the HTTP server metrics recorded for the requests.
+type serverMetrics struct {
+	duration metric.Float64Histogram
+	active   metric.Int64UpDownCounter
+}
+
+func newServerMetrics(mp metric.MeterProvider) *serverMetrics {
+	meter := mp.Meter(
+		ScopeName,
+		metric.WithInstrumentationVersion(Version()),
+	)
+
+	var (
+		m   serverMetrics
+		err error
+	)
+	m.duration, err = meter.Float64Histogram(
+		semconv.HTTPServerRequestDurationName,
+		metric.WithUnit(semconv.HTTPServerRequestDurationUnit),
+		metric.WithDescription(semconv.HTTPServerRequestDurationDescription),
+		metric.WithExplicitBucketBoundaries(durationBoundaries...),
+	)
+	handleErr(err)
+
+	m.active, err = meter.Int64UpDownCounter(
+		semconv.HTTPServerActiveRequestsName,
+		metric.WithUnit(semconv.HTTPServerActiveRequestsUnit),
+		metric.WithDescription(semconv.HTTPServerActiveRequestsDescription),
+	)
+	handleErr(err)
+
+	return &m
+}
+
+// recordDuration records the duration of a request with the method
+// attribute, that responded with status, and was routed to route.
+func (m *serverMetrics) recordDuration(ctx context.Context, d time.Duration, method attribute.KeyValue, status int, route string) {
+	attrs := make([]attribute.KeyValue, 0, 3)
+	attrs = append(attrs, method)
+	if status > 0 {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(status))
+	}
+	if route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+	m.duration.Record(ctx, d.Seconds(), metric.WithAttributes(attrs...))
+}
+
+func handleErr(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// requestMethod returns the http.request.method attribute of method. The
+// methods not defined by the HTTP specifications are recorded as "_OTHER" to
+// bound the cardinality of the metrics.
+func requestMethod(method string) attribute.KeyValue {
+	switch m := strings.ToUpper(method); m {
+	case http.MethodConnect, http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPatch, http.MethodPost, http.MethodPut, http.MethodTrace:
+		return semconv.HTTPRequestMethodKey.String(m)
+	}
+	return semconv.HTTPRequestMethodOther
+}
@@ -6,6 +6,7 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -19,6 +20,10 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
@@ -202,3 +207,80 @@ func TestErrorNotSwallowedByMiddleware(t *testing.T) {
 	err := h(c)
 	assert.Equal(t, assert.AnError, err)
 }
+
+func TestMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	router := echo.New()
+	router.Use(otelecho.Middleware("foobar", otelecho.WithMeterProvider(meterProvider)))
+	router.GET("/user/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, id := range []string{"123", "456"} {
+		r := httptest.NewRequest("GET", "/user/"+id, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, otelecho.ScopeName, sm.Scope.Name)
+	require.Len(t, sm.Metrics, 2)
+
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "http.server.request.duration",
+		Description: "Duration of HTTP server requests.",
+		Unit:        "s",
+		Data: metricdata.Histogram[float64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.HistogramDataPoint[float64]{
+				{
+					// Both requests are recorded with the route template.
+					Attributes: attribute.NewSet(
+						attribute.String("http.request.method", "GET"),
+						attribute.Int("http.response.status_code", http.StatusOK),
+						attribute.String("http.route", "/user/:id"),
+					),
+					Count: 2,
+				},
+			},
+		},
+	}, sm.Metrics[0], metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue(), metricdatatest.IgnoreExemplars())
+
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "http.server.active_requests",
+		Description: "Number of active HTTP server requests.",
+		Unit:        "{request}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(attribute.String("http.request.method", "GET")),
+					Value:      0,
+				},
+			},
+		},
+	}, sm.Metrics[1], metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+}
+
+func TestMetricsWithoutMeterProvider(t *testing.T) {
+	reader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(noop.NewMeterProvider()) })
+
+	router := echo.New()
+	router.Use(otelecho.Middleware("foobar"))
+	router.GET("/ping", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ping", nil))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Empty(t, rm.ScopeMetrics, "metrics should only be recorded with WithMeterProvider")
+}
@@ -4,6 +4,8 @@
 package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 
 import (
+	"go.mongodb.org/mongo-driver/bson"
+
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -18,13 +20,18 @@ type config struct {
 	Tracer trace.Tracer
 
 	CommandAttributeDisabled bool
+
+	CommandAttributeSanitizer func(bson.Raw) string
+
+	TransactionSpans bool
 }
 
 // newConfig returns a config with all Options set.
 func newConfig(opts ...Option) config {
 	cfg := config{
-		TracerProvider:           otel.GetTracerProvider(),
-		CommandAttributeDisabled: true,
+		TracerProvider:            otel.GetTracerProvider(),
+		CommandAttributeDisabled:  true,
+		CommandAttributeSanitizer: sanitizeCommand,
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
@@ -66,3 +73,41 @@ func WithCommandAttributeDisabled(disabled bool) Option {
 		cfg.CommandAttributeDisabled = disabled
 	})
 }
+
+// WithCommandAttributeSanitizer specifies the function used to convert a
+// MongoDB command into the value of the db.statement attribute. This can be
+// used to redact sensitive values from the command while keeping its
+// structure.
+//
+// The sanitizer is only used if the command attribute is enabled with
+// WithCommandAttributeDisabled(false). If this option is not provided, the
+// command is rendered as Extended JSON with all values of security sensitive
+// commands (e.g. saslStart, authenticate) redacted.
+func WithCommandAttributeSanitizer(sanitizer func(cmd bson.Raw) string) Option {
+	return optionFunc(func(cfg *config) {
+		if sanitizer != nil {
+			cfg.CommandAttributeSanitizer = sanitizer
+		}
+	})
+}
+
+// WithTransactionSpans specifies that a span is created for each transaction,
+// parenting the spans of the commands run in the transaction. The span is
+// started with the first command of the transaction and ended when the
+// commitTransaction or abortTransaction command finishes. It has an Error
+// status if this command fails. The commands run after it, such as its
+// retries, are not parented to the span.
+//
+// The span of a transaction that is not ended by one of these commands, e.g.
+// because its connection was lost or its session discarded, is ended when
+// the next transaction of its session starts, or when a transaction starts
+// more than two minutes after it.
+//
+// The commands are matched to their transaction with the ID of their session
+// and their transaction number. This is disabled by default and the spans of
+// the commands are the children of the span of the context of the operation.
+func WithTransactionSpans() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.TransactionSpans = true
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -24,10 +25,39 @@ type spanKey struct {
 	RequestID    int64
 }
 
+// txnKey identifies a transaction: the ID of the session it is run in, and
+// its number in the session.
+type txnKey struct {
+	SessionID string
+	TxnNumber int64
+}
+
+// transactionSpanTimeout is the duration after which the span of a
+// transaction that was not ended by a commitTransaction or abortTransaction
+// command, e.g. because its session was discarded, is ended. It is greater
+// than the 60 seconds default lifetime of the transactions on the servers.
+const transactionSpanTimeout = 2 * time.Minute
+
+// transaction is a transaction run in a session.
+type transaction struct {
+	number int64
+	span   trace.Span
+	start  time.Time
+}
+
 type monitor struct {
 	sync.Mutex
 	spans map[spanKey]trace.Span
 	cfg   config
+
+	// txns are the transactions in progress of the sessions, by session ID,
+	// and txnEnds the transactions ended by the commitTransaction and
+	// abortTransaction commands in progress. They are only used if the
+	// transaction spans are enabled with WithTransactionSpans.
+	txns    map[string]*transaction
+	txnEnds map[spanKey]txnKey
+	// now returns the current time, time.Now if nil.
+	now func() time.Time
 }
 
 func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
@@ -44,7 +74,7 @@ func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 		semconv.NetTransportTCP,
 	}
 	if !m.cfg.CommandAttributeDisabled {
-		attrs = append(attrs, semconv.DBStatement(sanitizeCommand(evt.Command)))
+		attrs = append(attrs, semconv.DBStatement(m.cfg.CommandAttributeSanitizer(evt.Command)))
 	}
 	if collection, err := extractCollection(evt); err == nil && collection != "" {
 		spanName = collection + "."
@@ -55,16 +85,111 @@ func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(attrs...),
 	}
-	_, span := m.cfg.Tracer.Start(ctx, spanName, opts...)
 	key := spanKey{
 		ConnectionID: evt.ConnectionID,
 		RequestID:    evt.RequestID,
 	}
+	if m.cfg.TransactionSpans {
+		if txn, ok := transactionKey(evt.Command); ok {
+			ctx = m.transactionContext(ctx, evt, key, txn)
+		}
+	}
+	_, span := m.cfg.Tracer.Start(ctx, spanName, opts...)
 	m.Lock()
 	m.spans[key] = span
 	m.Unlock()
 }
 
+// transactionContext returns a copy of ctx holding the span of the
+// transaction txn the command of evt is part of. The span is started by the
+// first command of the transaction, the one with the startTransaction field,
+// and ctx is returned unchanged if it was not seen. The span is ended, and
+// the transaction removed, when the commitTransaction or abortTransaction
+// command, identified by key, finishes: the commands of the transaction run
+// after, such as the retries of these commands, are not parented.
+//
+// The spans of the previous transaction of the session and of the expired
+// transactions are ended when a transaction starts.
+func (m *monitor) transactionContext(ctx context.Context, evt *event.CommandStartedEvent, key spanKey, txn txnKey) context.Context {
+	var leftovers []trace.Span
+	m.Lock()
+	defer func() {
+		m.Unlock()
+		for _, span := range leftovers {
+			span.End()
+		}
+	}()
+	if m.txns == nil {
+		m.txns = make(map[string]*transaction)
+		m.txnEnds = make(map[spanKey]txnKey)
+	}
+
+	t, ok := m.txns[txn.SessionID]
+	if ok && t.number != txn.TxnNumber {
+		if txn.TxnNumber < t.number {
+			return ctx
+		}
+		// The session runs a transaction at a time, the previous one is over.
+		leftovers = append(leftovers, t.span)
+		delete(m.txns, txn.SessionID)
+		ok = false
+	}
+	if !ok {
+		if start, _ := evt.Command.Lookup("startTransaction").BooleanOK(); !start {
+			return ctx
+		}
+		now := time.Now
+		if m.now != nil {
+			now = m.now
+		}
+		start := now()
+		leftovers = append(leftovers, m.expireTransactions(start)...)
+
+		_, span := m.cfg.Tracer.Start(ctx, "transaction",
+			trace.WithSpanKind(trace.SpanKindInternal),
+			trace.WithAttributes(
+				semconv.DBSystemMongoDB,
+				semconv.DBName(evt.DatabaseName),
+			),
+		)
+		t = &transaction{number: txn.TxnNumber, span: span, start: start}
+		m.txns[txn.SessionID] = t
+	}
+	if evt.CommandName == "commitTransaction" || evt.CommandName == "abortTransaction" {
+		m.txnEnds[key] = txn
+	}
+	return trace.ContextWithSpan(ctx, t.span)
+}
+
+// expireTransactions removes the transactions started more than
+// transactionSpanTimeout before now, and returns their spans. The lock must
+// be held.
+func (m *monitor) expireTransactions(now time.Time) []trace.Span {
+	var spans []trace.Span
+	for id, t := range m.txns {
+		if now.Sub(t.start) <= transactionSpanTimeout {
+			continue
+		}
+		spans = append(spans, t.span)
+		delete(m.txns, id)
+	}
+	return spans
+}
+
+// transactionKey returns the key of the transaction cmd is part of, false if
+// cmd is not run in a transaction.
+func transactionKey(cmd bson.Raw) (txnKey, bool) {
+	id, err := cmd.LookupErr("lsid", "id")
+	if err != nil {
+		return txnKey{}, false
+	}
+	n, ok := cmd.Lookup("txnNumber").Int64OK()
+	if !ok {
+		return txnKey{}, false
+	}
+	return txnKey{SessionID: string(id.Value), TxnNumber: n}, true
+}
+
 func (m *monitor) Succeeded(ctx context.Context, evt *event.CommandSucceededEvent) {
 	m.Finished(&evt.CommandFinishedEvent, nil)
 }
@@ -83,6 +208,14 @@ func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
 	if ok {
 		delete(m.spans, key)
 	}
+	var txnSpan trace.Span
+	if txn, ok := m.txnEnds[key]; ok {
+		delete(m.txnEnds, key)
+		if t := m.txns[txn.SessionID]; t != nil && t.number == txn.TxnNumber {
+			delete(m.txns, txn.SessionID)
+			txnSpan = t.span
+		}
+	}
 	m.Unlock()
 	if !ok {
 		return
@@ -93,15 +226,96 @@ func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
 	}
 
 	span.End()
+
+	if txnSpan != nil {
+		if err != nil {
+			txnSpan.SetStatus(codes.Error, err.Error())
+		}
+		txnSpan.End()
+	}
+}
+
+// redactedValue replaces the values of security sensitive commands.
+const redactedValue = "?"
+
+// sensitiveCommands are the MongoDB commands whose values may contain
+// credentials.
+var sensitiveCommands = map[string]struct{}{
+	"authenticate":    {},
+	"saslStart":       {},
+	"saslContinue":    {},
+	"getnonce":        {},
+	"createUser":      {},
+	"updateUser":      {},
+	"copydbgetnonce":  {},
+	"copydbsaslstart": {},
+	"copydb":          {},
 }
 
+// sanitizeCommand is the default command attribute sanitizer. It renders the
+// command as Extended JSON, redacting all values of security sensitive
+// commands.
+//
 // TODO sanitize values where possible, then reenable `db.statement` span attributes default.
 // TODO limit maximum size.
 func sanitizeCommand(command bson.Raw) string {
-	b, _ := bson.MarshalExtJSON(command, false, false)
+	var v interface{} = command
+	if isSensitiveCommand(command) {
+		v = redactDocument(command)
+	}
+	b, _ := bson.MarshalExtJSON(v, false, false)
 	return string(b)
 }
 
+// isSensitiveCommand returns if the command name, the first key of the
+// command document, is a security sensitive command.
+func isSensitiveCommand(command bson.Raw) bool {
+	elt, err := command.IndexErr(0)
+	if err != nil {
+		return false
+	}
+	key, err := elt.KeyErr()
+	if err != nil {
+		return false
+	}
+	_, ok := sensitiveCommands[key]
+	return ok
+}
+
+// redactDocument returns a copy of doc with the same keys where all values
+// are replaced with redactedValue. Embedded documents and arrays are redacted
+// recursively so the structure of doc is preserved.
+func redactDocument(doc bson.Raw) bson.D {
+	elts, err := doc.Elements()
+	if err != nil {
+		return bson.D{}
+	}
+	out := make(bson.D, 0, len(elts))
+	for _, elt := range elts {
+		out = append(out, bson.E{Key: elt.Key(), Value: redactValue(elt.Value())})
+	}
+	return out
+}
+
+func redactValue(v bson.RawValue) interface{} {
+	switch v.Type {
+	case bson.TypeEmbeddedDocument:
+		return redactDocument(v.Document())
+	case bson.TypeArray:
+		vals, err := v.Array().Values()
+		if err != nil {
+			return bson.A{}
+		}
+		out := make(bson.A, 0, len(vals))
+		for _, val := range vals {
+			out = append(out, redactValue(val))
+		}
+		return out
+	default:
+		return redactedValue
+	}
+}
+
 // extractCollection extracts the collection for the given mongodb command event.
 // For CRUD operations, this is the first key/value string pair in the bson
 // document where key == "<operation>" (e.g. key == "insert").
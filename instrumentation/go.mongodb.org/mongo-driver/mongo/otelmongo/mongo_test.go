@@ -0,0 +1,366 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelmongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func mustMarshal(t *testing.T, doc bson.D) bson.Raw {
+	t.Helper()
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestSanitizeCommand(t *testing.T) {
+	testCases := []struct {
+		name    string
+		command bson.D
+		want    string
+	}{
+		{
+			name: "insert",
+			command: bson.D{
+				{Key: "insert", Value: "test-collection"},
+				{Key: "documents", Value: bson.A{bson.D{{Key: "test-item", Value: "test-value"}}}},
+			},
+			want: `{"insert":"test-collection","documents":[{"test-item":"test-value"}]}`,
+		},
+		{
+			name: "saslStart",
+			command: bson.D{
+				{Key: "saslStart", Value: 1},
+				{Key: "mechanism", Value: "SCRAM-SHA-256"},
+				{Key: "payload", Value: "secret"},
+				{Key: "options", Value: bson.D{{Key: "skipEmptyExchange", Value: true}}},
+			},
+			want: `{"saslStart":"?","mechanism":"?","payload":"?","options":{"skipEmptyExchange":"?"}}`,
+		},
+		{
+			name: "authenticate",
+			command: bson.D{
+				{Key: "authenticate", Value: 1},
+				{Key: "user", Value: "admin"},
+				{Key: "mechanism", Value: "MONGODB-X509"},
+			},
+			want: `{"authenticate":"?","user":"?","mechanism":"?"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sanitizeCommand(mustMarshal(t, tc.command)); got != tc.want {
+				t.Errorf("sanitizeCommand() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+type startRecorder struct {
+	embedded.Tracer
+
+	attrs []attribute.KeyValue
+}
+
+func (r *startRecorder) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	r.attrs = cfg.Attributes()
+	return noop.NewTracerProvider().Tracer("").Start(ctx, name, opts...)
+}
+
+func statement(attrs []attribute.KeyValue) (string, bool) {
+	for _, attr := range attrs {
+		if attr.Key == "db.statement" {
+			return attr.Value.AsString(), true
+		}
+	}
+	return "", false
+}
+
+func TestCommandAttributeSanitizer(t *testing.T) {
+	evt := &event.CommandStartedEvent{
+		Command: mustMarshal(t, bson.D{
+			{Key: "authenticate", Value: 1},
+			{Key: "user", Value: "admin"},
+		}),
+		DatabaseName: "admin",
+		CommandName:  "authenticate",
+		ConnectionID: "localhost:27017[-1]",
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		r := &startRecorder{}
+		cfg := newConfig()
+		cfg.Tracer = r
+		m := &monitor{spans: make(map[spanKey]trace.Span), cfg: cfg}
+		m.Started(context.Background(), evt)
+
+		if _, ok := statement(r.attrs); ok {
+			t.Error("db.statement should be opt-in")
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		r := &startRecorder{}
+		cfg := newConfig(WithCommandAttributeDisabled(false))
+		cfg.Tracer = r
+		m := &monitor{spans: make(map[spanKey]trace.Span), cfg: cfg}
+		m.Started(context.Background(), evt)
+
+		got, ok := statement(r.attrs)
+		if !ok {
+			t.Fatal("db.statement not set")
+		}
+		if want := `{"authenticate":"?","user":"?"}`; got != want {
+			t.Errorf("db.statement = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		r := &startRecorder{}
+		cfg := newConfig(
+			WithCommandAttributeDisabled(false),
+			WithCommandAttributeSanitizer(func(cmd bson.Raw) string {
+				return "redacted"
+			}),
+		)
+		cfg.Tracer = r
+		m := &monitor{spans: make(map[spanKey]trace.Span), cfg: cfg}
+		m.Started(context.Background(), evt)
+
+		got, ok := statement(r.attrs)
+		if !ok {
+			t.Fatal("db.statement not set")
+		}
+		if want := "redacted"; got != want {
+			t.Errorf("db.statement = %s, want %s", got, want)
+		}
+	})
+}
+
+type recordedSpan struct {
+	noop.Span
+
+	name   string
+	kind   trace.SpanKind
+	parent *recordedSpan
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+func (s *recordedSpan) End(...trace.SpanEndOption)          { s.ended = true }
+
+type spanRecorder struct {
+	embedded.Tracer
+
+	spans []*recordedSpan
+}
+
+func (r *spanRecorder) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	s := &recordedSpan{name: name, kind: cfg.SpanKind()}
+	s.parent, _ = trace.SpanFromContext(ctx).(*recordedSpan)
+	r.spans = append(r.spans, s)
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+func TestTransactionSpans(t *testing.T) {
+	lsid := bson.D{{Key: "id", Value: primitive.Binary{Subtype: 4, Data: []byte("0123456789abcdef")}}}
+	started := func(requestID int64, name string, fields ...bson.E) *event.CommandStartedEvent {
+		cmd := append(bson.D{{Key: name, Value: "test-collection"}}, fields...)
+		return &event.CommandStartedEvent{
+			Command:      mustMarshal(t, cmd),
+			DatabaseName: "test-database",
+			CommandName:  name,
+			RequestID:    requestID,
+			ConnectionID: "localhost:27017[-1]",
+		}
+	}
+	finished := func(requestID int64) *event.CommandFinishedEvent {
+		return &event.CommandFinishedEvent{RequestID: requestID, ConnectionID: "localhost:27017[-1]"}
+	}
+	inTxn := []bson.E{{Key: "lsid", Value: lsid}, {Key: "txnNumber", Value: int64(1)}}
+	startTxn := append(inTxn, bson.E{Key: "startTransaction", Value: true}, bson.E{Key: "autocommit", Value: false})
+	run := func(m *monitor, endCommand string, endErr error) {
+		ctx := context.Background()
+		m.Started(ctx, started(1, "insert", startTxn...))
+		m.Finished(finished(1), nil)
+		m.Started(ctx, started(2, "update", append(inTxn, bson.E{Key: "autocommit", Value: false})...))
+		m.Finished(finished(2), nil)
+		m.Started(ctx, started(3, endCommand, inTxn...))
+		m.Finished(finished(3), endErr)
+		// A command outside of the transaction.
+		m.Started(ctx, started(4, "find"))
+		m.Finished(finished(4), nil)
+	}
+
+	newMonitor := func(opts ...Option) (*monitor, *spanRecorder) {
+		r := &spanRecorder{}
+		cfg := newConfig(opts...)
+		cfg.Tracer = r
+		return &monitor{spans: make(map[spanKey]trace.Span), cfg: cfg}, r
+	}
+
+	// recorded returns the n spans recorded by r.
+	recorded := func(t *testing.T, r *spanRecorder, n int) []*recordedSpan {
+		t.Helper()
+		if len(r.spans) != n {
+			t.Fatalf("got %d spans, want %d", len(r.spans), n)
+		}
+		return r.spans
+	}
+
+	t.Run("Commit", func(t *testing.T) {
+		m, r := newMonitor(WithTransactionSpans())
+		run(m, "commitTransaction", nil)
+
+		spans := recorded(t, r, 5)
+		txn := spans[0]
+		if txn.name != "transaction" || txn.kind != trace.SpanKindInternal || txn.parent != nil {
+			t.Errorf("transaction span = %s, %s, parent %v, want an internal root span named transaction", txn.name, txn.kind, txn.parent)
+		}
+		if !txn.ended || txn.status != codes.Unset {
+			t.Errorf("transaction span ended %t with status %s, want ended with status Unset", txn.ended, txn.status)
+		}
+		for _, s := range spans[1:4] {
+			if s.parent != txn || s.kind != trace.SpanKindClient || !s.ended {
+				t.Errorf("%s: got kind %s, ended %t and a different parent, want an ended client child of the transaction", s.name, s.kind, s.ended)
+			}
+		}
+		if s := spans[4]; s.name != "test-collection.find" || s.parent != nil {
+			t.Errorf("%s: the command outside of the transaction should not be parented", s.name)
+		}
+		if len(m.txnEnds) != 0 || len(m.txns) != 0 {
+			t.Errorf("the monitor should hold no transaction, got %d and %d", len(m.txns), len(m.txnEnds))
+		}
+	})
+
+	t.Run("RetriedCommit", func(t *testing.T) {
+		m, r := newMonitor(WithTransactionSpans())
+		run(m, "commitTransaction", nil)
+		m.Started(context.Background(), started(5, "commitTransaction", inTxn...))
+		m.Finished(finished(5), errors.New("retried"))
+
+		spans := recorded(t, r, 6)
+		if spans[5].parent != nil {
+			t.Error("the retry should not be parented to the ended transaction")
+		}
+		if !spans[5].ended {
+			t.Error("the retry span should be ended")
+		}
+		if spans[0].status != codes.Unset {
+			t.Error("the ended transaction should not be changed")
+		}
+		if len(m.txns) != 0 {
+			t.Errorf("the monitor should hold no transaction, got %d", len(m.txns))
+		}
+	})
+
+	t.Run("NextTransaction", func(t *testing.T) {
+		m, r := newMonitor(WithTransactionSpans())
+		ctx := context.Background()
+		m.Started(ctx, started(1, "insert", startTxn...))
+		m.Finished(finished(1), nil)
+		// The transaction is not committed, the session runs the next one.
+		m.Started(ctx, started(2, "insert",
+			bson.E{Key: "lsid", Value: lsid},
+			bson.E{Key: "txnNumber", Value: int64(2)},
+			bson.E{Key: "startTransaction", Value: true},
+		))
+		m.Finished(finished(2), nil)
+
+		spans := recorded(t, r, 4)
+		if !spans[0].ended {
+			t.Error("the previous transaction should be ended")
+		}
+		if spans[2].name != "transaction" || spans[2].ended {
+			t.Errorf("%s: want the next transaction span, not ended", spans[2].name)
+		}
+		if spans[3].parent != spans[2] {
+			t.Error("the command should be parented to the next transaction")
+		}
+		if len(m.txns) != 1 {
+			t.Errorf("got %d transactions, want 1", len(m.txns))
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		m, r := newMonitor(WithTransactionSpans())
+		now := time.Unix(0, 0)
+		m.now = func() time.Time { return now }
+		ctx := context.Background()
+		m.Started(ctx, started(1, "insert", startTxn...))
+		m.Finished(finished(1), nil)
+
+		// A transaction of another session starts after the timeout.
+		now = now.Add(transactionSpanTimeout + time.Second)
+		otherLSID := bson.D{{Key: "id", Value: primitive.Binary{Subtype: 4, Data: []byte("fedcba9876543210")}}}
+		m.Started(ctx, started(2, "insert",
+			bson.E{Key: "lsid", Value: otherLSID},
+			bson.E{Key: "txnNumber", Value: int64(1)},
+			bson.E{Key: "startTransaction", Value: true},
+		))
+		m.Finished(finished(2), nil)
+
+		spans := recorded(t, r, 4)
+		if !spans[0].ended {
+			t.Error("the expired transaction should be ended")
+		}
+		if spans[2].ended {
+			t.Error("the transaction of the other session should not be ended")
+		}
+		if len(m.txns) != 1 {
+			t.Errorf("got %d transactions, want 1", len(m.txns))
+		}
+
+		// The commands of the expired transaction are no longer parented.
+		m.Started(ctx, started(3, "commitTransaction", inTxn...))
+		m.Finished(finished(3), nil)
+		if s := recorded(t, r, 5)[4]; s.parent != nil {
+			t.Error("the command of the expired transaction should not be parented")
+		}
+	})
+
+	t.Run("AbortFailed", func(t *testing.T) {
+		m, r := newMonitor(WithTransactionSpans())
+		run(m, "abortTransaction", errors.New("aborted"))
+
+		spans := recorded(t, r, 5)
+		txn := spans[0]
+		if !txn.ended || txn.status != codes.Error {
+			t.Errorf("transaction span ended %t with status %s, want ended with status Error", txn.ended, txn.status)
+		}
+		if spans[3].parent != txn {
+			t.Error("the abortTransaction command should be parented to the transaction")
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		m, r := newMonitor()
+		run(m, "commitTransaction", nil)
+
+		for _, s := range recorded(t, r, 4) {
+			if s.parent != nil {
+				t.Errorf("%s: the span should not be parented", s.name)
+			}
+		}
+	})
+}
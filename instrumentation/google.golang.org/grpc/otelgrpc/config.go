@@ -4,6 +4,9 @@
 package otelgrpc // import "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 
 import (
+	"context"
+	"strings"
+
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -18,6 +21,28 @@ const (
 	ScopeName = "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	// GRPCStatusCodeKey is convention for numeric status code of a gRPC request.
 	GRPCStatusCodeKey = attribute.Key("rpc.grpc.status_code")
+	// GRPCDeadlineKey is convention for the time remaining, in milliseconds,
+	// until the deadline of a gRPC request when its span is started.
+	GRPCDeadlineKey = attribute.Key("rpc.grpc.deadline_ms")
+	// GRPCCompressionKey is convention for the compression algorithm
+	// negotiated for a gRPC request.
+	GRPCCompressionKey = attribute.Key("rpc.grpc.compression")
+	// GRPCMessageProtoNameKey is convention for the full name of the proto
+	// message of a message event.
+	GRPCMessageProtoNameKey = attribute.Key("rpc.grpc.message.proto_name")
+	// GRPCAuthorityKey is convention for the :authority pseudo-header of a
+	// gRPC request received by a server.
+	GRPCAuthorityKey = attribute.Key("rpc.grpc.authority")
+	// GRPCTypeKey is convention for the type of a gRPC method: "unary",
+	// "client_stream", "server_stream" or "bidi".
+	GRPCTypeKey = attribute.Key("rpc.grpc.type")
+	// GRPCAttemptKey is convention for the number, starting at 1, of the
+	// attempt of a gRPC request sent by a client, see WithAttemptSpans.
+	GRPCAttemptKey = attribute.Key("rpc.grpc.attempt")
+	// GRPCRequestMetadataKeyPrefix is the prefix of the attribute keys of the
+	// request metadata values, followed by the metadata key, e.g.
+	// "rpc.grpc.request.metadata.x-tenant-id", see WithMetadataKeys.
+	GRPCRequestMetadataKeyPrefix = "rpc.grpc.request.metadata."
 )
 
 // Filter is a predicate used to determine whether a given request in
@@ -36,6 +61,17 @@ type config struct {
 	ReceivedEvent bool
 	SentEvent     bool
 
+	CompressionAttribute       bool
+	MessageTypeAttributes      bool
+	AuthorityAndTypeAttributes bool
+	WithoutInfraMethods        bool
+	AttemptSpans               bool
+
+	MetadataKeys       []string
+	MetadataValueLimit int
+
+	ClientMetricAttributesFn func(ctx context.Context, fullMethod string) []attribute.KeyValue
+
 	tracer trace.Tracer
 	meter  metric.Meter
 
@@ -54,9 +90,10 @@ type Option interface {
 // newConfig returns a config configured with all the passed Options.
 func newConfig(opts []Option, role string) *config {
 	c := &config{
-		Propagators:    otel.GetTextMapPropagator(),
-		TracerProvider: otel.GetTracerProvider(),
-		MeterProvider:  otel.GetMeterProvider(),
+		Propagators:        otel.GetTextMapPropagator(),
+		TracerProvider:     otel.GetTracerProvider(),
+		MeterProvider:      otel.GetMeterProvider(),
+		MetadataValueLimit: defaultMetadataValueLimit,
 	}
 	for _, o := range opts {
 		o.apply(c)
@@ -166,6 +203,65 @@ func (o interceptorFilterOption) apply(c *config) {
 	}
 }
 
+// infraServices are the full names of the gRPC services, and the prefixes of
+// the full names followed by a dot, excluded with WithoutInfraMethods.
+var infraServices = []string{
+	"grpc.health.v1.Health",
+	"grpc.reflection",
+	"grpc.channelz.v1.Channelz",
+}
+
+// isInfraMethod returns true if fullMethod (e.g. "/grpc.health.v1.Health/Check")
+// is a method of one of the infraServices.
+func isInfraMethod(fullMethod string) bool {
+	service := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndexByte(service, '/'); i >= 0 {
+		service = service[:i]
+	}
+	for _, s := range infraServices {
+		if service == s || strings.HasPrefix(service, s+".") {
+			return true
+		}
+	}
+	return false
+}
+
+type withoutInfraMethodsOption struct{}
+
+func (withoutInfraMethodsOption) apply(c *config) {
+	c.WithoutInfraMethods = true
+}
+
+// WithoutInfraMethods returns an Option to exclude the methods of the
+// standard infrastructure services from the instrumentation: the health
+// service (grpc.health.v1.Health), the reflection services (grpc.reflection.*)
+// and the channelz service (grpc.channelz.v1.Channelz). No span is created
+// and no metric is recorded for these methods.
+//
+// This option applies to the stats handlers and the interceptors. For the
+// interceptors, it is combined with the filter of WithInterceptorFilter.
+func WithoutInfraMethods() Option {
+	return withoutInfraMethodsOption{}
+}
+
+// traced returns true if the request of the interceptor i is instrumented.
+func (c *config) traced(i *InterceptorInfo) bool {
+	if c.Filter != nil && !c.Filter(i) {
+		return false
+	}
+	if !c.WithoutInfraMethods {
+		return true
+	}
+	method := i.Method
+	switch {
+	case i.UnaryServerInfo != nil:
+		method = i.UnaryServerInfo.FullMethod
+	case i.StreamServerInfo != nil:
+		method = i.StreamServerInfo.FullMethod
+	}
+	return !isInfraMethod(method)
+}
+
 // WithTracerProvider returns an Option to use the TracerProvider when
 // creating a Tracer.
 func WithTracerProvider(tp trace.TracerProvider) Option {
@@ -232,3 +328,143 @@ func (o spanStartOption) apply(c *config) {
 func WithSpanOptions(opts ...trace.SpanStartOption) Option {
 	return spanStartOption{opts}
 }
+
+type clientMetricAttributesFnOption struct {
+	fn func(ctx context.Context, fullMethod string) []attribute.KeyValue
+}
+
+func (o clientMetricAttributesFnOption) apply(c *config) {
+	if o.fn != nil {
+		c.ClientMetricAttributesFn = o.fn
+	}
+}
+
+// WithClientMetricAttributesFn returns an Option to add the attributes
+// returned by fn to the metrics recorded by the client handler for each RPC,
+// including rpc.client.duration. The fn is called once per RPC with the
+// context of the RPC and its full method name (e.g. "/pkg.Service/Method").
+// This is only used by NewClientHandler.
+//
+// The attributes are added to every measurement of the RPC. Returning
+// attributes with many distinct values leads to a high cardinality of the
+// metrics, it is the responsibility of the user to prevent it.
+func WithClientMetricAttributesFn(fn func(ctx context.Context, fullMethod string) []attribute.KeyValue) Option {
+	return clientMetricAttributesFnOption{fn: fn}
+}
+
+type compressionAttributeOption struct{}
+
+func (compressionAttributeOption) apply(c *config) {
+	c.CompressionAttribute = true
+}
+
+// WithCompressionAttribute returns an Option to record the compression
+// algorithm negotiated for an RPC, read from its headers, with the
+// rpc.grpc.compression span attribute. The attribute is not recorded if the
+// messages are not compressed.
+//
+// This option only applies to the stats handlers, NewClientHandler and
+// NewServerHandler.
+func WithCompressionAttribute() Option {
+	return compressionAttributeOption{}
+}
+
+type authorityAndTypeAttributesOption struct{}
+
+func (authorityAndTypeAttributesOption) apply(c *config) {
+	c.AuthorityAndTypeAttributes = true
+}
+
+// WithAuthorityAndTypeAttributes returns an Option to record the type of the
+// RPC method with the rpc.grpc.type span attribute, "unary", "client_stream",
+// "server_stream" or "bidi", and the :authority pseudo-header of the RPCs
+// received by a server with the rpc.grpc.authority span attribute. The
+// authority is not recorded for the client spans, it is not known before the
+// RPC is sent.
+func WithAuthorityAndTypeAttributes() Option {
+	return authorityAndTypeAttributesOption{}
+}
+
+type metadataKeysOption []string
+
+func (o metadataKeysOption) apply(c *config) {
+	for _, k := range o {
+		c.MetadataKeys = append(c.MetadataKeys, strings.ToLower(k))
+	}
+}
+
+// WithMetadataKeys returns an Option to record the values of the request
+// metadata keys, e.g. "x-tenant-id", with the
+// rpc.grpc.request.metadata.<key> span attribute. Only the listed keys are
+// recorded, so callers cannot add arbitrary attributes to the spans. The
+// metadata received by a server and sent by a client are recorded. Keys are
+// case insensitive.
+//
+// The values longer than the limit set with WithMetadataValueLimit are
+// truncated, and only the first 16 values of a key are recorded.
+func WithMetadataKeys(keys ...string) Option {
+	return metadataKeysOption(keys)
+}
+
+// defaultMetadataValueLimit is the default maximum length, in bytes, of the
+// recorded metadata values.
+const defaultMetadataValueLimit = 256
+
+type metadataValueLimitOption int
+
+func (o metadataValueLimitOption) apply(c *config) {
+	if o > 0 {
+		c.MetadataValueLimit = int(o)
+	}
+}
+
+// WithMetadataValueLimit returns an Option to set the maximum length, in
+// bytes, of the metadata values recorded with WithMetadataKeys. Longer values
+// are truncated, on a UTF-8 character boundary, and end with "..." to mark
+// the truncation, the marker being counted within the limit. It is omitted if
+// the limit is 3 bytes or less. This protects the backends from callers
+// sending large values. The default limit is 256 bytes. A limit of zero or less is
+// ignored.
+func WithMetadataValueLimit(bytes int) Option {
+	return metadataValueLimitOption(bytes)
+}
+
+type messageTypeAttributesOption struct{}
+
+func (messageTypeAttributesOption) apply(c *config) {
+	c.MessageTypeAttributes = true
+}
+
+// WithMessageTypeAttributes returns an Option to record the full name of the
+// proto message of the message events, e.g. "grpc.testing.SimpleRequest",
+// with the rpc.grpc.message.proto_name attribute. The attribute is not
+// recorded for messages that are not proto messages. The message events are
+// enabled with WithMessageEvents.
+//
+// This option only applies to the stats handlers, NewClientHandler and
+// NewServerHandler.
+func WithMessageTypeAttributes() Option {
+	return messageTypeAttributesOption{}
+}
+
+type attemptSpansOption struct{}
+
+func (attemptSpansOption) apply(c *config) {
+	c.AttemptSpans = true
+}
+
+// WithAttemptSpans returns an Option to record a span for each attempt of
+// the RPCs sent by a client, as children of the span of the RPC. The RPCs
+// are attempted several times when they are retried with the retry policy of
+// the service config, or transparently retried by gRPC. The attempt spans
+// are numbered, from 1, with the rpc.grpc.attempt attribute, so that the
+// retries of an RPC can be found, e.g. to diagnose retry storms.
+//
+// The span of the RPC has the internal kind, the attempt spans have the
+// client kind and their span context is propagated to the server. The
+// status of the span of the RPC is the status of its last attempt.
+//
+// This option only applies to the client stats handler, NewClientHandler.
+func WithAttemptSpans() Option {
+	return attemptSpansOption{}
+}
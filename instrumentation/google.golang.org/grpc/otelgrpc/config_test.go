@@ -5,10 +5,15 @@ package otelgrpc
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/embedded"
 )
@@ -45,3 +50,65 @@ func (meter) Int64Histogram(string, ...metric.Int64HistogramOption) (metric.Int6
 func (meter) Float64Histogram(string, ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
 	return nil, assert.AnError
 }
+
+func TestIsInfraMethod(t *testing.T) {
+	for method, want := range map[string]bool{
+		"/grpc.health.v1.Health/Check":                                   true,
+		"/grpc.health.v1.Health/Watch":                                   true,
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      true,
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": true,
+		"/grpc.channelz.v1.Channelz/GetTopChannels":                      true,
+		"/grpc.testing.TestService/UnaryCall":                            false,
+		"/grpc.health.v1.HealthCheck/Check":                              false,
+		"/grpc.reflectionx.Service/Method":                               false,
+		"":                                                               false,
+	} {
+		assert.Equal(t, want, isInfraMethod(method), method)
+	}
+}
+
+func TestMetadataAttrs(t *testing.T) {
+	md := metadata.Pairs(
+		"x-tenant-id", "tenant-1",
+		"x-tenant-id", "tenant-2",
+		"x-large", strings.Repeat("a", 300),
+		"x-utf8", "héllo",
+		"x-ignored", "value",
+	)
+
+	c := newConfig([]Option{WithMetadataKeys("X-Tenant-ID", "x-large", "x-missing")}, "server")
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.StringSlice("rpc.grpc.request.metadata.x-tenant-id", []string{"tenant-1", "tenant-2"}),
+		attribute.StringSlice("rpc.grpc.request.metadata.x-large", []string{strings.Repeat("a", 253) + "..."}),
+	}, c.metadataAttrs(md))
+
+	c = newConfig([]Option{WithMetadataKeys("x-large", "x-utf8"), WithMetadataValueLimit(5)}, "server")
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.StringSlice("rpc.grpc.request.metadata.x-large", []string{"aa..."}),
+		// The truncation does not split the 2 bytes of "é".
+		attribute.StringSlice("rpc.grpc.request.metadata.x-utf8", []string{"h..."}),
+	}, c.metadataAttrs(md))
+
+	// The marker is omitted if the limit cannot hold it.
+	c = newConfig([]Option{WithMetadataKeys("x-large", "x-utf8"), WithMetadataValueLimit(2)}, "server")
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.StringSlice("rpc.grpc.request.metadata.x-large", []string{"aa"}),
+		attribute.StringSlice("rpc.grpc.request.metadata.x-utf8", []string{"h"}),
+	}, c.metadataAttrs(md))
+
+	c = newConfig(nil, "server")
+	assert.Nil(t, c.metadataAttrs(md))
+
+	// The number of values of a key is bounded.
+	md = metadata.MD{}
+	for i := 0; i < 1000; i++ {
+		md.Append("x-tenant-id", strconv.Itoa(i))
+	}
+	c = newConfig([]Option{WithMetadataKeys("x-tenant-id")}, "server")
+	attrs := c.metadataAttrs(md)
+	require.Len(t, attrs, 1)
+	values := attrs[0].Value.AsStringSlice()
+	require.Len(t, values, maxMetadataValues)
+	assert.Equal(t, "0", values[0])
+	assert.Equal(t, strconv.Itoa(maxMetadataValues-1), values[maxMetadataValues-1])
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"strconv"
 	"time"
+	"unicode/utf8"
 
 	"google.golang.org/grpc"
 	grpc_codes "google.golang.org/grpc/codes"
@@ -70,7 +71,7 @@ func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
 			Method: method,
 			Type:   UnaryClient,
 		}
-		if cfg.Filter != nil && !cfg.Filter(i) {
+		if !cfg.traced(i) {
 			return invoker(ctx, method, req, reply, cc, callOpts...)
 		}
 
@@ -79,6 +80,9 @@ func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
 		startOpts := append([]trace.SpanStartOption{
 			trace.WithSpanKind(trace.SpanKindClient),
 			trace.WithAttributes(attr...),
+			trace.WithAttributes(deadlineAttr(ctx)...),
+			trace.WithAttributes(cfg.typeAttr(false, false)...),
+			trace.WithAttributes(cfg.metadataAttrs(outgoingMD(ctx))...),
 		},
 			cfg.SpanStartOptions...,
 		)
@@ -230,7 +234,7 @@ func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
 			Method: method,
 			Type:   StreamClient,
 		}
-		if cfg.Filter != nil && !cfg.Filter(i) {
+		if !cfg.traced(i) {
 			return streamer(ctx, desc, cc, method, callOpts...)
 		}
 
@@ -239,6 +243,9 @@ func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
 		startOpts := append([]trace.SpanStartOption{
 			trace.WithSpanKind(trace.SpanKindClient),
 			trace.WithAttributes(attr...),
+			trace.WithAttributes(deadlineAttr(ctx)...),
+			trace.WithAttributes(cfg.typeAttr(desc.ClientStreams, desc.ServerStreams)...),
+			trace.WithAttributes(cfg.metadataAttrs(outgoingMD(ctx))...),
 		},
 			cfg.SpanStartOptions...,
 		)
@@ -285,7 +292,7 @@ func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
 			UnaryServerInfo: info,
 			Type:            UnaryServer,
 		}
-		if cfg.Filter != nil && !cfg.Filter(i) {
+		if !cfg.traced(i) {
 			return handler(ctx, req)
 		}
 
@@ -295,6 +302,10 @@ func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
 		startOpts := append([]trace.SpanStartOption{
 			trace.WithSpanKind(trace.SpanKindServer),
 			trace.WithAttributes(attr...),
+			trace.WithAttributes(deadlineAttr(ctx)...),
+			trace.WithAttributes(cfg.typeAttr(false, false)...),
+			trace.WithAttributes(cfg.authorityAttr(incomingMD(ctx))...),
+			trace.WithAttributes(cfg.metadataAttrs(incomingMD(ctx))...),
 		},
 			cfg.SpanStartOptions...,
 		)
@@ -411,7 +422,7 @@ func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
 			StreamServerInfo: info,
 			Type:             StreamServer,
 		}
-		if cfg.Filter != nil && !cfg.Filter(i) {
+		if !cfg.traced(i) {
 			return handler(srv, wrapServerStream(ctx, ss, cfg))
 		}
 
@@ -421,6 +432,10 @@ func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
 		startOpts := append([]trace.SpanStartOption{
 			trace.WithSpanKind(trace.SpanKindServer),
 			trace.WithAttributes(attr...),
+			trace.WithAttributes(deadlineAttr(ctx)...),
+			trace.WithAttributes(cfg.typeAttr(info.IsClientStream, info.IsServerStream)...),
+			trace.WithAttributes(cfg.authorityAttr(incomingMD(ctx))...),
+			trace.WithAttributes(cfg.metadataAttrs(incomingMD(ctx))...),
 		},
 			cfg.SpanStartOptions...,
 		)
@@ -500,6 +515,119 @@ func peerFromCtx(ctx context.Context) string {
 	return p.Addr.String()
 }
 
+// deadlineAttr returns the time remaining until the deadline of ctx as an
+// attribute. No attribute is returned if ctx does not have a deadline.
+func deadlineAttr(ctx context.Context) []attribute.KeyValue {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	return []attribute.KeyValue{GRPCDeadlineKey.Int64(time.Until(deadline).Milliseconds())}
+}
+
+// RPC method types recorded with GRPCTypeKey.
+const (
+	rpcTypeUnary        = "unary"
+	rpcTypeClientStream = "client_stream"
+	rpcTypeServerStream = "server_stream"
+	rpcTypeBidi         = "bidi"
+)
+
+// typeAttr returns the type attribute of a method streaming the requests if
+// clientStream, and the responses if serverStream. No attribute is returned
+// if WithAuthorityAndTypeAttributes is not used.
+func (c *config) typeAttr(clientStream, serverStream bool) []attribute.KeyValue {
+	if !c.AuthorityAndTypeAttributes {
+		return nil
+	}
+	t := rpcTypeUnary
+	switch {
+	case clientStream && serverStream:
+		t = rpcTypeBidi
+	case clientStream:
+		t = rpcTypeClientStream
+	case serverStream:
+		t = rpcTypeServerStream
+	}
+	return []attribute.KeyValue{GRPCTypeKey.String(t)}
+}
+
+// authorityAttr returns the authority attribute of the RPC received with md.
+// No attribute is returned if WithAuthorityAndTypeAttributes is not used or
+// md has no authority.
+func (c *config) authorityAttr(md metadata.MD) []attribute.KeyValue {
+	if !c.AuthorityAndTypeAttributes {
+		return nil
+	}
+	if v := md.Get(":authority"); len(v) > 0 && v[0] != "" {
+		return []attribute.KeyValue{GRPCAuthorityKey.String(v[0])}
+	}
+	return nil
+}
+
+// maxMetadataValues is the maximum number of values recorded for a metadata
+// key configured with WithMetadataKeys.
+const maxMetadataValues = 16
+
+// metadataAttrs returns the attributes of the values of md for the keys
+// configured with WithMetadataKeys. The values are truncated to the limit
+// configured with WithMetadataValueLimit, and only the first
+// maxMetadataValues values of a key are recorded.
+func (c *config) metadataAttrs(md metadata.MD) []attribute.KeyValue {
+	if len(c.MetadataKeys) == 0 || len(md) == 0 {
+		return nil
+	}
+	var attrs []attribute.KeyValue
+	for _, k := range c.MetadataKeys {
+		values := md.Get(k)
+		if len(values) == 0 {
+			continue
+		}
+		if len(values) > maxMetadataValues {
+			values = values[:maxMetadataValues]
+		}
+		truncated := make([]string, len(values))
+		for i, v := range values {
+			truncated[i] = truncateValue(v, c.MetadataValueLimit)
+		}
+		attrs = append(attrs, attribute.StringSlice(GRPCRequestMetadataKeyPrefix+k, truncated))
+	}
+	return attrs
+}
+
+// truncationMarker ends the metadata values truncated by truncateValue.
+const truncationMarker = "..."
+
+// truncateValue returns v truncated, on a UTF-8 character boundary, and
+// followed by truncationMarker if it is longer than limit bytes. The marker
+// is counted within the limit, and omitted if the limit is too small to hold
+// it.
+func truncateValue(v string, limit int) string {
+	if len(v) <= limit {
+		return v
+	}
+	n, marker := limit-len(truncationMarker), truncationMarker
+	if n <= 0 {
+		n, marker = limit, ""
+	}
+	for n > 0 && !utf8.RuneStart(v[n]) {
+		n--
+	}
+	return v[:n] + marker
+}
+
+// incomingMD returns the incoming metadata of ctx, or nil if it has none.
+func incomingMD(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return md
+}
+
+// outgoingMD returns the outgoing metadata of ctx, or nil if it has none.
+func outgoingMD(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return md
+}
+
 // statusCodeAttr returns status code attribute based on given gRPC code.
 func statusCodeAttr(c grpc_codes.Code) attribute.KeyValue {
 	return GRPCStatusCodeKey.Int64(int64(c))
@@ -5,6 +5,7 @@ package otelgrpc // import "go.opentelemetry.io/contrib/instrumentation/google.g
 
 import (
 	"context"
+	"strings"
 
 	"google.golang.org/grpc/metadata"
 
@@ -20,14 +21,25 @@ type metadataSupplier struct {
 // assert that metadataSupplier implements the TextMapCarrier interface.
 var _ propagation.TextMapCarrier = &metadataSupplier{}
 
+// Get returns the value associated with the passed key. If the key has
+// multiple values (e.g. a tracestate or baggage header split into several
+// entries), they are joined into a single comma-separated list as defined
+// for HTTP headers and expected by the propagators.
 func (s *metadataSupplier) Get(key string) string {
 	values := s.metadata.Get(key)
-	if len(values) == 0 {
+	switch len(values) {
+	case 0:
 		return ""
+	case 1:
+		return values[0]
+	default:
+		return strings.Join(values, ",")
 	}
-	return values[0]
 }
 
+// Set sets the value of key, replacing any existing values. Propagators set
+// the complete value of a key at once, so replacing ensures repeated
+// injections do not duplicate entries.
 func (s *metadataSupplier) Set(key string, value string) {
 	s.metadata.Set(key, value)
 }
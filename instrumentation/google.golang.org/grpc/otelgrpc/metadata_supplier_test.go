@@ -4,10 +4,17 @@
 package otelgrpc
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestMetadataSupplier(t *testing.T) {
@@ -26,3 +33,65 @@ func TestMetadataSupplier(t *testing.T) {
 	assert.Equal(t, v1, "v1")
 	assert.Equal(t, v2, "v2")
 }
+
+func TestMetadataSupplierMultipleValues(t *testing.T) {
+	md := metadata.MD{}
+	md.Append("tracestate", "vendor1=value1")
+	md.Append("tracestate", "vendor2=value2")
+	ms := &metadataSupplier{&md}
+
+	assert.Equal(t, "vendor1=value1,vendor2=value2", ms.Get("tracestate"))
+
+	ms.Set("tracestate", "vendor3=value3")
+	assert.Equal(t, []string{"vendor3=value3"}, md.Get("tracestate"))
+}
+
+func TestMetadataSupplierRoundTrip(t *testing.T) {
+	prop := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	ts, err := trace.ParseTraceState("vendor1=value1,vendor2=value2")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+
+	m1, err := baggage.NewMemberRaw("list", "a,b,c")
+	require.NoError(t, err)
+	m2, err := baggage.NewMemberRaw("key", "value")
+	require.NoError(t, err)
+	bag, err := baggage.New(m1, m2)
+	require.NoError(t, err)
+
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	ctx = baggage.ContextWithBaggage(ctx, bag)
+
+	md := metadata.MD{}
+	prop.Inject(ctx, &metadataSupplier{&md})
+
+	// Split the multi-entry headers as an intermediary might.
+	split := metadata.MD{}
+	for k, vals := range md {
+		for _, v := range vals {
+			for _, part := range strings.Split(v, ",") {
+				split.Append(k, part)
+			}
+		}
+	}
+
+	for name, carrier := range map[string]metadata.MD{"Injected": md, "Split": split} {
+		t.Run(name, func(t *testing.T) {
+			got := prop.Extract(context.Background(), &metadataSupplier{&carrier})
+
+			gotSC := trace.SpanContextFromContext(got)
+			assert.Equal(t, sc.TraceID(), gotSC.TraceID())
+			assert.Equal(t, ts.String(), gotSC.TraceState().String())
+
+			gotBag := baggage.FromContext(got)
+			assert.Equal(t, "a,b,c", gotBag.Member("list").Value())
+			assert.Equal(t, "value", gotBag.Member("key").Value())
+		})
+	}
+}
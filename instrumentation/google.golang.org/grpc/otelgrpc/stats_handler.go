@@ -5,6 +5,7 @@ package otelgrpc // import "go.opentelemetry.io/contrib/instrumentation/google.g
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/stats"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/internal"
 	"go.opentelemetry.io/otel/attribute"
@@ -27,8 +29,14 @@ type gRPCContext struct {
 	messagesReceived int64
 	messagesSent     int64
 	metricAttrs      []attribute.KeyValue
+	// call is the RPC of the attempt, nil if WithAttemptSpans is not used.
+	call *clientCall
 }
 
+// ignoredRPC is the gRPCContext of the RPCs excluded from the
+// instrumentation, see WithoutInfraMethods.
+var ignoredRPC = &gRPCContext{}
+
 type serverHandler struct {
 	*config
 }
@@ -53,6 +61,9 @@ func (h *serverHandler) HandleConn(ctx context.Context, info stats.ConnStats) {
 
 // TagRPC can attach some information to the given context.
 func (h *serverHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	if h.WithoutInfraMethods && isInfraMethod(info.FullMethodName) {
+		return context.WithValue(ctx, gRPCContextKey{}, ignoredRPC)
+	}
 	ctx = extract(ctx, h.config.Propagators)
 
 	name, attrs := internal.ParseFullMethod(info.FullMethodName)
@@ -62,6 +73,7 @@ func (h *serverHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) cont
 		name,
 		trace.WithSpanKind(trace.SpanKindServer),
 		trace.WithAttributes(attrs...),
+		trace.WithAttributes(deadlineAttr(ctx)...),
 	)
 
 	gctx := gRPCContext{
@@ -78,6 +90,10 @@ func (h *serverHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
 
 type clientHandler struct {
 	*config
+
+	// calls are the clientCall of the RPCs in progress, by the done channel
+	// of their context, if WithAttemptSpans is used.
+	calls sync.Map
 }
 
 // NewClientHandler creates a stats.Handler for a gRPC client.
@@ -91,22 +107,157 @@ func NewClientHandler(opts ...Option) stats.Handler {
 
 // TagRPC can attach some information to the given context.
 func (h *clientHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	if h.WithoutInfraMethods && isInfraMethod(info.FullMethodName) {
+		return context.WithValue(ctx, gRPCContextKey{}, ignoredRPC)
+	}
 	name, attrs := internal.ParseFullMethod(info.FullMethodName)
 	attrs = append(attrs, RPCSystemGRPC)
+
+	var (
+		call        *clientCall
+		attemptAttr []attribute.KeyValue
+	)
+	if h.AttemptSpans {
+		if call = h.call(ctx, name, attrs); call != nil {
+			ctx = trace.ContextWithSpan(ctx, call.span)
+			attemptAttr = []attribute.KeyValue{GRPCAttemptKey.Int64(call.attempts.Add(1))}
+		}
+	}
+
 	ctx, _ = h.tracer.Start(
 		ctx,
 		name,
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(attrs...),
+		trace.WithAttributes(attemptAttr...),
+		trace.WithAttributes(deadlineAttr(ctx)...),
+		trace.WithAttributes(h.metadataAttrs(outgoingMD(ctx))...),
 	)
 
+	metricAttrs := attrs
+	if h.ClientMetricAttributesFn != nil {
+		custom := h.ClientMetricAttributesFn(ctx, info.FullMethodName)
+		metricAttrs = make([]attribute.KeyValue, 0, len(attrs)+len(custom))
+		metricAttrs = append(metricAttrs, attrs...)
+		metricAttrs = append(metricAttrs, custom...)
+	}
+
 	gctx := gRPCContext{
-		metricAttrs: attrs,
+		metricAttrs: metricAttrs,
+		call:        call,
 	}
 
 	return inject(context.WithValue(ctx, gRPCContextKey{}, &gctx), h.config.Propagators)
 }
 
+// call returns the clientCall of the RPC attempted with ctx, starting its
+// span on its first attempt, and records the start of an attempt.
+//
+// gRPC calls TagRPC for each attempt of an RPC with a context derived from
+// the context of the RPC, which gRPC creates with its own cancel function and
+// cancels once the RPC is finished: the attempts of an RPC, and only them,
+// share the done channel of their context. nil is returned if the context
+// cannot be canceled.
+//
+// The span of the RPC is ended by the *stats.End of its last attempt, see
+// clientCall.finish.
+func (h *clientHandler) call(ctx context.Context, name string, attrs []attribute.KeyValue) *clientCall {
+	done := ctx.Done()
+	if done == nil {
+		return nil
+	}
+	// The attempts of an RPC are made sequentially.
+	if v, ok := h.calls.Load(done); ok {
+		c := v.(*clientCall)
+		c.start()
+		return c
+	}
+
+	_, span := h.tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attrs...),
+	)
+	c := &clientCall{span: span, remove: func() { h.calls.Delete(done) }}
+	c.start()
+	h.calls.Store(done, c)
+	context.AfterFunc(ctx, c.cancel)
+	return c
+}
+
+// clientCall is an RPC sent by a client instrumented with a span for each of
+// its attempts.
+type clientCall struct {
+	span     trace.Span
+	attempts atomic.Int64
+	// remove removes the clientCall from the RPCs in progress.
+	remove func()
+
+	mu  sync.Mutex
+	err error
+	// active is the number of attempts started and not finished.
+	active int
+	// done is true once the context of the RPC is done.
+	done  bool
+	ended bool
+}
+
+// start records the start of an attempt of c.
+func (c *clientCall) start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active++
+}
+
+// finish records the end of an attempt of c with err, nil if it succeeded,
+// and ends the span of c with the status of the attempt if it is the last
+// one.
+//
+// A successful attempt is the last one. A failed attempt is known to be the
+// last one once the context of the RPC is done, which gRPC cancels after the
+// last attempt is finished, or before it is finished if the RPC is canceled
+// or its deadline is exceeded.
+func (c *clientCall) finish(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active--
+	c.err = err
+	if err == nil || (c.done && c.active == 0) {
+		c.end()
+	}
+}
+
+// cancel records that the context of the RPC of c is done, and ends the span
+// of c if its last attempt is finished.
+func (c *clientCall) cancel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done = true
+	if c.active == 0 {
+		c.end()
+	}
+}
+
+// end ends the span of c with the status of its last attempt. It must be
+// called with c.mu held.
+func (c *clientCall) end() {
+	if c.ended {
+		return
+	}
+	c.ended = true
+	c.remove()
+
+	code := grpc_codes.OK
+	if c.err != nil {
+		s, _ := status.FromError(c.err)
+		c.span.SetStatus(codes.Error, s.Message())
+		code = s.Code()
+	}
+	c.span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	c.span.End()
+}
+
 // HandleRPC processes the RPC stats.
 func (h *clientHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
 	isServer := false
@@ -129,6 +280,9 @@ func (c *config) handleRPC(ctx context.Context, rs stats.RPCStats, isServer bool
 	var messageId int64
 
 	gctx, _ := ctx.Value(gRPCContextKey{}).(*gRPCContext)
+	if gctx == ignoredRPC {
+		return
+	}
 	if gctx != nil {
 		metricAttrs = make([]attribute.KeyValue, 0, len(gctx.metricAttrs)+1)
 		metricAttrs = append(metricAttrs, gctx.metricAttrs...)
@@ -136,6 +290,7 @@ func (c *config) handleRPC(ctx context.Context, rs stats.RPCStats, isServer bool
 
 	switch rs := rs.(type) {
 	case *stats.Begin:
+		span.SetAttributes(c.typeAttr(rs.IsClientStream, rs.IsServerStream)...)
 	case *stats.InPayload:
 		if gctx != nil {
 			messageId = atomic.AddInt64(&gctx.messagesReceived, 1)
@@ -144,12 +299,13 @@ func (c *config) handleRPC(ctx context.Context, rs stats.RPCStats, isServer bool
 
 		if c.ReceivedEvent {
 			span.AddEvent("message",
-				trace.WithAttributes(
+				trace.WithAttributes(c.messageAttributes(
+					rs.Payload,
 					semconv.MessageTypeReceived,
 					semconv.MessageIDKey.Int64(messageId),
 					semconv.MessageCompressedSizeKey.Int(rs.CompressedLength),
 					semconv.MessageUncompressedSizeKey.Int(rs.Length),
-				),
+				)...),
 			)
 		}
 	case *stats.OutPayload:
@@ -160,19 +316,31 @@ func (c *config) handleRPC(ctx context.Context, rs stats.RPCStats, isServer bool
 
 		if c.SentEvent {
 			span.AddEvent("message",
-				trace.WithAttributes(
+				trace.WithAttributes(c.messageAttributes(
+					rs.Payload,
 					semconv.MessageTypeSent,
 					semconv.MessageIDKey.Int64(messageId),
 					semconv.MessageCompressedSizeKey.Int(rs.CompressedLength),
 					semconv.MessageUncompressedSizeKey.Int(rs.Length),
-				),
+				)...),
 			)
 		}
+	case *stats.InHeader:
+		if c.CompressionAttribute {
+			setCompression(span, rs.Compression)
+		}
+		if isServer {
+			span.SetAttributes(c.authorityAttr(rs.Header)...)
+			span.SetAttributes(c.metadataAttrs(rs.Header)...)
+		}
 	case *stats.OutTrailer:
 	case *stats.OutHeader:
 		if p, ok := peer.FromContext(ctx); ok {
 			span.SetAttributes(peerAttr(p.Addr.String())...)
 		}
+		if c.CompressionAttribute {
+			setCompression(span, rs.Compression)
+		}
 	case *stats.End:
 		var rpcStatusAttr attribute.KeyValue
 
@@ -190,6 +358,9 @@ func (c *config) handleRPC(ctx context.Context, rs stats.RPCStats, isServer bool
 		}
 		span.SetAttributes(rpcStatusAttr)
 		span.End()
+		if gctx != nil && gctx.call != nil {
+			gctx.call.finish(rs.Error)
+		}
 
 		metricAttrs = append(metricAttrs, rpcStatusAttr)
 
@@ -205,3 +376,25 @@ func (c *config) handleRPC(ctx context.Context, rs stats.RPCStats, isServer bool
 		return
 	}
 }
+
+// setCompression sets the compression algorithm on span. Nothing is set if
+// the messages are not compressed.
+func setCompression(span trace.Span, compression string) {
+	if compression == "" || compression == "identity" {
+		return
+	}
+	span.SetAttributes(GRPCCompressionKey.String(compression))
+}
+
+// messageAttributes returns the attributes of the message event of payload,
+// attrs with the full name of the proto message of payload if
+// WithMessageTypeAttributes is used.
+func (c *config) messageAttributes(payload interface{}, attrs ...attribute.KeyValue) []attribute.KeyValue {
+	if !c.MessageTypeAttributes {
+		return attrs
+	}
+	if m, ok := payload.(proto.Message); ok {
+		attrs = append(attrs, GRPCMessageProtoNameKey.String(string(m.ProtoReflect().Descriptor().FullName())))
+	}
+	return attrs
+}
@@ -9,17 +9,22 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/internal/test"
 	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
@@ -28,6 +33,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	testpb "google.golang.org/grpc/interop/grpc_testing"
 )
@@ -1418,3 +1424,572 @@ func TestStatsHandlerConcurrentSafeContextCancellation(t *testing.T) {
 		wg.Wait()
 	}
 }
+
+func TestStatsHandlerDeadline(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+
+	serverSR := tracetest.NewSpanRecorder()
+	serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	client := newGrpcTest(t, listener,
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(clientTP))),
+		},
+		[]grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithTracerProvider(serverTP))),
+		},
+	)
+
+	const timeout = 10 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err = client.EmptyCall(ctx, &testpb.Empty{})
+	require.NoError(t, err)
+
+	_, err = client.EmptyCall(context.Background(), &testpb.Empty{})
+	require.NoError(t, err)
+
+	for name, sr := range map[string]*tracetest.SpanRecorder{"Client": clientSR, "Server": serverSR} {
+		t.Run(name, func(t *testing.T) {
+			spans := sr.Ended()
+			require.Len(t, spans, 2)
+
+			deadline, ok := attributeValue(spans[0].Attributes(), otelgrpc.GRPCDeadlineKey)
+			require.True(t, ok, "missing deadline attribute")
+			assert.InDelta(t, timeout.Milliseconds(), deadline.AsInt64(), float64(time.Second.Milliseconds()))
+
+			_, ok = attributeValue(spans[1].Attributes(), otelgrpc.GRPCDeadlineKey)
+			assert.False(t, ok, "deadline attribute recorded without a deadline")
+		})
+	}
+}
+
+func TestStatsHandlerBidiStreamSingleSpan(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+
+	serverSR := tracetest.NewSpanRecorder()
+	serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	client := newGrpcTest(t, listener,
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+				otelgrpc.WithTracerProvider(clientTP),
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+			)),
+		},
+		[]grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler(
+				otelgrpc.WithTracerProvider(serverTP),
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+			)),
+		},
+	)
+
+	const messages = 5
+	start := time.Now()
+	stream, err := client.FullDuplexCall(context.Background())
+	require.NoError(t, err)
+
+	for i := 0; i < messages; i++ {
+		require.NoError(t, stream.Send(&testpb.StreamingOutputCallRequest{
+			ResponseParameters: []*testpb.ResponseParameters{{Size: 1}},
+			Payload:            &testpb.Payload{Body: make([]byte, 1)},
+		}))
+		_, err := stream.Recv()
+		require.NoError(t, err)
+
+		// No span is ended while the stream is still open.
+		assert.Empty(t, clientSR.Ended(), "client span ended before the stream")
+		assert.Empty(t, serverSR.Ended(), "server span ended before the stream")
+	}
+	require.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	require.ErrorIs(t, err, io.EOF)
+	end := time.Now()
+
+	for name, sr := range map[string]*tracetest.SpanRecorder{"Client": clientSR, "Server": serverSR} {
+		t.Run(name, func(t *testing.T) {
+			// The server span may end after the client has received the
+			// end of the stream.
+			require.Eventually(t, func() bool {
+				return len(sr.Ended()) == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			span := sr.Ended()[0]
+			assert.Equal(t, "grpc.testing.TestService/FullDuplexCall", span.Name())
+			assert.False(t, span.StartTime().Before(start), "span started before the stream")
+			if name == "Client" {
+				assert.False(t, span.EndTime().After(end), "client span ended after the stream")
+			}
+
+			var sent, received int
+			for _, e := range span.Events() {
+				typ, ok := attributeValue(e.Attributes, semconv.MessageTypeKey)
+				require.True(t, ok, "missing message type")
+				switch typ.AsString() {
+				case "SENT":
+					sent++
+				case "RECEIVED":
+					received++
+				}
+			}
+			assert.Equal(t, messages, sent, "sent messages")
+			assert.Equal(t, messages, received, "received messages")
+
+			code, ok := attributeValue(span.Attributes(), semconv.RPCGRPCStatusCodeKey)
+			require.True(t, ok, "missing status code")
+			assert.Equal(t, int64(codes.OK), code.AsInt64())
+		})
+	}
+}
+
+func TestStatsHandlerOKStatusCodeMetrics(t *testing.T) {
+	clientMR := metric.NewManualReader()
+	clientMP := metric.NewMeterProvider(metric.WithReader(clientMR))
+
+	serverMR := metric.NewManualReader()
+	serverMP := metric.NewMeterProvider(metric.WithReader(serverMR))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	client := newGrpcTest(t, listener,
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithMeterProvider(clientMP))),
+		},
+		[]grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithMeterProvider(serverMP))),
+		},
+	)
+
+	_, err = client.EmptyCall(context.Background(), &testpb.Empty{})
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		reader metric.Reader
+		metric string
+	}{
+		"Client": {reader: clientMR, metric: "rpc.client.duration"},
+		"Server": {reader: serverMR, metric: "rpc.server.duration"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// The server records its metrics after the client received the
+			// response.
+			var dps []metricdata.HistogramDataPoint[float64]
+			require.Eventually(t, func() bool {
+				var rm metricdata.ResourceMetrics
+				require.NoError(t, tc.reader.Collect(context.Background(), &rm))
+				for _, sm := range rm.ScopeMetrics {
+					for _, m := range sm.Metrics {
+						if m.Name == tc.metric {
+							dps = m.Data.(metricdata.Histogram[float64]).DataPoints
+							return true
+						}
+					}
+				}
+				return false
+			}, 5*time.Second, 10*time.Millisecond)
+
+			require.Len(t, dps, 1)
+			code, ok := dps[0].Attributes.Value(otelgrpc.GRPCStatusCodeKey)
+			require.True(t, ok, "missing rpc.grpc.status_code")
+			assert.Equal(t, int64(codes.OK), code.AsInt64())
+		})
+	}
+}
+
+type featureKey struct{}
+
+func TestStatsHandlerClientMetricAttributesFn(t *testing.T) {
+	clientMR := metric.NewManualReader()
+	clientMP := metric.NewMeterProvider(metric.WithReader(clientMR))
+
+	var gotMethod string
+	attrsFn := func(ctx context.Context, fullMethod string) []attribute.KeyValue {
+		gotMethod = fullMethod
+		feature, _ := ctx.Value(featureKey{}).(string)
+		return []attribute.KeyValue{attribute.String("feature", feature)}
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	client := newGrpcTest(t, listener,
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+				otelgrpc.WithMeterProvider(clientMP),
+				otelgrpc.WithClientMetricAttributesFn(attrsFn),
+			)),
+		},
+		[]grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		},
+	)
+
+	ctx := context.WithValue(context.Background(), featureKey{}, "checkout")
+	_, err = client.EmptyCall(ctx, &testpb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "/grpc.testing.TestService/EmptyCall", gotMethod)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, clientMR.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	var found bool
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "rpc.client.duration" {
+			continue
+		}
+		found = true
+		dps := m.Data.(metricdata.Histogram[float64]).DataPoints
+		require.Len(t, dps, 1)
+		feature, ok := dps[0].Attributes.Value("feature")
+		require.True(t, ok, "missing custom attribute")
+		assert.Equal(t, "checkout", feature.AsString())
+		method, ok := dps[0].Attributes.Value(semconv.RPCMethodKey)
+		require.True(t, ok, "missing rpc.method")
+		assert.Equal(t, "EmptyCall", method.AsString())
+	}
+	assert.True(t, found, "missing rpc.client.duration")
+}
+
+func attributeValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestStatsHandlerCompressionAttribute(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+	serverSR := tracetest.NewSpanRecorder()
+	serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	client := newGrpcTest(t, listener,
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+				otelgrpc.WithTracerProvider(clientTP),
+				otelgrpc.WithCompressionAttribute(),
+			)),
+		},
+		[]grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler(
+				otelgrpc.WithTracerProvider(serverTP),
+				otelgrpc.WithCompressionAttribute(),
+			)),
+		},
+	)
+
+	_, err = client.EmptyCall(context.Background(), &testpb.Empty{}, grpc.UseCompressor(gzip.Name))
+	require.NoError(t, err)
+	_, err = client.UnaryCall(context.Background(), &testpb.SimpleRequest{})
+	require.NoError(t, err)
+
+	for name, spans := range map[string][]trace.ReadOnlySpan{
+		"client": clientSR.Ended(),
+		"server": serverSR.Ended(),
+	} {
+		require.Len(t, spans, 2, name)
+		v, ok := attributeValue(spans[0].Attributes(), otelgrpc.GRPCCompressionKey)
+		require.True(t, ok, "%s: missing compression attribute", name)
+		assert.Equal(t, gzip.Name, v.AsString(), name)
+
+		_, ok = attributeValue(spans[1].Attributes(), otelgrpc.GRPCCompressionKey)
+		assert.False(t, ok, "%s: compression attribute recorded for uncompressed call", name)
+	}
+}
+
+func TestStatsHandlerCompressionAttributeDisabled(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	client := newGrpcTest(t, listener,
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(clientTP))),
+		},
+		[]grpc.ServerOption{},
+	)
+
+	_, err = client.EmptyCall(context.Background(), &testpb.Empty{}, grpc.UseCompressor(gzip.Name))
+	require.NoError(t, err)
+
+	spans := clientSR.Ended()
+	require.Len(t, spans, 1)
+	_, ok := attributeValue(spans[0].Attributes(), otelgrpc.GRPCCompressionKey)
+	assert.False(t, ok)
+}
+
+func TestStatsHandlerMessageTypeAttributes(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+	serverSR := tracetest.NewSpanRecorder()
+	serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	client := newGrpcTest(t, listener,
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+				otelgrpc.WithTracerProvider(clientTP),
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+				otelgrpc.WithMessageTypeAttributes(),
+			)),
+		},
+		[]grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler(
+				otelgrpc.WithTracerProvider(serverTP),
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+				otelgrpc.WithMessageTypeAttributes(),
+			)),
+		},
+	)
+
+	_, err = client.UnaryCall(context.Background(), &testpb.SimpleRequest{})
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		spans    []trace.ReadOnlySpan
+		sent     string
+		received string
+	}{
+		"client": {clientSR.Ended(), "grpc.testing.SimpleRequest", "grpc.testing.SimpleResponse"},
+		"server": {serverSR.Ended(), "grpc.testing.SimpleResponse", "grpc.testing.SimpleRequest"},
+	} {
+		require.Len(t, tc.spans, 1, name)
+		events := tc.spans[0].Events()
+		require.Len(t, events, 2, name)
+		for _, e := range events {
+			typ, ok := attributeValue(e.Attributes, semconv.MessageTypeKey)
+			require.True(t, ok, name)
+			want := tc.received
+			if typ.AsString() == "SENT" {
+				want = tc.sent
+			}
+			v, ok := attributeValue(e.Attributes, otelgrpc.GRPCMessageProtoNameKey)
+			require.True(t, ok, "%s: missing proto name attribute", name)
+			assert.Equal(t, want, v.AsString(), name)
+		}
+	}
+}
+
+func TestStatsHandlerMessageTypeAttributesDisabled(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	client := newGrpcTest(t, listener,
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+				otelgrpc.WithTracerProvider(clientTP),
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+			)),
+		},
+		[]grpc.ServerOption{},
+	)
+
+	_, err = client.UnaryCall(context.Background(), &testpb.SimpleRequest{})
+	require.NoError(t, err)
+
+	spans := clientSR.Ended()
+	require.Len(t, spans, 1)
+	require.Len(t, spans[0].Events(), 2)
+	for _, e := range spans[0].Events() {
+		_, ok := attributeValue(e.Attributes, otelgrpc.GRPCMessageProtoNameKey)
+		assert.False(t, ok)
+	}
+}
+
+// flakyServer fails the first EmptyCall RPCs it receives with the
+// Unavailable status.
+type flakyServer struct {
+	testpb.UnimplementedTestServiceServer
+
+	failures atomic.Int64
+}
+
+func (s *flakyServer) EmptyCall(context.Context, *testpb.Empty) (*testpb.Empty, error) {
+	if s.failures.Add(-1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	return &testpb.Empty{}, nil
+}
+
+func TestStatsHandlerAttemptSpans(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	srv := &flakyServer{}
+	grpcServer := grpc.NewServer()
+	testpb.RegisterTestServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithTracerProvider(clientTP),
+			otelgrpc.WithAttemptSpans(),
+		)),
+		grpc.WithDefaultServiceConfig(`{
+			"methodConfig": [{
+				"name": [{"service": "grpc.testing.TestService"}],
+				"retryPolicy": {
+					"maxAttempts": 4,
+					"initialBackoff": "0.001s",
+					"maxBackoff": "0.001s",
+					"backoffMultiplier": 1,
+					"retryableStatusCodes": ["UNAVAILABLE"]
+				}
+			}]
+		}`),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	client := testpb.NewTestServiceClient(conn)
+
+	// The RPC succeeds on its third attempt.
+	srv.failures.Store(2)
+	_, err = client.EmptyCall(context.Background(), &testpb.Empty{})
+	require.NoError(t, err)
+	// The RPC is not retried.
+	_, err = client.EmptyCall(context.Background(), &testpb.Empty{})
+	require.NoError(t, err)
+
+	var calls, attempts []trace.ReadOnlySpan
+	require.Eventually(t, func() bool {
+		calls, attempts = nil, nil
+		for _, s := range clientSR.Ended() {
+			if s.SpanKind() == oteltrace.SpanKindInternal {
+				calls = append(calls, s)
+			} else {
+				attempts = append(attempts, s)
+			}
+		}
+		return len(calls) == 2 && len(attempts) == 4
+	}, time.Second, 10*time.Millisecond)
+
+	wantCodes := []codes.Code{codes.Unavailable, codes.Unavailable, codes.OK, codes.OK}
+	wantAttempts := []int64{1, 2, 3, 1}
+	for i, s := range attempts {
+		assert.Equal(t, "grpc.testing.TestService/EmptyCall", s.Name())
+		assert.Equal(t, oteltrace.SpanKindClient, s.SpanKind())
+		call := calls[0]
+		if i == 3 {
+			call = calls[1]
+		}
+		assert.Equal(t, call.SpanContext().SpanID(), s.Parent().SpanID(), "attempt %d", i)
+		assert.Contains(t, s.Attributes(), otelgrpc.GRPCAttemptKey.Int64(wantAttempts[i]), "attempt %d", i)
+		assert.Contains(t, s.Attributes(), semconv.RPCGRPCStatusCodeKey.Int64(int64(wantCodes[i])), "attempt %d", i)
+	}
+	for _, s := range calls {
+		assert.Equal(t, "grpc.testing.TestService/EmptyCall", s.Name())
+		assert.Contains(t, s.Attributes(), semconv.RPCGRPCStatusCodeKey.Int64(int64(codes.OK)))
+		_, ok := attributeValue(s.Attributes(), otelgrpc.GRPCAttemptKey)
+		assert.False(t, ok, "attempt attribute recorded for the RPC span")
+	}
+}
+
+// blockingServer blocks the EmptyCall RPCs until they are canceled.
+type blockingServer struct {
+	testpb.UnimplementedTestServiceServer
+
+	started chan struct{}
+}
+
+func (s *blockingServer) EmptyCall(ctx context.Context, _ *testpb.Empty) (*testpb.Empty, error) {
+	s.started <- struct{}{}
+	<-ctx.Done()
+	return nil, status.FromContextError(ctx.Err()).Err()
+}
+
+func TestStatsHandlerAttemptSpansCanceled(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to open port")
+	srv := &blockingServer{started: make(chan struct{}, 1)}
+	grpcServer := grpc.NewServer()
+	testpb.RegisterTestServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	for _, tt := range []struct {
+		name string
+		ctx  func() (context.Context, context.CancelFunc)
+		code codes.Code
+	}{
+		{
+			name: "Deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			code: codes.DeadlineExceeded,
+		},
+		{
+			name: "Canceled",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				go func() {
+					<-srv.started
+					cancel()
+				}()
+				return ctx, cancel
+			},
+			code: codes.Canceled,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			clientSR := tracetest.NewSpanRecorder()
+			clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+
+			conn, err := grpc.NewClient(
+				listener.Addr().String(),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+					otelgrpc.WithTracerProvider(clientTP),
+					otelgrpc.WithAttemptSpans(),
+				)),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+			client := testpb.NewTestServiceClient(conn)
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			_, err = client.EmptyCall(ctx, &testpb.Empty{})
+			require.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.DeadlineExceeded {
+				<-srv.started
+			}
+
+			require.Eventually(t, func() bool {
+				return len(clientSR.Ended()) == 2
+			}, time.Second, 10*time.Millisecond)
+
+			// The attempt span is ended before the span of the RPC, both
+			// with the status of the attempt.
+			spans := clientSR.Ended()
+			attempt, call := spans[0], spans[1]
+			assert.Equal(t, oteltrace.SpanKindClient, attempt.SpanKind())
+			assert.Equal(t, oteltrace.SpanKindInternal, call.SpanKind())
+			assert.Equal(t, call.SpanContext().SpanID(), attempt.Parent().SpanID())
+			assert.False(t, call.EndTime().Before(attempt.EndTime()), "RPC span ended before its attempt")
+			for _, s := range spans {
+				assert.Equal(t, otelcodes.Error, s.Status().Code, s.SpanKind().String())
+				assert.Contains(t, s.Attributes(), semconv.RPCGRPCStatusCodeKey.Int64(int64(tt.code)), s.SpanKind().String())
+			}
+		})
+	}
+}
@@ -5,8 +5,10 @@ package test
 
 import (
 	"context"
+	"io"
 	"net"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,6 +16,11 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/internal/test"
@@ -699,3 +706,260 @@ func findAttribute(kvs []attribute.KeyValue, key attribute.Key) (attribute.KeyVa
 	}
 	return attribute.KeyValue{}, false
 }
+
+func TestAuthorityAndTypeAttributes(t *testing.T) {
+	wantTypes := map[string]string{
+		"grpc.testing.TestService/EmptyCall":           "unary",
+		"grpc.testing.TestService/UnaryCall":           "unary",
+		"grpc.testing.TestService/StreamingInputCall":  "client_stream",
+		"grpc.testing.TestService/StreamingOutputCall": "server_stream",
+		"grpc.testing.TestService/FullDuplexCall":      "bidi",
+	}
+
+	testCases := []struct {
+		name string
+		opts func(clientTP, serverTP *trace.TracerProvider) ([]grpc.DialOption, []grpc.ServerOption)
+	}{
+		{
+			name: "interceptors",
+			opts: func(clientTP, serverTP *trace.TracerProvider) ([]grpc.DialOption, []grpc.ServerOption) {
+				clientOpts := []otelgrpc.Option{otelgrpc.WithTracerProvider(clientTP), otelgrpc.WithAuthorityAndTypeAttributes()}
+				serverOpts := []otelgrpc.Option{otelgrpc.WithTracerProvider(serverTP), otelgrpc.WithAuthorityAndTypeAttributes()}
+				return []grpc.DialOption{
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor(clientOpts...)),
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor(clientOpts...)),
+				}, []grpc.ServerOption{
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor(serverOpts...)),
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.StreamInterceptor(otelgrpc.StreamServerInterceptor(serverOpts...)),
+				}
+			},
+		},
+		{
+			name: "stats handlers",
+			opts: func(clientTP, serverTP *trace.TracerProvider) ([]grpc.DialOption, []grpc.ServerOption) {
+				return []grpc.DialOption{
+					grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+						otelgrpc.WithTracerProvider(clientTP),
+						otelgrpc.WithAuthorityAndTypeAttributes(),
+					)),
+				}, []grpc.ServerOption{
+					grpc.StatsHandler(otelgrpc.NewServerHandler(
+						otelgrpc.WithTracerProvider(serverTP),
+						otelgrpc.WithAuthorityAndTypeAttributes(),
+					)),
+				}
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientSR := tracetest.NewSpanRecorder()
+			clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+			serverSR := tracetest.NewSpanRecorder()
+			serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err, "failed to open port")
+			dialOpts, serverOpts := tc.opts(clientTP, serverTP)
+			client := newGrpcTest(t, listener, dialOpts, serverOpts)
+			doCalls(context.Background(), client)
+
+			for side, spans := range map[string][]trace.ReadOnlySpan{
+				"client": clientSR.Ended(),
+				"server": serverSR.Ended(),
+			} {
+				require.Len(t, spans, len(wantTypes), side)
+				for _, span := range spans {
+					attrs := attribute.NewSet(span.Attributes()...)
+					v, ok := attrs.Value(otelgrpc.GRPCTypeKey)
+					require.True(t, ok, "%s %s: missing type attribute", side, span.Name())
+					assert.Equal(t, wantTypes[span.Name()], v.AsString(), "%s %s", side, span.Name())
+
+					v, ok = attrs.Value(otelgrpc.GRPCAuthorityKey)
+					if side == "server" {
+						assert.True(t, ok, "server %s: missing authority attribute", span.Name())
+						assert.Equal(t, listener.Addr().String(), v.AsString(), "server %s", span.Name())
+					} else {
+						assert.False(t, ok, "client %s: unexpected authority attribute", span.Name())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWithoutInfraMethods(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts func(clientTP, serverTP *trace.TracerProvider) ([]grpc.DialOption, []grpc.ServerOption)
+	}{
+		{
+			name: "interceptors",
+			opts: func(clientTP, serverTP *trace.TracerProvider) ([]grpc.DialOption, []grpc.ServerOption) {
+				clientOpts := []otelgrpc.Option{otelgrpc.WithTracerProvider(clientTP), otelgrpc.WithoutInfraMethods()}
+				serverOpts := []otelgrpc.Option{otelgrpc.WithTracerProvider(serverTP), otelgrpc.WithoutInfraMethods()}
+				return []grpc.DialOption{
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor(clientOpts...)),
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor(clientOpts...)),
+				}, []grpc.ServerOption{
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor(serverOpts...)),
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.StreamInterceptor(otelgrpc.StreamServerInterceptor(serverOpts...)),
+				}
+			},
+		},
+		{
+			name: "stats handlers",
+			opts: func(clientTP, serverTP *trace.TracerProvider) ([]grpc.DialOption, []grpc.ServerOption) {
+				return []grpc.DialOption{
+					grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+						otelgrpc.WithTracerProvider(clientTP),
+						otelgrpc.WithoutInfraMethods(),
+					)),
+				}, []grpc.ServerOption{
+					grpc.StatsHandler(otelgrpc.NewServerHandler(
+						otelgrpc.WithTracerProvider(serverTP),
+						otelgrpc.WithoutInfraMethods(),
+					)),
+				}
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientSR := tracetest.NewSpanRecorder()
+			clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+			serverSR := tracetest.NewSpanRecorder()
+			serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+			dialOpts, serverOpts := tc.opts(clientTP, serverTP)
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err, "failed to open port")
+			grpcServer := grpc.NewServer(serverOpts...)
+			pb.RegisterTestServiceServer(grpcServer, test.NewTestServer())
+			healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+			reflection.Register(grpcServer)
+			errCh := make(chan error)
+			go func() { errCh <- grpcServer.Serve(listener) }()
+			t.Cleanup(func() {
+				grpcServer.Stop()
+				assert.NoError(t, <-errCh)
+			})
+
+			dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			conn, err := grpc.NewClient(listener.Addr().String(), dialOpts...)
+			require.NoError(t, err)
+			t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+
+			ctx := context.Background()
+			_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+
+			stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+			require.NoError(t, err)
+			require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+			}))
+			_, err = stream.Recv()
+			require.NoError(t, err)
+			require.NoError(t, stream.CloseSend())
+			_, err = stream.Recv()
+			require.ErrorIs(t, err, io.EOF)
+
+			_, err = pb.NewTestServiceClient(conn).EmptyCall(ctx, &pb.Empty{})
+			require.NoError(t, err)
+
+			for side, spans := range map[string][]trace.ReadOnlySpan{
+				"client": clientSR.Ended(),
+				"server": serverSR.Ended(),
+			} {
+				require.Len(t, spans, 1, side)
+				assert.Equal(t, "grpc.testing.TestService/EmptyCall", spans[0].Name(), side)
+			}
+		})
+	}
+}
+
+func TestMetadataAttributes(t *testing.T) {
+	otelOpts := func(tp *trace.TracerProvider) []otelgrpc.Option {
+		return []otelgrpc.Option{
+			otelgrpc.WithTracerProvider(tp),
+			otelgrpc.WithMetadataKeys("x-tenant-id", "x-large"),
+			otelgrpc.WithMetadataValueLimit(16),
+		}
+	}
+	testCases := []struct {
+		name string
+		opts func(clientTP, serverTP *trace.TracerProvider) ([]grpc.DialOption, []grpc.ServerOption)
+	}{
+		{
+			name: "interceptors",
+			opts: func(clientTP, serverTP *trace.TracerProvider) ([]grpc.DialOption, []grpc.ServerOption) {
+				return []grpc.DialOption{
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor(otelOpts(clientTP)...)),
+				}, []grpc.ServerOption{
+					//nolint:staticcheck // Interceptors are deprecated and will be removed in the next release.
+					grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor(otelOpts(serverTP)...)),
+				}
+			},
+		},
+		{
+			name: "stats handlers",
+			opts: func(clientTP, serverTP *trace.TracerProvider) ([]grpc.DialOption, []grpc.ServerOption) {
+				return []grpc.DialOption{
+					grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelOpts(clientTP)...)),
+				}, []grpc.ServerOption{
+					grpc.StatsHandler(otelgrpc.NewServerHandler(otelOpts(serverTP)...)),
+				}
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientSR := tracetest.NewSpanRecorder()
+			clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+			serverSR := tracetest.NewSpanRecorder()
+			serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err, "failed to open port")
+			dialOpts, serverOpts := tc.opts(clientTP, serverTP)
+			client := newGrpcTest(t, listener, dialOpts, serverOpts)
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(),
+				"x-tenant-id", "tenant-1",
+				"x-large", strings.Repeat("x", 1024),
+				"x-other", "not recorded",
+			)
+			_, err = client.EmptyCall(ctx, &pb.Empty{})
+			require.NoError(t, err)
+
+			for side, spans := range map[string][]trace.ReadOnlySpan{
+				"client": clientSR.Ended(),
+				"server": serverSR.Ended(),
+			} {
+				require.Len(t, spans, 1, side)
+				attrs := attribute.NewSet(spans[0].Attributes()...)
+
+				v, ok := attrs.Value(otelgrpc.GRPCRequestMetadataKeyPrefix + "x-tenant-id")
+				require.True(t, ok, side)
+				assert.Equal(t, []string{"tenant-1"}, v.AsStringSlice(), side)
+
+				v, ok = attrs.Value(otelgrpc.GRPCRequestMetadataKeyPrefix + "x-large")
+				require.True(t, ok, side)
+				assert.Equal(t, []string{strings.Repeat("x", 13) + "..."}, v.AsStringSlice(), side)
+
+				_, ok = attrs.Value(otelgrpc.GRPCRequestMetadataKeyPrefix + "x-other")
+				assert.False(t, ok, side)
+			}
+		})
+	}
+}
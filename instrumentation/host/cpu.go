@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// cpuModeKey is the attribute key of the CPU mode of the system.cpu.time
+	// and system.cpu.utilization metrics.
+	cpuModeKey = attribute.Key("cpu.mode")
+	// cpuLogicalNumberKey is the attribute key of the logical CPU number of
+	// the system.cpu.time and system.cpu.utilization metrics. It is only
+	// recorded when the metrics are reported per CPU.
+	cpuLogicalNumberKey = attribute.Key("cpu.logical_number")
+)
+
+// cpuMode is the time spent by a CPU in a mode.
+type cpuMode struct {
+	mode  string
+	value float64
+}
+
+// cpuModes returns the time spent in each mode of t. This follows the
+// breakdown of the OpenTelemetry Collector's "hostmetrics" receiver. The
+// guest times are not reported as they are already accounted for in the user
+// and nice times.
+func cpuModes(t cpu.TimesStat) []cpuMode {
+	return []cpuMode{
+		{"user", t.User},
+		{"system", t.System},
+		{"idle", t.Idle},
+		{"interrupt", t.Irq},
+		{"nice", t.Nice},
+		{"softirq", t.Softirq},
+		{"steal", t.Steal},
+		{"iowait", t.Iowait},
+	}
+}
+
+// cpuTotal returns the total time of modes.
+func cpuTotal(modes []cpuMode) float64 {
+	var total float64
+	for _, m := range modes {
+		total += m.value
+	}
+	return total
+}
+
+// cpuStats observes the CPU time and utilization of the host, either
+// aggregated for all CPUs or per CPU.
+type cpuStats struct {
+	perCPU bool
+	times  func(context.Context, bool) ([]cpu.TimesStat, error)
+
+	// last holds the times of each CPU at the previous observation. The
+	// utilization is computed over the time elapsed since then.
+	last map[string][]cpuMode
+}
+
+func newCPUStats(perCPU bool, times func(context.Context, bool) ([]cpu.TimesStat, error)) *cpuStats {
+	if times == nil {
+		times = cpu.TimesWithContext
+	}
+	return &cpuStats{
+		perCPU: perCPU,
+		times:  times,
+		last:   make(map[string][]cpuMode),
+	}
+}
+
+// observe observes the CPU time spent in each mode with cpuTime, and the
+// fraction of time spent in each mode since the previous observation with
+// cpuUtilization. The utilization of the first observation is computed
+// over the time since the host started.
+func (c *cpuStats) observe(ctx context.Context, o metric.Observer, cpuTime, cpuUtilization metric.Float64Observable) error {
+	stats, err := c.times(ctx, c.perCPU)
+	if err != nil {
+		return err
+	}
+	if !c.perCPU && len(stats) != 1 {
+		return fmt.Errorf("host CPU usage: incorrect summary count")
+	}
+
+	for i, t := range stats {
+		modes := cpuModes(t)
+		deltas := modes
+		if last, ok := c.last[t.CPU]; ok {
+			deltas = make([]cpuMode, len(modes))
+			for j := range modes {
+				deltas[j] = cpuMode{modes[j].mode, modes[j].value - last[j].value}
+			}
+		}
+		c.last[t.CPU] = modes
+		total := cpuTotal(deltas)
+
+		for j, m := range modes {
+			attrs := c.attributes(i, t.CPU, m.mode)
+			o.ObserveFloat64(cpuTime, m.value, attrs)
+			if total > 0 {
+				o.ObserveFloat64(cpuUtilization, deltas[j].value/total, attrs)
+			}
+		}
+	}
+	return nil
+}
+
+// attributes returns the attributes of the measurements of mode for the
+// CPU named name, the i-th of the CPUs.
+func (c *cpuStats) attributes(i int, name, mode string) metric.MeasurementOption {
+	if !c.perCPU {
+		return metric.WithAttributes(cpuModeKey.String(mode))
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(name, "cpu"))
+	if err != nil {
+		n = i
+	}
+	return metric.WithAttributes(cpuModeKey.String(mode), cpuLogicalNumberKey.Int(n))
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// fakeCPUTimes returns the CPU times of two CPUs, or of their aggregate.
+// Each call adds 10s of user time and 30s of idle time to each CPU.
+type fakeCPUTimes struct {
+	calls int
+}
+
+func (f *fakeCPUTimes) times(_ context.Context, perCPU bool) ([]cpu.TimesStat, error) {
+	f.calls++
+	elapsed := float64(f.calls - 1)
+	percpu := []cpu.TimesStat{
+		{CPU: "cpu0", User: 10 + 10*elapsed, System: 5, Idle: 80 + 30*elapsed, Iowait: 2, Irq: 1, Softirq: 1, Nice: 1},
+		{CPU: "cpu1", User: 20 + 10*elapsed, System: 10, Idle: 60 + 30*elapsed, Iowait: 4, Steal: 6},
+	}
+	if perCPU {
+		return percpu, nil
+	}
+	total := cpu.TimesStat{CPU: "cpu-total"}
+	for _, t := range percpu {
+		total.User += t.User
+		total.System += t.System
+		total.Idle += t.Idle
+		total.Iowait += t.Iowait
+		total.Irq += t.Irq
+		total.Softirq += t.Softirq
+		total.Nice += t.Nice
+		total.Steal += t.Steal
+	}
+	return []cpu.TimesStat{total}, nil
+}
+
+func cpuModeSet(mode string, cpu ...int) attribute.Distinct {
+	attrs := []attribute.KeyValue{cpuModeKey.String(mode)}
+	for _, n := range cpu {
+		attrs = append(attrs, cpuLogicalNumberKey.Int(n))
+	}
+	set := attribute.NewSet(attrs...)
+	return set.Equivalent()
+}
+
+func TestHostCPUMetrics(t *testing.T) {
+	fake := &fakeCPUTimes{}
+	m := &fakeMeter{}
+	h := &host{
+		meter:    m,
+		config:   newConfig(),
+		proc:     fakeProcess{times: &cpu.TimesStat{}, mem: &process.MemoryInfoStat{}},
+		cpuTimes: fake.times,
+	}
+	require.NoError(t, h.register())
+	assert.Contains(t, m.instruments, "system.cpu.time")
+	assert.Contains(t, m.instruments, "system.cpu.utilization")
+
+	obs, err := m.collect(context.Background())
+	require.NoError(t, err)
+
+	want := map[attribute.Distinct]float64{
+		cpuModeSet("user"):      30,
+		cpuModeSet("system"):    15,
+		cpuModeSet("idle"):      140,
+		cpuModeSet("interrupt"): 1,
+		cpuModeSet("nice"):      1,
+		cpuModeSet("softirq"):   1,
+		cpuModeSet("steal"):     6,
+		cpuModeSet("iowait"):    6,
+	}
+	assert.Equal(t, want, obs["system.cpu.time"])
+	// The first utilization is computed over the time since the host started.
+	assert.InDelta(t, 30.0/200.0, obs["system.cpu.utilization"][cpuModeSet("user")], 1e-9)
+
+	obs, err = m.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, obs["system.cpu.time"][cpuModeSet("user")])
+	assert.Equal(t, 200.0, obs["system.cpu.time"][cpuModeSet("idle")])
+	// Then over the time since the previous collection: 20s of user and 60s
+	// of idle time.
+	util := obs["system.cpu.utilization"]
+	assert.InDelta(t, 0.25, util[cpuModeSet("user")], 1e-9)
+	assert.InDelta(t, 0.75, util[cpuModeSet("idle")], 1e-9)
+	assert.InDelta(t, 0, util[cpuModeSet("system")], 1e-9)
+}
+
+func TestHostCPUMetricsPerCPU(t *testing.T) {
+	fake := &fakeCPUTimes{}
+	m := &fakeMeter{}
+	h := &host{
+		meter:    m,
+		config:   newConfig(WithPerCPU()),
+		proc:     fakeProcess{times: &cpu.TimesStat{}, mem: &process.MemoryInfoStat{}},
+		cpuTimes: fake.times,
+	}
+	require.NoError(t, h.register())
+
+	obs, err := m.collect(context.Background())
+	require.NoError(t, err)
+
+	cpuTime := obs["system.cpu.time"]
+	assert.Len(t, cpuTime, 16, "8 modes for each of the 2 CPUs")
+	assert.Equal(t, 10.0, cpuTime[cpuModeSet("user", 0)])
+	assert.Equal(t, 20.0, cpuTime[cpuModeSet("user", 1)])
+	assert.Equal(t, 6.0, cpuTime[cpuModeSet("steal", 1)])
+	assert.Equal(t, 0.0, cpuTime[cpuModeSet("steal", 0)])
+	assert.NotContains(t, cpuTime, cpuModeSet("user"), "aggregate should not be reported")
+
+	obs, err = m.collect(context.Background())
+	require.NoError(t, err)
+	util := obs["system.cpu.utilization"]
+	assert.InDelta(t, 0.25, util[cpuModeSet("user", 0)], 1e-9)
+	assert.InDelta(t, 0.25, util[cpuModeSet("user", 1)], 1e-9)
+}
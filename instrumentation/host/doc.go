@@ -14,11 +14,22 @@
 //
 // ----------------------------------------------------------------------
 //
-//	process.cpu.time           state=user|system
-//	system.cpu.time            state=user|system|other|idle
-//	system.memory.usage        state=used|available
-//	system.memory.utilization  state=used|available
-//	system.network.io          direction=transmit|receive
+//	process.cpu.time               state=user|system
+//	process.memory.usage
+//	process.open_file_descriptors  (Linux only)
+//	system.cpu.time                cpu.mode=user|system|idle|interrupt|nice|softirq|steal|iowait
+//	system.cpu.utilization         cpu.mode=user|system|idle|interrupt|nice|softirq|steal|iowait
+//	system.memory.usage            state=used|available
+//	system.memory.utilization      state=used|available
+//	system.network.io              direction=transmit|receive
+//	system.hardware.temperature    sensor (with WithSensors)
+//
+// The system.* metrics are not reported when the WithOnlyProcessMetrics
+// option is used. The system.cpu.* metrics are aggregated for all CPUs unless
+// the WithPerCPU option is used, in which case they are reported for each CPU
+// with the additional cpu.logical_number attribute. The
+// system.hardware.temperature metric is only reported when the WithSensors
+// option is used, and on platforms where the sensors can be read.
 //
 // See https://github.com/open-telemetry/oteps/blob/main/text/0119-standard-system-metrics.md
 // for the definition of these metric instruments.
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+// fdCountSupported is true on platforms where gopsutil is able to report the
+// number of file descriptors opened by a process.
+const fdCountSupported = true
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/shirou/gopsutil/v3/cpu"
+	pshost "github.com/shirou/gopsutil/v3/host"
 	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
@@ -26,6 +27,21 @@ const ScopeName = "go.opentelemetry.io/contrib/instrumentation/host"
 type host struct {
 	config config
 	meter  metric.Meter
+	proc   processStats
+	// cpuTimes returns the CPU times of the host. If nil,
+	// cpu.TimesWithContext is used.
+	cpuTimes func(ctx context.Context, perCPU bool) ([]cpu.TimesStat, error)
+	// temperatures returns the temperatures of the sensors of the host. If
+	// nil, host.SensorsTemperaturesWithContext of gopsutil is used.
+	temperatures func(ctx context.Context) ([]pshost.TemperatureStat, error)
+}
+
+// processStats provides the resource usage statistics of a single process.
+// It is implemented by *process.Process.
+type processStats interface {
+	TimesWithContext(context.Context) (*cpu.TimesStat, error)
+	MemoryInfoWithContext(context.Context) (*process.MemoryInfoStat, error)
+	NumFDsWithContext(context.Context) (int32, error)
 }
 
 // config contains optional settings for reporting host metrics.
@@ -33,6 +49,21 @@ type config struct {
 	// MeterProvider sets the metric.MeterProvider.  If nil, the global
 	// Provider will be used.
 	MeterProvider metric.MeterProvider
+
+	// ProcessOnly restricts reporting to the metrics of the current
+	// process.  System-wide metrics are not reported when true.
+	ProcessOnly bool
+
+	// PerCPU reports the CPU metrics of the host for each CPU instead of
+	// aggregated for all CPUs.
+	PerCPU bool
+
+	// Sensors reports the temperatures of the hardware sensors of the host.
+	Sensors bool
+
+	// ErrorHandler handles the errors of the collection of the metrics. If
+	// nil, the global error handler is used.
+	ErrorHandler func(error)
 }
 
 // Option supports configuring optional settings for host metrics.
@@ -55,14 +86,81 @@ func (o metricProviderOption) apply(c *config) {
 	}
 }
 
+// WithOnlyProcessMetrics restricts reporting to the metrics of the current
+// process (process.cpu.time, process.memory.usage, and
+// process.open_file_descriptors). System-wide metrics are not reported when
+// this option is used.
+func WithOnlyProcessMetrics() Option {
+	return processOnlyOption{}
+}
+
+type processOnlyOption struct{}
+
+func (processOnlyOption) apply(c *config) {
+	c.ProcessOnly = true
+}
+
+// WithPerCPU reports the system.cpu.time and system.cpu.utilization
+// metrics for each logical CPU of the host, identified by the
+// cpu.logical_number attribute. By default, these metrics are aggregated for
+// all CPUs.
+func WithPerCPU() Option {
+	return perCPUOption{}
+}
+
+type perCPUOption struct{}
+
+func (perCPUOption) apply(c *config) {
+	c.PerCPU = true
+}
+
+// WithSensors reports the temperature of each hardware sensor of the host,
+// identified by the sensor attribute, with the system.hardware.temperature
+// metric. The sensors are only available on some platforms, and may require
+// additional permissions. The temperatures of the sensors that can be read
+// are reported, and the read errors are passed to the error handler (see
+// WithErrorHandler).
+//
+// This option has no effect if WithOnlyProcessMetrics is used.
+func WithSensors() Option {
+	return sensorsOption{}
+}
+
+type sensorsOption struct{}
+
+func (sensorsOption) apply(c *config) {
+	c.Sensors = true
+}
+
+// WithErrorHandler sets the handler of the errors occurring while the
+// metrics are collected, for example when a file of /proc cannot be read in a
+// container. The metrics that could be collected are still reported. If this
+// option is not used, the errors are passed to the global error handler (see
+// otel.SetErrorHandler).
+func WithErrorHandler(handler func(error)) Option {
+	return errorHandlerOption(handler)
+}
+
+type errorHandlerOption func(error)
+
+func (o errorHandlerOption) apply(c *config) {
+	if o != nil {
+		c.ErrorHandler = o
+	}
+}
+
 // Attribute sets.
 var (
 	// Attribute sets for CPU time measurements.
 
 	AttributeCPUTimeUser   = attribute.NewSet(attribute.String("state", "user"))
 	AttributeCPUTimeSystem = attribute.NewSet(attribute.String("state", "system"))
-	AttributeCPUTimeOther  = attribute.NewSet(attribute.String("state", "other"))
-	AttributeCPUTimeIdle   = attribute.NewSet(attribute.String("state", "idle"))
+	// Deprecated: system.cpu.time is attributed by the cpu.mode attribute
+	// and this attribute set is no longer used.
+	AttributeCPUTimeOther = attribute.NewSet(attribute.String("state", "other"))
+	// Deprecated: system.cpu.time is attributed by the cpu.mode attribute
+	// and this attribute set is no longer used.
+	AttributeCPUTimeIdle = attribute.NewSet(attribute.String("state", "idle"))
 
 	// Attribute sets used for Memory measurements.
 
@@ -92,37 +190,58 @@ func Start(opts ...Option) error {
 	if c.MeterProvider == nil {
 		c.MeterProvider = otel.GetMeterProvider()
 	}
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return fmt.Errorf("could not find this process: %w", err)
+	}
 	h := &host{
 		meter: c.MeterProvider.Meter(
 			ScopeName,
 			metric.WithInstrumentationVersion(Version()),
 		),
 		config: c,
+		proc:   proc,
 	}
 	return h.register()
 }
 
+// handleErr passes err to the error handler of the configuration.
+func (h *host) handleErr(err error) {
+	if h.config.ErrorHandler != nil {
+		h.config.ErrorHandler(err)
+		return
+	}
+	otel.Handle(err)
+}
+
 func (h *host) register() error {
+	if err := h.registerProcess(); err != nil {
+		return err
+	}
+	if h.config.ProcessOnly {
+		return nil
+	}
+	if err := h.registerHost(); err != nil {
+		return err
+	}
+	if h.config.Sensors {
+		return h.registerSensors()
+	}
+	return nil
+}
+
+func (h *host) registerProcess() error {
 	var (
 		err error
 
-		processCPUTime metric.Float64ObservableCounter
-		hostCPUTime    metric.Float64ObservableCounter
-
-		hostMemoryUsage       metric.Int64ObservableGauge
-		hostMemoryUtilization metric.Float64ObservableGauge
-
-		networkIOUsage metric.Int64ObservableCounter
+		processCPUTime     metric.Float64ObservableCounter
+		processMemoryUsage metric.Int64ObservableUpDownCounter
+		processOpenFDs     metric.Int64ObservableUpDownCounter
 
 		// lock prevents a race between batch observer and instrument registration.
 		lock sync.Mutex
 	)
 
-	proc, err := process.NewProcess(int32(os.Getpid()))
-	if err != nil {
-		return fmt.Errorf("could not find this process: %w", err)
-	}
-
 	lock.Lock()
 	defer lock.Unlock()
 
@@ -139,16 +258,115 @@ func (h *host) register() error {
 		return err
 	}
 
+	if processMemoryUsage, err = h.meter.Int64ObservableUpDownCounter(
+		"process.memory.usage",
+		metric.WithUnit("By"),
+		metric.WithDescription(
+			"The amount of physical memory in use by this process (resident set size)",
+		),
+	); err != nil {
+		return err
+	}
+
+	instruments := []metric.Observable{processCPUTime, processMemoryUsage}
+
+	if fdCountSupported {
+		if processOpenFDs, err = h.meter.Int64ObservableUpDownCounter(
+			"process.open_file_descriptors",
+			metric.WithUnit("{count}"),
+			metric.WithDescription(
+				"Number of file descriptors in use by this process",
+			),
+		); err != nil {
+			return err
+		}
+		instruments = append(instruments, processOpenFDs)
+	}
+
+	_, err = h.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			// This follows the OpenTelemetry Collector's "hostmetrics"
+			// receiver/hostmetricsreceiver/internal/scraper/processscraper
+			// measures User and System IOwait time.
+			// TODO: the Collector has per-OS compilation modules to support
+			// specific metrics that are not universal.
+			//
+			// The errors are handled for each metric so a failure to read
+			// one of them does not prevent the others to be reported.
+			if processTimes, err := h.proc.TimesWithContext(ctx); err != nil {
+				h.handleErr(fmt.Errorf("process.cpu.time: %w", err))
+			} else {
+				opt := metric.WithAttributeSet(AttributeCPUTimeUser)
+				o.ObserveFloat64(processCPUTime, processTimes.User, opt)
+				opt = metric.WithAttributeSet(AttributeCPUTimeSystem)
+				o.ObserveFloat64(processCPUTime, processTimes.System, opt)
+			}
+
+			if memInfo, err := h.proc.MemoryInfoWithContext(ctx); err != nil {
+				h.handleErr(fmt.Errorf("process.memory.usage: %w", err))
+			} else {
+				o.ObserveInt64(processMemoryUsage, int64(memInfo.RSS))
+			}
+
+			if fdCountSupported {
+				if fds, err := h.proc.NumFDsWithContext(ctx); err != nil {
+					h.handleErr(fmt.Errorf("process.open_file_descriptors: %w", err))
+				} else {
+					o.ObserveInt64(processOpenFDs, int64(fds))
+				}
+			}
+
+			return nil
+		},
+		instruments...,
+	)
+	return err
+}
+
+func (h *host) registerHost() error {
+	var (
+		err error
+
+		hostCPUTime        metric.Float64ObservableCounter
+		hostCPUUtilization metric.Float64ObservableGauge
+
+		hostMemoryUsage       metric.Int64ObservableGauge
+		hostMemoryUtilization metric.Float64ObservableGauge
+
+		networkIOUsage metric.Int64ObservableCounter
+
+		// lock prevents a race between batch observer and instrument registration.
+		lock sync.Mutex
+	)
+
+	lock.Lock()
+	defer lock.Unlock()
+
 	if hostCPUTime, err = h.meter.Float64ObservableCounter(
 		"system.cpu.time",
 		metric.WithUnit("s"),
 		metric.WithDescription(
-			"Accumulated CPU time spent by this host attributed by state (User, System, Other, Idle)",
+			"Accumulated CPU time spent by this host attributed by mode (User, System, Idle, ...)",
 		),
 	); err != nil {
 		return err
 	}
 
+	if hostCPUUtilization, err = h.meter.Float64ObservableGauge(
+		"system.cpu.utilization",
+		metric.WithUnit("1"),
+		metric.WithDescription(
+			"Fraction of CPU time spent by this host in each mode since the last measurement",
+		),
+	); err != nil {
+		return err
+	}
+
+	cpuStats := newCPUStats(h.config.PerCPU, h.cpuTimes)
+
 	if hostMemoryUsage, err = h.meter.Int64ObservableGauge(
 		"system.memory.usage",
 		metric.WithUnit("By"),
@@ -184,92 +402,51 @@ func (h *host) register() error {
 			lock.Lock()
 			defer lock.Unlock()
 
-			// This follows the OpenTelemetry Collector's "hostmetrics"
-			// receiver/hostmetricsreceiver/internal/scraper/processscraper
-			// measures User and System IOwait time.
-			// TODO: the Collector has per-OS compilation modules to support
-			// specific metrics that are not universal.
-			processTimes, err := proc.TimesWithContext(ctx)
-			if err != nil {
-				return err
-			}
-
-			hostTimeSlice, err := cpu.TimesWithContext(ctx, false)
-			if err != nil {
-				return err
-			}
-			if len(hostTimeSlice) != 1 {
-				return fmt.Errorf("host CPU usage: incorrect summary count")
+			// The errors are handled for each metric so a failure to read
+			// one of them does not prevent the others to be reported.
+			if err := cpuStats.observe(ctx, o, hostCPUTime, hostCPUUtilization); err != nil {
+				h.handleErr(fmt.Errorf("system.cpu.time: %w", err))
 			}
 
-			vmStats, err := mem.VirtualMemoryWithContext(ctx)
-			if err != nil {
-				return err
+			if vmStats, err := mem.VirtualMemoryWithContext(ctx); err != nil {
+				h.handleErr(fmt.Errorf("system.memory.usage: %w", err))
+			} else {
+				// Host memory usage
+				opt := metric.WithAttributeSet(AttributeMemoryUsed)
+				o.ObserveInt64(hostMemoryUsage, int64(vmStats.Used), opt)
+				opt = metric.WithAttributeSet(AttributeMemoryAvailable)
+				o.ObserveInt64(hostMemoryUsage, int64(vmStats.Available), opt)
+
+				// Host memory utilization
+				opt = metric.WithAttributeSet(AttributeMemoryUsed)
+				o.ObserveFloat64(hostMemoryUtilization, float64(vmStats.Used)/float64(vmStats.Total), opt)
+				opt = metric.WithAttributeSet(AttributeMemoryAvailable)
+				o.ObserveFloat64(hostMemoryUtilization, float64(vmStats.Available)/float64(vmStats.Total), opt)
 			}
 
 			ioStats, err := net.IOCountersWithContext(ctx, false)
-			if err != nil {
-				return err
+			switch {
+			case err != nil:
+				h.handleErr(fmt.Errorf("system.network.io: %w", err))
+			case len(ioStats) != 1:
+				h.handleErr(fmt.Errorf("host network usage: incorrect summary count"))
+			default:
+				// Host network usage
+				//
+				// TODO: These can be broken down by network
+				// interface. As with the per-CPU measurements of
+				// WithPerCPU, this would need to be optional as it
+				// multiplies the number of reported series.
+				opt := metric.WithAttributeSet(AttributeNetworkTransmit)
+				o.ObserveInt64(networkIOUsage, int64(ioStats[0].BytesSent), opt)
+				opt = metric.WithAttributeSet(AttributeNetworkReceive)
+				o.ObserveInt64(networkIOUsage, int64(ioStats[0].BytesRecv), opt)
 			}
-			if len(ioStats) != 1 {
-				return fmt.Errorf("host network usage: incorrect summary count")
-			}
-
-			hostTime := hostTimeSlice[0]
-			opt := metric.WithAttributeSet(AttributeCPUTimeUser)
-			o.ObserveFloat64(processCPUTime, processTimes.User, opt)
-			o.ObserveFloat64(hostCPUTime, hostTime.User, opt)
-
-			opt = metric.WithAttributeSet(AttributeCPUTimeSystem)
-			o.ObserveFloat64(processCPUTime, processTimes.System, opt)
-			o.ObserveFloat64(hostCPUTime, hostTime.System, opt)
-
-			// TODO(#244): "other" is a placeholder for actually dealing
-			// with these states.  Do users actually want this
-			// (unconditionally)?  How should we handle "iowait"
-			// if not all systems expose it?  Should we break
-			// these down by CPU?  If so, are users going to want
-			// to aggregate in-process?  See:
-			// https://github.com/open-telemetry/opentelemetry-go-contrib/issues/244
-			other := hostTime.Nice +
-				hostTime.Iowait +
-				hostTime.Irq +
-				hostTime.Softirq +
-				hostTime.Steal +
-				hostTime.Guest +
-				hostTime.GuestNice
-
-			opt = metric.WithAttributeSet(AttributeCPUTimeOther)
-			o.ObserveFloat64(hostCPUTime, other, opt)
-			opt = metric.WithAttributeSet(AttributeCPUTimeIdle)
-			o.ObserveFloat64(hostCPUTime, hostTime.Idle, opt)
-
-			// Host memory usage
-			opt = metric.WithAttributeSet(AttributeMemoryUsed)
-			o.ObserveInt64(hostMemoryUsage, int64(vmStats.Used), opt)
-			opt = metric.WithAttributeSet(AttributeMemoryAvailable)
-			o.ObserveInt64(hostMemoryUsage, int64(vmStats.Available), opt)
-
-			// Host memory utilization
-			opt = metric.WithAttributeSet(AttributeMemoryUsed)
-			o.ObserveFloat64(hostMemoryUtilization, float64(vmStats.Used)/float64(vmStats.Total), opt)
-			opt = metric.WithAttributeSet(AttributeMemoryAvailable)
-			o.ObserveFloat64(hostMemoryUtilization, float64(vmStats.Available)/float64(vmStats.Total), opt)
-
-			// Host network usage
-			//
-			// TODO: These can be broken down by network
-			// interface, with similar questions to those posed
-			// about per-CPU measurements above.
-			opt = metric.WithAttributeSet(AttributeNetworkTransmit)
-			o.ObserveInt64(networkIOUsage, int64(ioStats[0].BytesSent), opt)
-			opt = metric.WithAttributeSet(AttributeNetworkReceive)
-			o.ObserveInt64(networkIOUsage, int64(ioStats[0].BytesRecv), opt)
 
 			return nil
 		},
-		processCPUTime,
 		hostCPUTime,
+		hostCPUUtilization,
 		hostMemoryUsage,
 		hostMemoryUtilization,
 		networkIOUsage,
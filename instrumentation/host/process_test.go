@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+type fakeProcess struct {
+	times *cpu.TimesStat
+	mem   *process.MemoryInfoStat
+	fds   int32
+
+	// timesErr is returned by TimesWithContext if not nil.
+	timesErr error
+}
+
+func (p fakeProcess) TimesWithContext(context.Context) (*cpu.TimesStat, error) {
+	if p.timesErr != nil {
+		return nil, p.timesErr
+	}
+	return p.times, nil
+}
+
+func (p fakeProcess) MemoryInfoWithContext(context.Context) (*process.MemoryInfoStat, error) {
+	return p.mem, nil
+}
+
+func (p fakeProcess) NumFDsWithContext(context.Context) (int32, error) {
+	return p.fds, nil
+}
+
+// fakeMeter records the instruments created and the callbacks registered
+// with it so they can be invoked directly by a test.
+type fakeMeter struct {
+	noop.Meter
+
+	instruments []string
+	callbacks   []metric.Callback
+}
+
+type fakeFloat64Counter struct {
+	noop.Float64ObservableCounter
+	name string
+}
+
+type fakeFloat64Gauge struct {
+	noop.Float64ObservableGauge
+	name string
+}
+
+type fakeInt64Counter struct {
+	noop.Int64ObservableCounter
+	name string
+}
+
+type fakeInt64UpDownCounter struct {
+	noop.Int64ObservableUpDownCounter
+	name string
+}
+
+type fakeInt64Gauge struct {
+	noop.Int64ObservableGauge
+	name string
+}
+
+func (m *fakeMeter) Float64ObservableCounter(name string, _ ...metric.Float64ObservableCounterOption) (metric.Float64ObservableCounter, error) {
+	m.instruments = append(m.instruments, name)
+	return fakeFloat64Counter{name: name}, nil
+}
+
+func (m *fakeMeter) Float64ObservableGauge(name string, _ ...metric.Float64ObservableGaugeOption) (metric.Float64ObservableGauge, error) {
+	m.instruments = append(m.instruments, name)
+	return fakeFloat64Gauge{name: name}, nil
+}
+
+func (m *fakeMeter) Int64ObservableCounter(name string, _ ...metric.Int64ObservableCounterOption) (metric.Int64ObservableCounter, error) {
+	m.instruments = append(m.instruments, name)
+	return fakeInt64Counter{name: name}, nil
+}
+
+func (m *fakeMeter) Int64ObservableUpDownCounter(name string, _ ...metric.Int64ObservableUpDownCounterOption) (metric.Int64ObservableUpDownCounter, error) {
+	m.instruments = append(m.instruments, name)
+	return fakeInt64UpDownCounter{name: name}, nil
+}
+
+func (m *fakeMeter) Int64ObservableGauge(name string, _ ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	m.instruments = append(m.instruments, name)
+	return fakeInt64Gauge{name: name}, nil
+}
+
+func (m *fakeMeter) RegisterCallback(f metric.Callback, _ ...metric.Observable) (metric.Registration, error) {
+	m.callbacks = append(m.callbacks, f)
+	return noop.Registration{}, nil
+}
+
+// collect invokes all registered callbacks and returns the observations
+// keyed by instrument name and attribute set.
+func (m *fakeMeter) collect(ctx context.Context) (observations, error) {
+	o := &fakeObserver{obs: make(observations)}
+	for _, f := range m.callbacks {
+		if err := f(ctx, o); err != nil {
+			return nil, err
+		}
+	}
+	return o.obs, nil
+}
+
+type observations map[string]map[attribute.Distinct]float64
+
+type fakeObserver struct {
+	noop.Observer
+
+	obs observations
+}
+
+func instrumentName(o metric.Observable) string {
+	switch i := o.(type) {
+	case fakeFloat64Counter:
+		return i.name
+	case fakeFloat64Gauge:
+		return i.name
+	case fakeInt64Counter:
+		return i.name
+	case fakeInt64UpDownCounter:
+		return i.name
+	case fakeInt64Gauge:
+		return i.name
+	}
+	return ""
+}
+
+func (o *fakeObserver) record(inst metric.Observable, v float64, opts []metric.ObserveOption) {
+	name := instrumentName(inst)
+	if o.obs[name] == nil {
+		o.obs[name] = make(map[attribute.Distinct]float64)
+	}
+	set := metric.NewObserveConfig(opts).Attributes()
+	o.obs[name][set.Equivalent()] = v
+}
+
+func (o *fakeObserver) ObserveFloat64(inst metric.Float64Observable, v float64, opts ...metric.ObserveOption) {
+	o.record(inst, v, opts)
+}
+
+func (o *fakeObserver) ObserveInt64(inst metric.Int64Observable, v int64, opts ...metric.ObserveOption) {
+	o.record(inst, float64(v), opts)
+}
+
+func TestProcessMetrics(t *testing.T) {
+	m := &fakeMeter{}
+	h := &host{
+		meter:  m,
+		config: newConfig(WithOnlyProcessMetrics()),
+		proc: fakeProcess{
+			times: &cpu.TimesStat{User: 1.5, System: 0.5},
+			mem:   &process.MemoryInfoStat{RSS: 4096},
+			fds:   12,
+		},
+	}
+	require.NoError(t, h.register())
+
+	want := []string{"process.cpu.time", "process.memory.usage"}
+	if fdCountSupported {
+		want = append(want, "process.open_file_descriptors")
+	}
+	assert.Equal(t, want, m.instruments, "only process metrics should be registered")
+
+	obs, err := m.collect(context.Background())
+	require.NoError(t, err)
+
+	cpuTime := obs["process.cpu.time"]
+	assert.Equal(t, 1.5, cpuTime[AttributeCPUTimeUser.Equivalent()])
+	assert.Equal(t, 0.5, cpuTime[AttributeCPUTimeSystem.Equivalent()])
+
+	emptyKey := attribute.EmptySet().Equivalent()
+	assert.Equal(t, float64(4096), obs["process.memory.usage"][emptyKey])
+
+	if fdCountSupported {
+		assert.Equal(t, float64(12), obs["process.open_file_descriptors"][emptyKey])
+	} else {
+		assert.NotContains(t, obs, "process.open_file_descriptors")
+	}
+}
+
+func TestProcessAndHostMetrics(t *testing.T) {
+	m := &fakeMeter{}
+	h := &host{
+		meter:  m,
+		config: newConfig(),
+		proc:   fakeProcess{},
+	}
+	require.NoError(t, h.register())
+
+	assert.Contains(t, m.instruments, "process.cpu.time")
+	assert.Contains(t, m.instruments, "system.cpu.time")
+	assert.Contains(t, m.instruments, "system.memory.usage")
+	assert.Contains(t, m.instruments, "system.network.io")
+}
+
+func TestProcessMetricsError(t *testing.T) {
+	errTimes := errors.New("cannot read /proc/self/stat")
+	var handled []error
+	m := &fakeMeter{}
+	h := &host{
+		meter: m,
+		config: newConfig(
+			WithOnlyProcessMetrics(),
+			WithErrorHandler(func(err error) { handled = append(handled, err) }),
+		),
+		proc: fakeProcess{
+			mem:      &process.MemoryInfoStat{RSS: 4096},
+			fds:      12,
+			timesErr: errTimes,
+		},
+	}
+	require.NoError(t, h.register())
+
+	obs, err := m.collect(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, handled, 1)
+	assert.ErrorIs(t, handled[0], errTimes)
+
+	// The metrics that could be read are still reported.
+	assert.NotContains(t, obs, "process.cpu.time")
+	emptyKey := attribute.EmptySet().Equivalent()
+	assert.Equal(t, float64(4096), obs["process.memory.usage"][emptyKey])
+	if fdCountSupported {
+		assert.Equal(t, float64(12), obs["process.open_file_descriptors"][emptyKey])
+	}
+}
+
+func TestHostMetricsError(t *testing.T) {
+	errCPU := errors.New("cannot read /proc/stat")
+	var handled []error
+	m := &fakeMeter{}
+	h := &host{
+		meter: m,
+		config: newConfig(WithErrorHandler(func(err error) {
+			handled = append(handled, err)
+		})),
+		proc: fakeProcess{
+			times: &cpu.TimesStat{},
+			mem:   &process.MemoryInfoStat{},
+		},
+		cpuTimes: func(context.Context, bool) ([]cpu.TimesStat, error) {
+			return nil, errCPU
+		},
+	}
+	require.NoError(t, h.register())
+
+	obs, err := m.collect(context.Background())
+	require.NoError(t, err)
+
+	require.NotEmpty(t, handled)
+	assert.ErrorIs(t, handled[0], errCPU)
+
+	assert.NotContains(t, obs, "system.cpu.time")
+	assert.NotContains(t, obs, "system.cpu.utilization")
+	assert.Contains(t, obs, "process.cpu.time")
+	assert.Contains(t, obs, "system.memory.usage")
+	assert.Contains(t, obs, "system.memory.utilization")
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host // import "go.opentelemetry.io/contrib/instrumentation/host"
+
+import (
+	"context"
+	"fmt"
+
+	pshost "github.com/shirou/gopsutil/v3/host"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// sensorKey is the attribute key of the sensor name of the
+// system.hardware.temperature metric.
+const sensorKey = attribute.Key("sensor")
+
+// registerSensors registers the system.hardware.temperature metric, reporting
+// the temperature of each sensor of the host.
+func (h *host) registerSensors() error {
+	temperatures := h.temperatures
+	if temperatures == nil {
+		temperatures = pshost.SensorsTemperaturesWithContext
+	}
+
+	temperature, err := h.meter.Float64ObservableGauge(
+		"system.hardware.temperature",
+		metric.WithUnit("Cel"),
+		metric.WithDescription("Temperature of the hardware sensors of this host attributed by sensor"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			// The sensors are not available on all platforms, or may not
+			// be readable with the permissions of the process. The error
+			// is handled and the temperatures read, if any, are reported.
+			stats, err := temperatures(ctx)
+			if err != nil {
+				h.handleErr(fmt.Errorf("system.hardware.temperature: %w", err))
+			}
+			for _, s := range stats {
+				o.ObserveFloat64(temperature, s.Temperature, metric.WithAttributes(sensorKey.String(s.SensorKey)))
+			}
+			return nil
+		},
+		temperature,
+	)
+	return err
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	pshost "github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func sensorSet(name string) attribute.Distinct {
+	set := attribute.NewSet(sensorKey.String(name))
+	return set.Equivalent()
+}
+
+func TestHostSensorMetrics(t *testing.T) {
+	m := &fakeMeter{}
+	h := &host{
+		meter:  m,
+		config: newConfig(WithSensors()),
+		proc:   fakeProcess{times: &cpu.TimesStat{}, mem: &process.MemoryInfoStat{}},
+		temperatures: func(context.Context) ([]pshost.TemperatureStat, error) {
+			return []pshost.TemperatureStat{
+				{SensorKey: "coretemp_core_0", Temperature: 45.5},
+				{SensorKey: "nvme_composite", Temperature: 38},
+			}, nil
+		},
+	}
+	require.NoError(t, h.register())
+
+	obs, err := m.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[attribute.Distinct]float64{
+		sensorSet("coretemp_core_0"): 45.5,
+		sensorSet("nvme_composite"):  38,
+	}, obs["system.hardware.temperature"])
+}
+
+func TestHostSensorMetricsUnavailable(t *testing.T) {
+	errSensors := errors.New("permission denied")
+	var handled []error
+	m := &fakeMeter{}
+	h := &host{
+		meter: m,
+		config: newConfig(
+			WithSensors(),
+			WithErrorHandler(func(err error) { handled = append(handled, err) }),
+		),
+		proc: fakeProcess{times: &cpu.TimesStat{}, mem: &process.MemoryInfoStat{}},
+		temperatures: func(context.Context) ([]pshost.TemperatureStat, error) {
+			// Sensors that could be read are returned along with the error.
+			return []pshost.TemperatureStat{
+				{SensorKey: "coretemp_core_0", Temperature: 45.5},
+			}, errSensors
+		},
+	}
+	require.NoError(t, h.register())
+
+	obs, err := m.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[attribute.Distinct]float64{
+		sensorSet("coretemp_core_0"): 45.5,
+	}, obs["system.hardware.temperature"])
+
+	require.Len(t, handled, 1)
+	assert.ErrorIs(t, handled[0], errSensors)
+}
+
+func TestHostSensorMetricsDisabled(t *testing.T) {
+	m := &fakeMeter{}
+	h := &host{
+		meter:  m,
+		config: newConfig(),
+		proc:   fakeProcess{times: &cpu.TimesStat{}, mem: &process.MemoryInfoStat{}},
+		temperatures: func(context.Context) ([]pshost.TemperatureStat, error) {
+			t.Error("sensors read without WithSensors")
+			return nil, nil
+		},
+	}
+	require.NoError(t, h.register())
+	assert.NotContains(t, m.instruments, "system.hardware.temperature")
+
+	_, err := m.collect(context.Background())
+	require.NoError(t, err)
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// metricAttrsPool holds the slices used to build the attributes of the
+// metrics recorded for each request. The attribute set of the measurements is
+// computed from the slice before it is returned to the pool, so the slices are
+// never retained by the metric SDK.
+var metricAttrsPool = sync.Pool{
+	New: func() interface{} {
+		// Route, method, scheme, host name, host port, protocol name and
+		// version, and status code.
+		s := make([]attribute.KeyValue, 0, 8)
+		return &s
+	},
+}
+
+// getMetricAttrs returns an empty slice from metricAttrsPool.
+func getMetricAttrs() *[]attribute.KeyValue {
+	return metricAttrsPool.Get().(*[]attribute.KeyValue)
+}
+
+// putMetricAttrs returns s to metricAttrsPool. The attributes are cleared so
+// the pool does not keep their values alive.
+func putMetricAttrs(s *[]attribute.KeyValue) {
+	clear(*s)
+	*s = (*s)[:0]
+	metricAttrsPool.Put(s)
+}
@@ -16,6 +16,15 @@ import (
 // the global propagator, the DefaultClient might still be using the old one.
 var DefaultClient = &http.Client{Transport: NewTransport(http.DefaultTransport)}
 
+// NewClient returns a new http.Client that wraps each request it sends in a
+// client span. Its Transport is http.DefaultTransport wrapped using
+// NewTransport with the provided options.
+//
+// Use NewTransport directly to instrument a custom http.RoundTripper.
+func NewClient(opts ...Option) *http.Client {
+	return &http.Client{Transport: NewTransport(http.DefaultTransport, opts...)}
+}
+
 // Get is a convenient replacement for http.Get that adds a span around the request.
 func Get(ctx context.Context, targetURL string) (resp *http.Response, err error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", targetURL, nil)
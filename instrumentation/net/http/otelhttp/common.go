@@ -16,6 +16,17 @@ const (
 	ReadErrorKey  = attribute.Key("http.read_error")  // If an error occurred while reading a request, the string of the error (io.EOF is not recorded)
 	WroteBytesKey = attribute.Key("http.wrote_bytes") // if anything was written to the response writer, the total number of bytes written
 	WriteErrorKey = attribute.Key("http.write_error") // if an error occurred while writing a reply, the string of the error (io.EOF is not recorded)
+
+	TLSProtocolVersionKey = attribute.Key("tls.protocol.version") // the TLS version of the connection used by a client request (e.g. "1.3"), see WithTLSAttributes
+	TLSCipherKey          = attribute.Key("tls.cipher")           // the cipher suite of the connection used by a client request, see WithTLSAttributes
+
+	DropSpanKey    = attribute.Key("otelhttp.drop")     // true if the span of a request is marked to be dropped, see WithDropFastSpans
+	RequestBodyKey = attribute.Key("http.request.body") // the body of a request that failed with a server error, see WithErrorRequestBodyCapture
+	RequestIDKey   = attribute.Key("http.request.id")   // the ID of a request read from, or generated for, its request ID header, see WithRequestIDHeader
+
+	DebugSamplingKey = attribute.Key("otelhttp.debug_sampling") // true if the span of a request is requested to be sampled with its debug header, see WithDebugSamplingHeader
+	CanceledKey      = attribute.Key("http.server.cancelled")   // true if a request was canceled by its client before it was served, see WithCancellationStatus
+	RouteMatchedKey  = attribute.Key("http.route.matched")      // false if a request did not match any route, see NotFoundHandler
 )
 
 // Server HTTP metrics.
@@ -23,6 +34,12 @@ const (
 	serverRequestSize  = "http.server.request.size"  // Incoming request bytes total
 	serverResponseSize = "http.server.response.size" // Incoming response bytes total
 	serverDuration     = "http.server.duration"      // Incoming end to end duration, milliseconds
+
+	serverQueueDuration   = "http.server.queue.duration"   // Duration until the handler start is marked, milliseconds
+	serverHandlerDuration = "http.server.handler.duration" // Duration after the handler start is marked, milliseconds
+
+	serverSSEEvents   = "http.server.sse.events"   // Server-Sent Events sent, see WithSSEEvents
+	serverSSEDuration = "http.server.sse.duration" // Duration of Server-Sent Events streams, milliseconds
 )
 
 // Client HTTP metrics.
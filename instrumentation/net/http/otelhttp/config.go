@@ -7,8 +7,11 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptrace"
+	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
@@ -32,6 +35,25 @@ type config struct {
 	Filters           []Filter
 	SpanNameFormatter func(string, *http.Request) string
 	ClientTrace       func(context.Context) *httptrace.ClientTrace
+	InjectionFilter   func(*http.Request) bool
+	DropFastSpans     time.Duration
+
+	ErrorRequestBodyCapture int
+
+	RequestIDHeader     string
+	GenerateRequestID   bool
+	DebugSamplingHeader string
+	DebugSamplingFilter Filter
+
+	MetricAttributesFn func(context.Context) []attribute.KeyValue
+
+	DisableServeMuxPattern bool
+	RouteAugmentor         func(*http.Request, string) string
+	TLSAttributes          bool
+	SemconvSchema          string
+	SSEEvents              bool
+	OmitUserAgent          bool
+	CancellationStatus     *codes.Code
 
 	TracerProvider trace.TracerProvider
 	MeterProvider  metric.MeterProvider
@@ -179,6 +201,21 @@ func WithSpanNameFormatter(f func(operation string, r *http.Request) string) Opt
 	})
 }
 
+// WithClientSpanNameFormatter takes a function that will be called on every
+// request sent by a Transport and the returned string will become the Span
+// Name. It is a convenience for WithSpanNameFormatter when the operation name
+// is not needed. A nil f is ignored and the default span name is kept.
+func WithClientSpanNameFormatter(f func(r *http.Request) string) Option {
+	return optionFunc(func(c *config) {
+		if f == nil {
+			return
+		}
+		c.SpanNameFormatter = func(_ string, r *http.Request) string {
+			return f(r)
+		}
+	})
+}
+
 // WithClientTrace takes a function that returns client trace instance that will be
 // applied to the requests sent through the otelhttp Transport.
 func WithClientTrace(f func(context.Context) *httptrace.ClientTrace) Option {
@@ -187,10 +224,230 @@ func WithClientTrace(f func(context.Context) *httptrace.ClientTrace) Option {
 	})
 }
 
+// WithInjectionFilter returns an Option that skips the injection of the span
+// context into the headers of the requests sent by a Transport for which f
+// returns true. The span of these requests is still created and recorded,
+// only no propagation headers are written. This is useful when requests go
+// through proxies or to services that reject unknown headers.
+func WithInjectionFilter(f func(*http.Request) bool) Option {
+	return optionFunc(func(c *config) {
+		c.InjectionFilter = f
+	})
+}
+
+// WithDropFastSpans returns an Option that marks the span of the requests
+// served by a Handler in less than threshold, and without error, to be
+// dropped. These spans are marked by the DropSpanKey attribute set to true.
+//
+// The Handler cannot drop a span by itself: a span is sampled when it starts,
+// before the duration of the request is known. The marked spans are still
+// ended and passed to the span processors of the TracerProvider, and it is
+// up to the user to register a processor or exporter that discards them.
+// Note that this only drops individual spans: the children of a marked span
+// are not dropped, which can leave incomplete traces. Sampling complete
+// traces based on their duration requires tail sampling, for example with
+// the OpenTelemetry Collector.
+//
+// A threshold of zero or less disables the marking, this is the default.
+func WithDropFastSpans(threshold time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.DropFastSpans = threshold
+	})
+}
+
+// WithErrorRequestBodyCapture returns an Option that records the body of the
+// requests served by a Handler with a server error response, a status code
+// of 500 or more, with the http.request.body span attribute. At most
+// maxBytes of the body are recorded, the rest is truncated.
+//
+// The body is copied as it is read by the wrapped handler, which still reads
+// the complete body. Only the part of the body read by the handler is
+// recorded. The body of the requests can contain sensitive data, only use
+// this option for debugging purposes.
+//
+// A maxBytes of zero or less disables the capture, this is the default.
+func WithErrorRequestBodyCapture(maxBytes int) Option {
+	return optionFunc(func(c *config) {
+		c.ErrorRequestBodyCapture = maxBytes
+	})
+}
+
 // WithServerName returns an Option that sets the name of the (virtual) server
 // handling requests.
+//
+// When set, the server name is used for the net.host.name attribute, and its
+// port (if any) for the net.host.port attribute, of the spans and metrics of
+// a Handler instead of the values derived from the request Host header. This
+// is useful behind reverse proxies where the Host header does not identify
+// the server. If server does not contain a port, the port is still derived
+// from the request.
 func WithServerName(server string) Option {
 	return optionFunc(func(c *config) {
 		c.ServerName = server
 	})
 }
+
+// WithoutServeMuxPattern returns an Option that disables the use of the
+// pattern matched by an http.ServeMux as the http.route attribute and span
+// name of the Handler.
+//
+// By default, when the wrapped handler is an http.ServeMux (Go 1.23 and
+// later) and it matched the request with a pattern, the path of that pattern
+// (e.g. "/items/{id}") is recorded as the http.route attribute on the span and
+// metrics. Unless a span name formatter is configured with
+// WithSpanNameFormatter, the span is also renamed to the request method
+// followed by that route (e.g. "GET /items/{id}").
+func WithoutServeMuxPattern() Option {
+	return optionFunc(func(c *config) {
+		c.DisableServeMuxPattern = true
+	})
+}
+
+// WithMetricAttributesFromContext returns an Option that adds the attributes
+// returned by fn to the metrics recorded by the Handler for every request. fn
+// is called with the context of the request when the metrics are recorded,
+// once the request has been handled. This can be used to add the attributes
+// common to all requests, derived from their context (e.g. the deployment
+// color).
+//
+// The attributes added for a request with AddMetricAttributes take
+// precedence over the ones returned by fn.
+func WithMetricAttributesFromContext(fn func(context.Context) []attribute.KeyValue) Option {
+	return optionFunc(func(c *config) {
+		c.MetricAttributesFn = fn
+	})
+}
+
+// WithRouteAugmentor returns an Option that rewrites the route resolved for a
+// request by the Handler with fn, before it is recorded as the http.route
+// attribute and used in the span name. fn is called with the request and its
+// route, and returns the route to record. This allows to distinguish routes
+// that differ by more than their path, for example APIs versioned with the
+// Accept header (e.g. "/users/{id}#v2").
+//
+// The route passed to fn is the pattern matched by an http.ServeMux, see
+// WithoutServeMuxPattern. fn is not called if no route was resolved. fn
+// should return low cardinality values as they are recorded on metrics.
+func WithRouteAugmentor(fn func(r *http.Request, route string) string) Option {
+	return optionFunc(func(c *config) {
+		c.RouteAugmentor = fn
+	})
+}
+
+// WithSSEEvents returns an Option that instruments the Server-Sent Events
+// responses of the Handler, the responses with the text/event-stream content
+// type. The events sent are counted with the http.server.sse.events metric,
+// and the duration of the stream, from the response header being written to
+// the end of the handler, is recorded with the http.server.sse.duration
+// metric. Both metrics have the attributes of the other server metrics.
+//
+// An event is counted every time the response is flushed, as SSE handlers
+// flush the response after each event. Use SSEEvent to count the events
+// explicitly instead.
+func WithSSEEvents() Option {
+	return optionFunc(func(c *config) {
+		c.SSEEvents = true
+	})
+}
+
+// WithUserAgent returns an Option that controls whether the User-Agent header
+// of the requests is recorded with the user_agent.original attribute on the
+// spans of the Handler. It is recorded by default, if the request has a
+// User-Agent header. Use WithUserAgent(false) to omit it, for example for
+// privacy reasons.
+func WithUserAgent(record bool) Option {
+	return optionFunc(func(c *config) {
+		c.OmitUserAgent = !record
+	})
+}
+
+// WithCancellationStatus returns an Option that sets the status of the spans
+// of the requests canceled by their client, for example because the client
+// closed the connection, to code instead of the status derived from the
+// response status code. These spans are also marked with the
+// http.server.cancelled attribute. This avoids counting the requests given up
+// by their clients as server errors, for example with codes.Unset.
+//
+// A request is canceled if the context of the request is canceled with
+// context.Canceled when the handler returns. By default, the canceled
+// requests are not distinguished from the other requests.
+func WithCancellationStatus(code codes.Code) Option {
+	return optionFunc(func(c *config) {
+		c.CancellationStatus = &code
+	})
+}
+
+// WithRequestIDHeader returns an Option that records the ID of the requests
+// served by a Handler, read from their header name (e.g. "X-Request-ID"), as
+// the http.request.id span attribute. The ID is also added to the request
+// context and can be retrieved with RequestIDFromContext.
+//
+// By default, no ID is recorded for the requests without the header. Use
+// WithRequestIDGeneration to generate an ID for these requests.
+func WithRequestIDHeader(name string) Option {
+	return optionFunc(func(c *config) {
+		c.RequestIDHeader = name
+	})
+}
+
+// WithRequestIDGeneration returns an Option that generates a random ID for
+// the requests served by a Handler without the header configured with
+// WithRequestIDHeader. The generated ID is recorded like the ones read from
+// the requests, and set in the header of the response so clients can
+// correlate their request with the server telemetry.
+//
+// This option has no effect without WithRequestIDHeader.
+func WithRequestIDGeneration() Option {
+	return optionFunc(func(c *config) {
+		c.GenerateRequestID = true
+	})
+}
+
+// WithDebugSamplingHeader returns an Option that requests the span of the
+// requests served by a Handler with the header name set to "1" or "true"
+// (e.g. "X-Debug-Trace: 1") to be sampled. Only the requests for which
+// trusted returns true are considered, trusted is expected to check that the
+// request comes from a trusted source. If trusted is nil, the header of all
+// requests is honored, which lets any client increase the tracing volume.
+//
+// The span of a debug request is started with a context for which
+// IsDebugSampling returns true, and with the DebugSamplingKey attribute set
+// to true. The sampling is forced by the sampler of the TracerProvider: wrap
+// it with the DebugSampler of the
+// go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpsampler
+// module, otherwise the header has no effect on the sampling decision.
+func WithDebugSamplingHeader(name string, trusted func(*http.Request) bool) Option {
+	return optionFunc(func(c *config) {
+		c.DebugSamplingHeader = name
+		c.DebugSamplingFilter = trusted
+	})
+}
+
+// WithTLSAttributes returns an Option that enables recording the TLS protocol
+// version and cipher suite of the connection used by a Transport as the
+// tls.protocol.version and tls.cipher client span attributes. These
+// attributes are not recorded for requests that did not use TLS.
+func WithTLSAttributes() Option {
+	return optionFunc(func(c *config) {
+		c.TLSAttributes = true
+	})
+}
+
+// WithSemconvSchema returns an Option that selects the version of the
+// semantic conventions of the span attributes recorded by the Handler. This
+// eases the transition of backends expecting different versions. The
+// supported versions are:
+//
+//   - "1.20.0": the http.method, http.status_code, net.host.name, ...
+//     attributes. This is the default.
+//   - "1.24.0": the stable HTTP attributes, http.request.method,
+//     http.response.status_code, server.address, url.path, ... These
+//     attribute names are unchanged up to the 1.26.0 version.
+//
+// An unsupported version is reported to the global error handler and the
+// default version is used instead.
+func WithSemconvSchema(version string) Option {
+	return optionFunc(func(c *config) {
+		c.SemconvSchema = version
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+import (
+	"context"
+	"net/http"
+)
+
+type debugSamplingContextKeyType int
+
+const debugSamplingContextKey debugSamplingContextKeyType = 0
+
+// IsDebugSampling returns true if ctx is the context of a request served by a
+// Handler configured with WithDebugSamplingHeader, and whose header requests
+// its span to be sampled. Samplers can use it with the parent context of the
+// sampling parameters to sample these spans.
+func IsDebugSampling(ctx context.Context) bool {
+	debug, _ := ctx.Value(debugSamplingContextKey).(bool)
+	return debug
+}
+
+// debugSampling returns true if the header name of r requests its span to be
+// sampled and r is trusted.
+func debugSampling(r *http.Request, name string, trusted Filter) bool {
+	switch r.Header.Get(name) {
+	case "1", "true":
+	default:
+		return false
+	}
+	return trusted == nil || trusted(r)
+}
@@ -4,8 +4,11 @@ go 1.21
 
 replace go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp => ../
 
+replace go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpview => ../otelhttpview
+
 require (
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.51.0
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpview v0.51.0
 	go.opentelemetry.io/otel v1.26.0
 	go.opentelemetry.io/otel/exporters/stdout/stdoutmetric v1.26.0
 	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.26.0
@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpview"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -51,7 +52,15 @@ func initMeter() (*sdkmetric.MeterProvider, error) {
 		return nil, err
 	}
 
-	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)))
+	// The default bucket boundaries are too coarse for handlers responding
+	// in less than a millisecond. The View aggregates the request duration
+	// histograms of otelhttp, in milliseconds, with finer boundaries.
+	durationView := otelhttpview.DurationView([]float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 50, 100})
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)),
+		sdkmetric.WithView(durationView),
+	)
 	otel.SetMeterProvider(mp)
 	return mp, nil
 }
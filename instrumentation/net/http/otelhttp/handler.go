@@ -4,6 +4,9 @@
 package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 import (
+	"context"
+	"errors"
+	"io"
 	"net/http"
 	"time"
 
@@ -12,6 +15,8 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/internal/semconv"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/internal/semconvutil"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
@@ -32,11 +37,32 @@ type middleware struct {
 	spanNameFormatter func(string, *http.Request) string
 	publicEndpoint    bool
 	publicEndpointFn  func(*http.Request) bool
-
-	traceSemconv         semconv.HTTPServer
-	requestBytesCounter  metric.Int64Counter
-	responseBytesCounter metric.Int64Counter
-	serverLatencyMeasure metric.Float64Histogram
+	dropFastSpans     time.Duration
+	errorBodyCapture  int
+	metricAttrsFn     func(context.Context) []attribute.KeyValue
+	requestIDHeader   string
+	generateRequestID bool
+	debugHeader       string
+	debugFilter       Filter
+	sseEvents         bool
+	omitUserAgent     bool
+
+	cancellationStatus *codes.Code
+
+	serveMuxPattern bool
+	routeAugmentor  func(*http.Request, string) string
+	// defaultSpanName is true if the span name is not customized with
+	// WithSpanNameFormatter.
+	defaultSpanName bool
+
+	traceSemconv          semconv.HTTPServer
+	requestBytesCounter   metric.Int64Counter
+	responseBytesCounter  metric.Int64Counter
+	serverLatencyMeasure  metric.Float64Histogram
+	queueLatencyMeasure   metric.Float64Histogram
+	handlerLatencyMeasure metric.Float64Histogram
+	sseEventsCounter      metric.Int64Counter
+	sseDurationMeasure    metric.Float64Histogram
 }
 
 func defaultHandlerFormatter(operation string, _ *http.Request) string {
@@ -61,7 +87,6 @@ func NewMiddleware(operation string, opts ...Option) func(http.Handler) http.Han
 
 	defaultOpts := []Option{
 		WithSpanOptions(trace.WithSpanKind(trace.SpanKindServer)),
-		WithSpanNameFormatter(defaultHandlerFormatter),
 	}
 
 	c := newConfig(append(defaultOpts, opts...)...)
@@ -84,9 +109,33 @@ func (h *middleware) configure(c *config) {
 	h.writeEvent = c.WriteEvent
 	h.filters = c.Filters
 	h.spanNameFormatter = c.SpanNameFormatter
+	if h.spanNameFormatter == nil {
+		h.spanNameFormatter = defaultHandlerFormatter
+		h.defaultSpanName = true
+	}
+	h.serveMuxPattern = !c.DisableServeMuxPattern
+	h.routeAugmentor = c.RouteAugmentor
 	h.publicEndpoint = c.PublicEndpoint
 	h.publicEndpointFn = c.PublicEndpointFn
+	h.dropFastSpans = c.DropFastSpans
+	h.errorBodyCapture = c.ErrorRequestBodyCapture
+	h.metricAttrsFn = c.MetricAttributesFn
+	h.requestIDHeader = c.RequestIDHeader
+	h.generateRequestID = c.GenerateRequestID
+	h.debugHeader = c.DebugSamplingHeader
+	h.debugFilter = c.DebugSamplingFilter
 	h.server = c.ServerName
+	h.sseEvents = c.SSEEvents
+	h.omitUserAgent = c.OmitUserAgent
+	h.cancellationStatus = c.CancellationStatus
+	if c.SemconvSchema != "" {
+		s, err := semconv.NewHTTPServerVersion(c.SemconvSchema)
+		if err != nil {
+			handleErr(err)
+		} else {
+			h.traceSemconv = s
+		}
+	}
 }
 
 func handleErr(err error) {
@@ -117,6 +166,36 @@ func (h *middleware) createMeasures() {
 		metric.WithDescription("Measures the duration of inbound HTTP requests."),
 	)
 	handleErr(err)
+
+	h.queueLatencyMeasure, err = h.meter.Float64Histogram(
+		serverQueueDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of inbound HTTP requests before their handler started, as marked with MarkHandlerStart."),
+	)
+	handleErr(err)
+
+	h.handlerLatencyMeasure, err = h.meter.Float64Histogram(
+		serverHandlerDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of inbound HTTP requests after their handler started, as marked with MarkHandlerStart."),
+	)
+	handleErr(err)
+
+	if h.sseEvents {
+		h.sseEventsCounter, err = h.meter.Int64Counter(
+			serverSSEEvents,
+			metric.WithUnit("{event}"),
+			metric.WithDescription("Measures the number of Server-Sent Events sent."),
+		)
+		handleErr(err)
+
+		h.sseDurationMeasure, err = h.meter.Float64Histogram(
+			serverSSEDuration,
+			metric.WithUnit("ms"),
+			metric.WithDescription("Measures the duration of Server-Sent Events streams."),
+		)
+		handleErr(err)
+	}
 }
 
 // serveHTTP sets up tracing and calls the given next http.Handler with the span
@@ -132,10 +211,31 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 	}
 
 	ctx := h.propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
-	opts := []trace.SpanStartOption{
-		trace.WithAttributes(h.traceSemconv.RequestTraceAttrs(h.server, r)...),
+	// Sized for the request attributes, the request ID, the debug sampling
+	// attribute, the configured options, and the public endpoint options.
+	opts := make([]trace.SpanStartOption, 0, len(h.spanStartOptions)+5)
+	reqAttrs := h.traceSemconv.RequestTraceAttrs(h.server, r)
+	if h.omitUserAgent {
+		reqAttrs = withoutUserAgent(reqAttrs)
+	}
+	opts = append(opts, trace.WithAttributes(reqAttrs...))
+	if h.requestIDHeader != "" {
+		id := r.Header.Get(h.requestIDHeader)
+		if id == "" && h.generateRequestID {
+			id = newRequestID()
+			if id != "" {
+				w.Header().Set(h.requestIDHeader, id)
+			}
+		}
+		if id != "" {
+			opts = append(opts, trace.WithAttributes(RequestIDKey.String(id)))
+			ctx = injectRequestID(ctx, id)
+		}
+	}
+	if h.debugHeader != "" && debugSampling(r, h.debugHeader, h.debugFilter) {
+		opts = append(opts, trace.WithAttributes(DebugSamplingKey.Bool(true)))
+		ctx = context.WithValue(ctx, debugSamplingContextKey, true)
 	}
-
 	opts = append(opts, h.spanStartOptions...)
 	if h.publicEndpoint || (h.publicEndpointFn != nil && h.publicEndpointFn(r.WithContext(ctx))) {
 		opts = append(opts, trace.WithNewRoot())
@@ -166,11 +266,16 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 	}
 
 	var bw bodyWrapper
+	var body *limitedBuffer
 	// if request body is nil or NoBody, we don't want to mutate the body as it
 	// will affect the identity of it in an unforeseeable way because we assert
 	// ReadCloser fulfills a certain interface and it is indeed nil or NoBody.
 	if r.Body != nil && r.Body != http.NoBody {
 		bw.ReadCloser = r.Body
+		if h.errorBodyCapture > 0 {
+			body = &limitedBuffer{max: h.errorBodyCapture}
+			bw.ReadCloser = &teeReadCloser{ReadCloser: r.Body, buf: body}
+		}
 		bw.record = readRecordFunc
 		r.Body = &bw
 	}
@@ -194,7 +299,7 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 	// other interfaces that w may implement (http.CloseNotifier,
 	// http.Flusher, http.Hijacker, http.Pusher, io.ReaderFrom).
 
-	w = httpsnoop.Wrap(w, httpsnoop.Hooks{
+	hooks := httpsnoop.Hooks{
 		Header: func(httpsnoop.HeaderFunc) httpsnoop.HeaderFunc {
 			return rww.Header
 		},
@@ -204,14 +309,65 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 		WriteHeader: func(httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
 			return rww.WriteHeader
 		},
-	})
+	}
+	if h.sseEvents {
+		rww.sse = &sseStream{}
+		ctx = injectSSEStream(ctx, rww.sse)
+		hooks.Flush = func(next httpsnoop.FlushFunc) httpsnoop.FlushFunc {
+			return func() { rww.Flush(next) }
+		}
+	}
+	w = httpsnoop.Wrap(w, hooks)
 
 	labeler := &Labeler{}
 	ctx = injectLabeler(ctx, labeler)
+	start := newHandlerStart(requestStartTime)
+	ctx = injectHandlerStart(ctx, start)
+	match := &routeMatch{}
+	ctx = injectRouteMatch(ctx, match)
+
+	// The http.ServeMux sets the matched pattern on the request it is
+	// passed, keep a reference to it so the pattern can be read afterwards.
+	r = r.WithContext(ctx)
+	next.ServeHTTP(w, r)
+
+	attrsBuf := getMetricAttrs()
+	defer putMetricAttrs(attrsBuf)
+	attributes := *attrsBuf
+	if match.unmatched.Load() {
+		// The pattern of the catch-all route serving the request, if any,
+		// is replaced by the placeholder.
+		routeAttr := h.traceSemconv.Route(UnmatchedRoute)
+		span.SetAttributes(routeAttr, RouteMatchedKey.Bool(false))
+		if h.defaultSpanName {
+			span.SetName(r.Method + " " + UnmatchedRoute)
+		}
+		attributes = append(attributes, routeAttr, RouteMatchedKey.Bool(false))
+	} else if h.serveMuxPattern {
+		if route := patternRoute(requestPattern(r)); route != "" {
+			if h.routeAugmentor != nil {
+				route = h.routeAugmentor(r, route)
+			}
+			routeAttr := h.traceSemconv.Route(route)
+			span.SetAttributes(routeAttr)
+			if h.defaultSpanName {
+				span.SetName(r.Method + " " + route)
+			}
+			// Added before any labeler attributes so a route set with
+			// WithRouteTag takes precedence.
+			attributes = append(attributes, routeAttr)
+		}
+	}
 
-	next.ServeHTTP(w, r.WithContext(ctx))
-
-	span.SetStatus(semconv.ServerStatus(rww.statusCode))
+	if h.cancellationStatus != nil && errors.Is(ctx.Err(), context.Canceled) {
+		span.SetAttributes(CanceledKey.Bool(true))
+		span.SetStatus(*h.cancellationStatus, "request canceled")
+	} else {
+		span.SetStatus(semconv.ServerStatus(rww.statusCode))
+	}
+	if body != nil && rww.statusCode >= 500 {
+		span.SetAttributes(RequestBodyKey.String(string(body.buf)))
+	}
 	span.SetAttributes(h.traceSemconv.ResponseTraceAttrs(semconv.ResponseTelemetry{
 		StatusCode: rww.statusCode,
 		ReadBytes:  bw.read.Load(),
@@ -221,18 +377,52 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 	})...)
 
 	// Add metrics
-	attributes := append(labeler.Get(), semconvutil.HTTPServerRequestMetrics(h.server, r)...)
+	if h.metricAttrsFn != nil {
+		// Added before the labeler attributes so the ones of the request
+		// take precedence.
+		attributes = append(attributes, h.metricAttrsFn(ctx)...)
+	}
+	attributes = labeler.appendTo(attributes)
+	attributes = append(attributes, semconvutil.HTTPServerRequestMetrics(h.server, r)...)
 	if rww.statusCode > 0 {
 		attributes = append(attributes, semconv.HTTPStatusCode(rww.statusCode))
 	}
-	o := metric.WithAttributes(attributes...)
+	// Keep the grown slice for the next requests.
+	*attrsBuf = attributes
+	// The set is built directly from the pooled slice, it copies the
+	// attributes so the slice can be reused once the request is done.
+	o := metric.WithAttributeSet(attribute.NewSet(attributes...))
 	h.requestBytesCounter.Add(ctx, bw.read.Load(), o)
 	h.responseBytesCounter.Add(ctx, rww.written, o)
 
 	// Use floating point division here for higher precision (instead of Millisecond method).
-	elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
+	elapsed := time.Since(requestStartTime)
+	elapsedTime := float64(elapsed) / float64(time.Millisecond)
 
 	h.serverLatencyMeasure.Record(ctx, elapsedTime, o)
+
+	if h.dropFastSpans > 0 && elapsed < h.dropFastSpans && !failed(rww.statusCode, bw.err, rww.err) {
+		span.SetAttributes(DropSpanKey.Bool(true))
+	}
+
+	if rww.sse != nil && rww.wroteHeader && isSSE(rww.Header()) {
+		h.sseEventsCounter.Add(ctx, rww.sse.count(), o)
+		h.sseDurationMeasure.Record(ctx, float64(time.Since(rww.sse.start))/float64(time.Millisecond), o)
+	}
+
+	if queued, ok := start.queueDuration(); ok {
+		h.queueLatencyMeasure.Record(ctx, float64(queued)/float64(time.Millisecond), o)
+		h.handlerLatencyMeasure.Record(ctx, float64(elapsed-queued)/float64(time.Millisecond), o)
+	}
+}
+
+// failed returns true if a request served with statusCode, and whose body
+// was read with readErr and response written with writeErr, failed.
+func failed(statusCode int, readErr, writeErr error) bool {
+	if statusCode >= 500 {
+		return true
+	}
+	return (readErr != nil && readErr != io.EOF) || (writeErr != nil && writeErr != io.EOF)
 }
 
 // WithRouteTag annotates spans and metrics with the provided route name
@@ -249,3 +439,18 @@ func WithRouteTag(route string, h http.Handler) http.Handler {
 		h.ServeHTTP(w, r)
 	})
 }
+
+// userAgentOriginalKey is the key of the User-Agent attribute, which is
+// unchanged across the supported semantic conventions versions.
+const userAgentOriginalKey = attribute.Key("user_agent.original")
+
+// withoutUserAgent removes the user_agent.original attribute from attrs.
+func withoutUserAgent(attrs []attribute.KeyValue) []attribute.KeyValue {
+	out := attrs[:0]
+	for _, a := range attrs {
+		if a.Key != userAgentOriginalKey {
+			out = append(out, a)
+		}
+	}
+	return out
+}
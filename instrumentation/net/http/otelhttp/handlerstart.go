@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// handlerStart holds when a request handler was marked as started with
+// MarkHandlerStart.
+type handlerStart struct {
+	// requestStart is when the instrumentation started handling the request.
+	requestStart time.Time
+	// queued is the duration from requestStart to the mark, or -1 if the
+	// handler start was not marked.
+	queued atomic.Int64
+}
+
+func newHandlerStart(requestStart time.Time) *handlerStart {
+	s := &handlerStart{requestStart: requestStart}
+	s.queued.Store(-1)
+	return s
+}
+
+// mark marks the handler start if it was not already.
+func (s *handlerStart) mark() {
+	s.queued.CompareAndSwap(-1, int64(time.Since(s.requestStart)))
+}
+
+// queueDuration returns the duration from the request start to the mark and
+// whether the handler start was marked.
+func (s *handlerStart) queueDuration() (time.Duration, bool) {
+	d := s.queued.Load()
+	if d < 0 {
+		return 0, false
+	}
+	return time.Duration(d), true
+}
+
+type handlerStartContextKeyType int
+
+const handlerStartContextKey handlerStartContextKeyType = 0
+
+func injectHandlerStart(ctx context.Context, s *handlerStart) context.Context {
+	return context.WithValue(ctx, handlerStartContextKey, s)
+}
+
+// MarkHandlerStart marks the time the handler of the request whose context is
+// ctx starts processing it, for example after the authentication and parsing
+// middlewares it is wrapped in have run. When marked, the instrumentation
+// records the time spent before the mark in the http.server.queue.duration
+// metric and the time spent after it in the http.server.handler.duration
+// metric, in addition to the total duration. Only the first mark of a request
+// is used.
+//
+// It reports whether ctx belongs to a request handled by the instrumentation.
+// If it returns false the mark is not used.
+func MarkHandlerStart(ctx context.Context) bool {
+	s, ok := ctx.Value(handlerStartContextKey).(*handlerStart)
+	if !ok {
+		return false
+	}
+	s.mark()
+	return true
+}
@@ -55,6 +55,19 @@ func NewHTTPServer() HTTPServer {
 	return oldHTTPServer{}
 }
 
+// NewHTTPServerVersion returns the HTTPServer emitting the attributes of the
+// semantic conventions version, either "1.20.0" or "1.24.0". An error is
+// returned if version is not supported.
+func NewHTTPServerVersion(version string) (HTTPServer, error) {
+	switch version {
+	case "1.20.0":
+		return oldHTTPServer{}, nil
+	case "1.24.0":
+		return newHTTPServer{}, nil
+	}
+	return nil, fmt.Errorf("unsupported semantic conventions version %q", version)
+}
+
 // ServerStatus returns a span status code and message for an HTTP status code
 // value returned by a server. Status codes in the 400-499 range are not
 // returned as errors.
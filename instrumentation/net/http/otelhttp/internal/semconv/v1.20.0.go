@@ -6,6 +6,7 @@ package semconv // import "go.opentelemetry.io/contrib/instrumentation/net/http/
 import (
 	"io"
 	"net/http"
+	"strings"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/internal/semconvutil"
 	"go.opentelemetry.io/otel/attribute"
@@ -33,7 +34,7 @@ var _ HTTPServer = oldHTTPServer{}
 // If the primary server name is not known, server should be an empty string.
 // The req Host will be used to determine the server instead.
 func (o oldHTTPServer) RequestTraceAttrs(server string, req *http.Request) []attribute.KeyValue {
-	return semconvutil.HTTPServerRequest(server, req)
+	return standardizeMethod(semconvutil.HTTPServerRequest(server, req), req.Method)
 }
 
 // ResponseTraceAttrs returns trace attributes for telemetry from an HTTP response.
@@ -68,6 +69,47 @@ func (o oldHTTPServer) Route(route string) attribute.KeyValue {
 	return semconv.HTTPRoute(route)
 }
 
+// ClientRequestTraceAttrs returns trace attributes for an HTTP request made by
+// a client.
+func ClientRequestTraceAttrs(req *http.Request) []attribute.KeyValue {
+	return standardizeMethod(semconvutil.HTTPClientRequest(req), req.Method)
+}
+
+// methodOriginalKey is the attribute key of the original HTTP request method
+// when it is not a standard method.
+const methodOriginalKey = attribute.Key("http.request.method_original")
+
+// standardizeMethod replaces the http.method attribute in attrs by the
+// standardized method. Standard methods are recorded in upper case, and
+// other methods are recorded as "_OTHER" with the original method recorded
+// with the http.request.method_original attribute.
+func standardizeMethod(attrs []attribute.KeyValue, method string) []attribute.KeyValue {
+	if method == "" {
+		// The Go client and server default to GET.
+		return attrs
+	}
+
+	std := strings.ToUpper(method)
+	switch std {
+	case http.MethodConnect, http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPatch, http.MethodPost, http.MethodPut, http.MethodTrace:
+	default:
+		std = "_OTHER"
+	}
+	if std == method {
+		return attrs
+	}
+
+	for i, kv := range attrs {
+		if kv.Key == semconv.HTTPMethodKey {
+			attrs[i] = semconv.HTTPMethod(std)
+		}
+	}
+	if std == "_OTHER" {
+		attrs = append(attrs, methodOriginalKey.String(method))
+	}
+	return attrs
+}
+
 // HTTPStatusCode returns the attribute for the HTTP status code.
 // This is a temporary function needed by metrics.  This will be removed when MetricsRequest is added.
 func HTTPStatusCode(status int) attribute.KeyValue {
@@ -5,9 +5,11 @@ package semconv
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -83,3 +85,44 @@ func TestV120TraceResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestV120MethodTraceAttrs(t *testing.T) {
+	testCases := []struct {
+		method string
+		want   []attribute.KeyValue
+	}{
+		{
+			method: "GET",
+			want:   []attribute.KeyValue{attribute.String("http.method", "GET")},
+		},
+		{
+			method: "get",
+			want:   []attribute.KeyValue{attribute.String("http.method", "GET")},
+		},
+		{
+			method: "FOOBAR",
+			want: []attribute.KeyValue{
+				attribute.String("http.method", "_OTHER"),
+				attribute.String("http.request.method_original", "FOOBAR"),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.method, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, "http://example.com/", http.NoBody)
+			require.NoError(t, err)
+
+			server := NewHTTPServer().RequestTraceAttrs("", req)
+			client := ClientRequestTraceAttrs(req)
+			for _, kv := range tc.want {
+				assert.Contains(t, server, kv, "server")
+				assert.Contains(t, client, kv, "client")
+			}
+			if len(tc.want) == 1 {
+				for _, kv := range append(server, client...) {
+					assert.NotEqual(t, methodOriginalKey, kv.Key)
+				}
+			}
+		})
+	}
+}
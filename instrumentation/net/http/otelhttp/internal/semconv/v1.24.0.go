@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconv // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/internal/semconv"
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconvNew "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+type newHTTPServer struct{}
+
+var _ HTTPServer = newHTTPServer{}
+
+// RequestTraceAttrs returns trace attributes for an HTTP request received by a
+// server.
+//
+// The server must be the primary server name if it is known. For example this
+// would be the ServerName directive
+// (https://httpd.apache.org/docs/2.4/mod/core.html#servername) for an Apache
+// server, and the server_name directive
+// (http://nginx.org/en/docs/http/ngx_http_core_module.html#server_name) for an
+// nginx server. More generically, the primary server name would be the host
+// header value that matches the default virtual host of an HTTP server. It
+// should include the host identifier and if a port is used to route to the
+// server that port identifier should be included as an appropriate port
+// suffix.
+//
+// If the primary server name is not known, server should be an empty string.
+// The req Host will be used to determine the server instead.
+func (n newHTTPServer) RequestTraceAttrs(server string, req *http.Request) []attribute.KeyValue {
+	attrs := n.method(req.Method)
+	if req.TLS != nil {
+		attrs = append(attrs, semconvNew.URLScheme("https"))
+	} else {
+		attrs = append(attrs, semconvNew.URLScheme("http"))
+	}
+
+	if server == "" {
+		server = req.Host
+	}
+	host, port := splitHostPort(server)
+	if port < 0 {
+		// The server name has no port, use the one of the request if any.
+		_, port = splitHostPort(req.Host)
+	}
+	if host != "" {
+		attrs = append(attrs, semconvNew.ServerAddress(host))
+	}
+	if port > 0 {
+		attrs = append(attrs, semconvNew.ServerPort(port))
+	}
+
+	if peer, peerPort := splitHostPort(req.RemoteAddr); peer != "" {
+		attrs = append(attrs, semconvNew.NetworkPeerAddress(peer))
+		if peerPort > 0 {
+			attrs = append(attrs, semconvNew.NetworkPeerPort(peerPort))
+		}
+	}
+
+	if useragent := req.UserAgent(); useragent != "" {
+		attrs = append(attrs, semconvNew.UserAgentOriginal(useragent))
+	}
+
+	if clientIP := serverClientIP(req.Header.Get("X-Forwarded-For")); clientIP != "" {
+		attrs = append(attrs, semconvNew.ClientAddress(clientIP))
+	}
+
+	if req.URL != nil && req.URL.Path != "" {
+		attrs = append(attrs, semconvNew.URLPath(req.URL.Path))
+	}
+	if req.URL != nil && req.URL.RawQuery != "" {
+		attrs = append(attrs, semconvNew.URLQuery(req.URL.RawQuery))
+	}
+
+	protoName, protoVersion := netProtocol(req.Proto)
+	if protoName != "" && protoName != "http" {
+		attrs = append(attrs, semconvNew.NetworkProtocolName(protoName))
+	}
+	if protoVersion != "" {
+		attrs = append(attrs, semconvNew.NetworkProtocolVersion(protoVersion))
+	}
+
+	return attrs
+}
+
+func (n newHTTPServer) method(method string) []attribute.KeyValue {
+	if method == "" {
+		// The Go server defaults to GET.
+		return []attribute.KeyValue{semconvNew.HTTPRequestMethodGet}
+	}
+
+	std := strings.ToUpper(method)
+	switch std {
+	case http.MethodConnect, http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPatch, http.MethodPost, http.MethodPut, http.MethodTrace:
+	default:
+		return []attribute.KeyValue{
+			semconvNew.HTTPRequestMethodOther,
+			semconvNew.HTTPRequestMethodOriginal(method),
+		}
+	}
+	attrs := []attribute.KeyValue{semconvNew.HTTPRequestMethodKey.String(std)}
+	if std != method {
+		attrs = append(attrs, semconvNew.HTTPRequestMethodOriginal(method))
+	}
+	return attrs
+}
+
+// ResponseTraceAttrs returns trace attributes for telemetry from an HTTP response.
+//
+// If any of the fields in the ResponseTelemetry are not set the attribute will be omitted.
+func (n newHTTPServer) ResponseTraceAttrs(resp ResponseTelemetry) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{}
+
+	if resp.ReadBytes > 0 {
+		attributes = append(attributes, semconvNew.HTTPRequestBodySize(int(resp.ReadBytes)))
+	}
+	if resp.ReadError != nil && resp.ReadError != io.EOF {
+		// This is not in the semantic conventions, but is historically provided
+		attributes = append(attributes, attribute.String("http.read_error", resp.ReadError.Error()))
+	}
+	if resp.WriteBytes > 0 {
+		attributes = append(attributes, semconvNew.HTTPResponseBodySize(int(resp.WriteBytes)))
+	}
+	if resp.StatusCode > 0 {
+		attributes = append(attributes, semconvNew.HTTPResponseStatusCode(resp.StatusCode))
+	}
+	if resp.WriteError != nil && resp.WriteError != io.EOF {
+		// This is not in the semantic conventions, but is historically provided
+		attributes = append(attributes, attribute.String("http.write_error", resp.WriteError.Error()))
+	}
+
+	return attributes
+}
+
+// Route returns the attribute for the route.
+func (n newHTTPServer) Route(route string) attribute.KeyValue {
+	return semconvNew.HTTPRoute(route)
+}
+
+// serverClientIP returns the client IP of the X-Forwarded-For header value
+// xForwardedFor, its first entry.
+func serverClientIP(xForwardedFor string) string {
+	if idx := strings.Index(xForwardedFor, ","); idx >= 0 {
+		xForwardedFor = xForwardedFor[:idx]
+	}
+	return strings.TrimSpace(xForwardedFor)
+}
+
+// netProtocol returns the lower case name and the version of the protocol
+// proto, e.g. "HTTP/1.1". The version of HTTP/2 and later is recorded
+// without minor version, e.g. "2".
+func netProtocol(proto string) (name string, version string) {
+	name, version, _ = strings.Cut(proto, "/")
+	name = strings.ToLower(name)
+	if major, minor, ok := strings.Cut(version, "."); ok && minor == "0" {
+		if n, err := strconv.Atoi(major); err == nil && n >= 2 {
+			version = major
+		}
+	}
+	return name, version
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconv
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestNewHTTPServerVersion(t *testing.T) {
+	serv, err := NewHTTPServerVersion("1.20.0")
+	require.NoError(t, err)
+	assert.Equal(t, oldHTTPServer{}, serv)
+
+	serv, err = NewHTTPServerVersion("1.24.0")
+	require.NoError(t, err)
+	assert.Equal(t, newHTTPServer{}, serv)
+
+	_, err = NewHTTPServerVersion("1.99.0")
+	assert.EqualError(t, err, `unsupported semantic conventions version "1.99.0"`)
+}
+
+func TestV124TraceRequest(t *testing.T) {
+	want := func(req testServerReq) []attribute.KeyValue {
+		return []attribute.KeyValue{
+			attribute.String("http.request.method", "GET"),
+			attribute.String("url.scheme", "http"),
+			attribute.String("server.address", req.hostname),
+			attribute.Int("server.port", req.serverPort),
+			attribute.String("network.peer.address", req.peerAddr),
+			attribute.Int("network.peer.port", req.peerPort),
+			attribute.String("user_agent.original", "Go-http-client/1.1"),
+			attribute.String("client.address", req.clientIP),
+			attribute.String("network.protocol.version", "1.1"),
+			attribute.String("url.path", "/"),
+		}
+	}
+	testTraceRequest(t, newHTTPServer{}, want)
+}
+
+func TestV124TraceRequestServerName(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://example.com:8080/path?q=1", http.NoBody)
+	require.NoError(t, err)
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2.0", 2, 0
+
+	got := newHTTPServer{}.RequestTraceAttrs("virtual.example.com", req)
+	assert.Contains(t, got, attribute.String("server.address", "virtual.example.com"))
+	assert.Contains(t, got, attribute.Int("server.port", 8080))
+	assert.Contains(t, got, attribute.String("url.query", "q=1"))
+	assert.Contains(t, got, attribute.String("network.protocol.version", "2"))
+}
+
+func TestV124TraceResponse(t *testing.T) {
+	testCases := []struct {
+		name string
+		resp ResponseTelemetry
+		want []attribute.KeyValue
+	}{
+		{
+			name: "empty",
+			resp: ResponseTelemetry{},
+			want: nil,
+		},
+		{
+			name: "no errors",
+			resp: ResponseTelemetry{
+				StatusCode: 200,
+				ReadBytes:  701,
+				WriteBytes: 802,
+			},
+			want: []attribute.KeyValue{
+				attribute.Int("http.request.body.size", 701),
+				attribute.Int("http.response.body.size", 802),
+				attribute.Int("http.response.status_code", 200),
+			},
+		},
+		{
+			name: "with errors",
+			resp: ResponseTelemetry{
+				StatusCode: 200,
+				ReadBytes:  701,
+				ReadError:  fmt.Errorf("read error"),
+				WriteBytes: 802,
+				WriteError: fmt.Errorf("write error"),
+			},
+			want: []attribute.KeyValue{
+				attribute.Int("http.request.body.size", 701),
+				attribute.String("http.read_error", "read error"),
+				attribute.Int("http.response.body.size", 802),
+				attribute.String("http.write_error", "write error"),
+				attribute.Int("http.response.status_code", 200),
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newHTTPServer{}.ResponseTraceAttrs(tt.resp)
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestV124MethodTraceAttrs(t *testing.T) {
+	testCases := []struct {
+		method string
+		want   []attribute.KeyValue
+	}{
+		{
+			method: "GET",
+			want:   []attribute.KeyValue{attribute.String("http.request.method", "GET")},
+		},
+		{
+			method: "get",
+			want: []attribute.KeyValue{
+				attribute.String("http.request.method", "GET"),
+				attribute.String("http.request.method_original", "get"),
+			},
+		},
+		{
+			method: "FOOBAR",
+			want: []attribute.KeyValue{
+				attribute.String("http.request.method", "_OTHER"),
+				attribute.String("http.request.method_original", "FOOBAR"),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.method, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, "http://example.com/", http.NoBody)
+			require.NoError(t, err)
+
+			got := newHTTPServer{}.RequestTraceAttrs("", req)
+			for _, kv := range tc.want {
+				assert.Contains(t, got, kv)
+			}
+		})
+	}
+}
@@ -33,6 +33,14 @@ func (l *Labeler) Get() []attribute.KeyValue {
 	return ret
 }
 
+// appendTo appends the attributes added to the Labeler to dst and returns the
+// extended slice.
+func (l *Labeler) appendTo(dst []attribute.KeyValue) []attribute.KeyValue {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append(dst, l.attributes...)
+}
+
 type labelerContextKeyType int
 
 const lablelerContextKey labelerContextKeyType = 0
@@ -52,3 +60,17 @@ func LabelerFromContext(ctx context.Context) (*Labeler, bool) {
 	}
 	return l, ok
 }
+
+// AddMetricAttributes adds attrs to the metrics recorded for the request
+// whose context is ctx. The attributes are added to the Labeler injected in
+// ctx by the instrumentation and are included when the metrics are recorded,
+// at the end of the request. Therefore, they can be added at any point while
+// the request is being handled.
+//
+// It reports whether ctx contained a Labeler. If it returns false the
+// attributes are not used.
+func AddMetricAttributes(ctx context.Context, attrs ...attribute.KeyValue) bool {
+	l, ok := LabelerFromContext(ctx)
+	l.Add(attrs...)
+	return ok
+}
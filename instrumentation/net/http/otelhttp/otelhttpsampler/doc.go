@@ -0,0 +1,12 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otelhttpsampler provides the Samplers of the OpenTelemetry trace SDK
+for the spans started by the otelhttp package.
+
+This package is in a separate module from the otelhttp instrumentation to
+isolate the dependency of the default SDK and not impose this as a transitive
+dependency for users.
+*/
+package otelhttpsampler // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpsampler"
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttpsampler // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpsampler"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DebugSampler returns a Sampler sampling the spans of the requests served by
+// an otelhttp Handler configured with otelhttp.WithDebugSamplingHeader, whose
+// header requests their span to be sampled. The sampling decision of the
+// other spans is delegated to base.
+//
+// The debug requests are sampled regardless of base, including when their
+// remote parent is not sampled and base is the default
+// ParentBased(AlwaysSample()) sampler of the SDK, e.g.
+//
+//	tp := sdktrace.NewTracerProvider(
+//		sdktrace.WithSampler(otelhttpsampler.DebugSampler(sdktrace.ParentBased(sdktrace.AlwaysSample()))),
+//	)
+func DebugSampler(base sdktrace.Sampler) sdktrace.Sampler {
+	return debugSampler{base: base}
+}
+
+type debugSampler struct {
+	base sdktrace.Sampler
+}
+
+func (s debugSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if otelhttp.IsDebugSampling(p.ParentContext) {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return s.base.ShouldSample(p)
+}
+
+func (s debugSampler) Description() string {
+	return fmt.Sprintf("DebugSampler{%s}", s.base.Description())
+}
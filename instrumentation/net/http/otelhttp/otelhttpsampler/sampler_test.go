@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttpsampler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpsampler"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDebugSampler(t *testing.T) {
+	const header = "X-Debug-Trace"
+	trusted := func(r *http.Request) bool { return r.RemoteAddr == "10.0.0.1:1234" }
+	testCases := []struct {
+		name        string
+		value       string
+		remoteAddr  string
+		wantSampled bool
+	}{
+		{name: "debug", value: "1", remoteAddr: "10.0.0.1:1234", wantSampled: true},
+		{name: "debug true", value: "true", remoteAddr: "10.0.0.1:1234", wantSampled: true},
+		{name: "no header", remoteAddr: "10.0.0.1:1234"},
+		{name: "disabled", value: "0", remoteAddr: "10.0.0.1:1234"},
+		{name: "untrusted", value: "1", remoteAddr: "192.0.2.1:1234"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			// The default sampler of the SDK does not sample the spans of
+			// the requests whose remote parent is not sampled.
+			provider := sdktrace.NewTracerProvider(
+				sdktrace.WithSampler(otelhttpsampler.DebugSampler(sdktrace.ParentBased(sdktrace.AlwaysSample()))),
+				sdktrace.WithSpanProcessor(sr),
+			)
+			var debug bool
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					debug = otelhttp.IsDebugSampling(r.Context())
+				}), "test_handler",
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithPropagators(propagation.TraceContext{}),
+				otelhttp.WithDebugSamplingHeader(header, trusted),
+			)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tc.remoteAddr
+			r.Header.Set("traceparent", "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-00")
+			if tc.value != "" {
+				r.Header.Set(header, tc.value)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.Equal(t, tc.wantSampled, debug, "request context")
+			if !tc.wantSampled {
+				assert.Empty(t, sr.Ended(), "span should not be sampled")
+				return
+			}
+			require.Len(t, sr.Ended(), 1, "span should be sampled")
+			span := sr.Ended()[0]
+			assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", span.SpanContext().TraceID().String())
+			assert.Contains(t, span.Attributes(), otelhttp.DebugSamplingKey.Bool(true))
+		})
+	}
+}
+
+func TestDebugSamplerDescription(t *testing.T) {
+	s := otelhttpsampler.DebugSampler(sdktrace.AlwaysSample())
+	assert.Equal(t, "DebugSampler{AlwaysOnSampler}", s.Description())
+}
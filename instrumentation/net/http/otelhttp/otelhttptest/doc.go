@@ -0,0 +1,17 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otelhttptest provides helpers to test the handlers instrumented with
+the otelhttp package.
+
+A Recorder wraps handlers with the otelhttp instrumentation using a tracer
+provider that records the ended spans, and generates deterministic trace and
+span IDs. This allows to assert on the spans of a handler without setting up
+the OpenTelemetry SDK.
+
+This package is in a separate module from the otelhttp instrumentation to
+isolate the dependency of the default SDK and not impose this as a transitive
+dependency for users.
+*/
+package otelhttptest // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttptest"
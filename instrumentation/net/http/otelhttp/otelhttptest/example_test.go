@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttptest_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttptest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+)
+
+func ExampleRecorder() {
+	rec := otelhttptest.NewRecorder()
+	h := rec.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}), "brew")
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/coffee", nil))
+
+	for _, span := range rec.Ended() {
+		fmt.Println(span.Name(), span.SpanContext().TraceID())
+		for _, kv := range span.Attributes() {
+			if kv.Key == semconv.HTTPStatusCodeKey {
+				fmt.Println(kv.Key, kv.Value.AsInt64())
+			}
+		}
+	}
+	// Output:
+	// brew 00000000000000000000000000000001
+	// http.status_code 418
+}
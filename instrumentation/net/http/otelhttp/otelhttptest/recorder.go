@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttptest // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttptest"
+
+import (
+	"context"
+	"encoding/binary"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Recorder records the spans of the handlers it instruments.
+type Recorder struct {
+	sr *tracetest.SpanRecorder
+	tp *sdktrace.TracerProvider
+}
+
+// NewRecorder returns a Recorder sampling all spans. The trace and span IDs
+// are generated in sequence, starting from 1, so they are the same on every
+// run of a test.
+func NewRecorder() *Recorder {
+	sr := tracetest.NewSpanRecorder()
+	return &Recorder{
+		sr: sr,
+		tp: sdktrace.NewTracerProvider(
+			sdktrace.WithSampler(sdktrace.AlwaysSample()),
+			sdktrace.WithIDGenerator(&sequentialIDGenerator{}),
+			sdktrace.WithSpanProcessor(sr),
+		),
+	}
+}
+
+// TracerProvider returns the TracerProvider recording the spans.
+func (r *Recorder) TracerProvider() trace.TracerProvider {
+	return r.tp
+}
+
+// NewHandler returns handler wrapped with otelhttp.NewHandler, configured
+// with opts and the TracerProvider of r.
+func (r *Recorder) NewHandler(handler http.Handler, operation string, opts ...otelhttp.Option) http.Handler {
+	// The tracer provider is set last so it is not overridden by opts.
+	opts = append(opts[:len(opts):len(opts)], otelhttp.WithTracerProvider(r.tp))
+	return otelhttp.NewHandler(handler, operation, opts...)
+}
+
+// Ended returns the spans ended since r was created, in the order they
+// ended.
+func (r *Recorder) Ended() []sdktrace.ReadOnlySpan {
+	return r.sr.Ended()
+}
+
+// sequentialIDGenerator generates trace and span IDs in sequence.
+type sequentialIDGenerator struct {
+	mu      sync.Mutex
+	traceID uint64
+	spanID  uint64
+}
+
+var _ sdktrace.IDGenerator = (*sequentialIDGenerator)(nil)
+
+// NewIDs returns the next trace ID and span ID.
+func (g *sequentialIDGenerator) NewIDs(context.Context) (trace.TraceID, trace.SpanID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.traceID++
+	var tid trace.TraceID
+	binary.BigEndian.PutUint64(tid[8:], g.traceID)
+	return tid, g.nextSpanID()
+}
+
+// NewSpanID returns the next span ID.
+func (g *sequentialIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.nextSpanID()
+}
+
+func (g *sequentialIDGenerator) nextSpanID() trace.SpanID {
+	g.spanID++
+	var sid trace.SpanID
+	binary.BigEndian.PutUint64(sid[:], g.spanID)
+	return sid
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttptest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestRecorderDeterministicIDs(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		rec := NewRecorder()
+		h := rec.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, child := rec.TracerProvider().Tracer("test").Start(r.Context(), "child")
+			child.End()
+		}), "server")
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		spans := rec.Ended()
+		require.Len(t, spans, 4)
+		want := []struct{ traceID, spanID string }{
+			{"00000000000000000000000000000001", "0000000000000002"}, // child
+			{"00000000000000000000000000000001", "0000000000000001"}, // server
+			{"00000000000000000000000000000002", "0000000000000004"}, // child
+			{"00000000000000000000000000000002", "0000000000000003"}, // server
+		}
+		for j, s := range spans {
+			assert.Equal(t, want[j].traceID, s.SpanContext().TraceID().String(), "trace ID of span %d", j)
+			assert.Equal(t, want[j].spanID, s.SpanContext().SpanID().String(), "span ID of span %d", j)
+		}
+	}
+}
+
+func TestRecorderTracerProviderOverride(t *testing.T) {
+	rec := NewRecorder()
+	h := rec.NewHandler(
+		http.NotFoundHandler(), "server",
+		otelhttp.WithTracerProvider(noop.NewTracerProvider()),
+	)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Len(t, rec.Ended(), 1, "spans should be recorded by the recorder")
+}
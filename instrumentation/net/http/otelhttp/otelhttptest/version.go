@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttptest // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttptest"
+
+// Version is the current release version of the otelhttp testing helpers.
+func Version() string {
+	return "0.51.0"
+	// This string is updated by the pre_release.sh script during release
+}
@@ -0,0 +1,12 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package otelhttpview provides the Views of the OpenTelemetry metric SDK for
+the metrics recorded by the otelhttp package.
+
+This package is in a separate module from the otelhttp instrumentation to
+isolate the dependency of the default SDK and not impose this as a transitive
+dependency for users.
+*/
+package otelhttpview // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpview"
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttpview // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpview"
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+// requestDurations are the names of the request duration histograms of the
+// Handler and the Transport, in milliseconds, and their names in the stable
+// HTTP semantic conventions.
+var requestDurations = map[string]struct{}{
+	"http.server.duration":         {},
+	"http.client.duration":         {},
+	"http.server.request.duration": {},
+	"http.client.request.duration": {},
+}
+
+// DurationView returns a View aggregating the request duration histograms of
+// the otelhttp Handler and Transport with the explicit bucket boundaries. The
+// default boundaries of the SDK are too coarse for APIs responding in less
+// than a millisecond.
+//
+// The View is registered with the MeterProvider, e.g.
+//
+//	mp := metric.NewMeterProvider(
+//		metric.WithReader(reader),
+//		metric.WithView(otelhttpview.DurationView([]float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10})),
+//	)
+//
+// The instruments are matched by their exact names: the queue, handler and
+// Server-Sent Events duration histograms, like the other instruments, are
+// left to the other Views.
+func DurationView(boundaries []float64) metric.View {
+	boundaries = append([]float64(nil), boundaries...)
+	return func(i metric.Instrument) (metric.Stream, bool) {
+		if i.Scope.Name != otelhttp.ScopeName {
+			return metric.Stream{}, false
+		}
+		if _, ok := requestDurations[i.Name]; !ok {
+			return metric.Stream{}, false
+		}
+		return metric.Stream{
+			Name:        i.Name,
+			Description: i.Description,
+			Unit:        i.Unit,
+			Aggregation: metric.AggregationExplicitBucketHistogram{
+				Boundaries: boundaries,
+			},
+		}, true
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttpview_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/otelhttpview"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestDurationView(t *testing.T) {
+	boundaries := []float64{0.1, 0.5, 1}
+	view := otelhttpview.DurationView(boundaries)
+	scope := instrumentation.Scope{Name: otelhttp.ScopeName, Version: otelhttp.Version()}
+
+	// The boundaries of the caller are copied.
+	boundaries[0] = 42
+	want := metric.AggregationExplicitBucketHistogram{Boundaries: []float64{0.1, 0.5, 1}}
+
+	for _, name := range []string{
+		"http.server.duration",
+		"http.client.duration",
+		"http.server.request.duration",
+		"http.client.request.duration",
+	} {
+		stream, ok := view(metric.Instrument{Name: name, Unit: "ms", Scope: scope})
+		if assert.True(t, ok, name) {
+			assert.Equal(t, name, stream.Name)
+			assert.Equal(t, "ms", stream.Unit)
+			assert.Equal(t, want, stream.Aggregation)
+		}
+	}
+
+	for _, name := range []string{
+		"http.server.queue.duration",
+		"http.server.handler.duration",
+		"http.server.sse.duration",
+		"http.server.request.size",
+		"http.client.response.size",
+	} {
+		_, ok := view(metric.Instrument{Name: name, Scope: scope})
+		assert.False(t, ok, name)
+	}
+
+	// The duration instruments of other instrumentation libraries are not
+	// matched.
+	_, ok := view(metric.Instrument{Name: "http.server.duration", Scope: instrumentation.Scope{Name: "other"}})
+	assert.False(t, ok)
+}
+
+func TestDurationViewHandler(t *testing.T) {
+	boundaries := []float64{0.1, 0.5, 1}
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(
+		metric.WithReader(reader),
+		metric.WithView(otelhttpview.DurationView(boundaries)),
+	)
+
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		"test_handler",
+		otelhttp.WithMeterProvider(meterProvider),
+	)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost/", nil))
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var found bool
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "http.server.duration" {
+			continue
+		}
+		found = true
+		hist, ok := m.Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, hist.DataPoints, 1)
+		assert.Equal(t, boundaries, hist.DataPoints[0].Bounds)
+	}
+	assert.True(t, found, "http.server.duration not recorded")
+}
@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+import "strings"
+
+// patternRoute returns the path of an http.ServeMux pattern. Patterns have
+// the form "[METHOD ][HOST]/[PATH]", the returned route is "/[PATH]". An
+// empty string is returned if pattern does not contain a path.
+func patternRoute(pattern string) string {
+	if i := strings.IndexByte(pattern, '/'); i >= 0 {
+		return pattern[i:]
+	}
+	return ""
+}
@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !go1.23
+
+package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+import "net/http"
+
+// requestPattern returns the http.ServeMux pattern that matched r.
+//
+// The pattern is only exposed by the http.Request starting with Go 1.23, an
+// empty string is always returned for earlier versions.
+func requestPattern(*http.Request) string {
+	return ""
+}
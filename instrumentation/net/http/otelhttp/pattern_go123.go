@@ -0,0 +1,13 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build go1.23
+
+package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+import "net/http"
+
+// requestPattern returns the http.ServeMux pattern that matched r.
+func requestPattern(r *http.Request) string {
+	return r.Pattern
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type requestIDContextKeyType int
+
+const requestIDContextKey requestIDContextKeyType = 0
+
+func injectRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, id)
+}
+
+// RequestIDFromContext returns the ID of the request served by a Handler
+// configured with WithRequestIDHeader, read from the request header or
+// generated. It returns an empty string if the request has no ID.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
+
+// newRequestID returns a random request ID of 32 hexadecimal characters.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		handleErr(err)
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
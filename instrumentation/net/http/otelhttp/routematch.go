@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+// UnmatchedRoute is the http.route recorded for the requests served by a
+// NotFoundHandler, which did not match any route.
+const UnmatchedRoute = "<unmatched>"
+
+// routeMatch holds whether a request was served by a NotFoundHandler.
+type routeMatch struct {
+	unmatched atomic.Bool
+}
+
+type routeMatchContextKeyType int
+
+const routeMatchContextKey routeMatchContextKeyType = 0
+
+func injectRouteMatch(ctx context.Context, m *routeMatch) context.Context {
+	return context.WithValue(ctx, routeMatchContextKey, m)
+}
+
+// NotFoundHandler returns a handler serving the requests with h and marking
+// them as not matched by any route. Set it as the handler of the requests
+// that do not match any route of the router wrapped with NewHandler, for
+// example as the handler of the "/" pattern of an http.ServeMux, so that
+// their span and metrics are recorded with the UnmatchedRoute http.route and
+// the http.route.matched attribute set to false. This tells the genuine 404
+// responses apart from the ones of the matched routes, which are recorded
+// without the attribute.
+//
+// If h is nil, http.NotFoundHandler is used.
+func NotFoundHandler(h http.Handler) http.Handler {
+	if h == nil {
+		h = http.NotFoundHandler()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m, ok := r.Context().Value(routeMatchContextKey).(*routeMatch); ok {
+			m.unmatched.Store(true)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// sseContentType is the media type of Server-Sent Events responses.
+const sseContentType = "text/event-stream"
+
+// sseStream counts the events of a Server-Sent Events response, see
+// WithSSEEvents.
+type sseStream struct {
+	// start is when the response header was written.
+	start time.Time
+	// flushes is the number of times the response was flushed.
+	flushes atomic.Int64
+	// events is the number of events marked with SSEEvent.
+	events atomic.Int64
+}
+
+// count returns the number of events sent: the ones marked with SSEEvent if
+// any, the number of flushes otherwise.
+func (s *sseStream) count() int64 {
+	if n := s.events.Load(); n > 0 {
+		return n
+	}
+	return s.flushes.Load()
+}
+
+// isSSE returns true if h is the header of a Server-Sent Events response.
+func isSSE(h http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	return err == nil && mediaType == sseContentType
+}
+
+type sseStreamContextKeyType int
+
+const sseStreamContextKey sseStreamContextKeyType = 0
+
+func injectSSEStream(ctx context.Context, s *sseStream) context.Context {
+	return context.WithValue(ctx, sseStreamContextKey, s)
+}
+
+// SSEEvent marks that an event of the Server-Sent Events response of the
+// request whose context is ctx was sent. By default, the Handler configured
+// with WithSSEEvents counts an event every time the response is flushed.
+// Once SSEEvent is called for a request, only the marked events are counted,
+// this is useful when several events are sent per flush, or when the
+// response is flushed without sending events (e.g. comments used as
+// keepalives).
+//
+// It reports whether ctx belongs to a request handled by the instrumentation
+// with WithSSEEvents. If it returns false the event is not counted.
+func SSEEvent(ctx context.Context) bool {
+	s, ok := ctx.Value(sseStreamContextKey).(*sseStream)
+	if !ok {
+		return false
+	}
+	s.events.Add(1)
+	return true
+}
@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 func TestConvenienceWrappers(t *testing.T) {
@@ -75,6 +76,55 @@ func TestConvenienceWrappers(t *testing.T) {
 	assert.Equal(t, "HTTP POST", spans[3].Name())
 }
 
+func TestNewClient(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := otelhttp.NewClient(
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithClientSpanNameFormatter(func(r *http.Request) string {
+			return "client " + r.Method + " " + r.URL.Path
+		}),
+	)
+
+	res, err := client.Get(ts.URL + "/hello")
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "client GET /hello", spans[0].Name())
+	assert.Equal(t, oteltrace.SpanKindClient, spans[0].SpanKind())
+}
+
+func TestNewClientNilSpanNameFormatter(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := otelhttp.NewClient(
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithClientSpanNameFormatter(nil),
+	)
+
+	res, err := client.Get(ts.URL + "/hello")
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "HTTP GET", spans[0].Name())
+}
+
 func TestClientWithTraceContext(t *testing.T) {
 	sr := tracetest.NewSpanRecorder()
 	provider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
@@ -11,7 +11,9 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -462,6 +464,167 @@ func TestSpanStatus(t *testing.T) {
 	}
 }
 
+func TestHandlerDropFastSpans(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sleep    time.Duration
+		status   int
+		wantDrop bool
+	}{
+		{"fast", 0, http.StatusOK, true},
+		{"fast client error", 0, http.StatusNotFound, true},
+		{"fast server error", 0, http.StatusInternalServerError, false},
+		{"slow", 100 * time.Millisecond, http.StatusOK, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(tc.sleep)
+					w.WriteHeader(tc.status)
+				}), "test_handler",
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithDropFastSpans(50*time.Millisecond),
+			)
+
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+			require.Len(t, sr.Ended(), 1, "should emit a span")
+			attrs := sr.Ended()[0].Attributes()
+			if tc.wantDrop {
+				assert.Contains(t, attrs, otelhttp.DropSpanKey.Bool(true))
+			} else {
+				for _, kv := range attrs {
+					assert.NotEqual(t, otelhttp.DropSpanKey, kv.Key)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerDropFastSpansDisabled(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		"test_handler",
+		otelhttp.WithTracerProvider(provider),
+	)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	require.Len(t, sr.Ended(), 1, "should emit a span")
+	for _, kv := range sr.Ended()[0].Attributes() {
+		assert.NotEqual(t, otelhttp.DropSpanKey, kv.Key)
+	}
+}
+
+func TestHandlerErrorRequestBodyCapture(t *testing.T) {
+	const body = "0123456789abcdef"
+	testCases := []struct {
+		name     string
+		status   int
+		wantBody string
+	}{
+		{"server error", http.StatusInternalServerError, "0123456789"},
+		{"client error", http.StatusBadRequest, ""},
+		{"ok", http.StatusOK, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			var read []byte
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var err error
+					read, err = io.ReadAll(r.Body)
+					assert.NoError(t, err)
+					w.WriteHeader(tc.status)
+				}), "test_handler",
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithErrorRequestBodyCapture(10),
+			)
+
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", strings.NewReader(body)))
+
+			assert.Equal(t, body, string(read), "handler should read the full body")
+			require.Len(t, sr.Ended(), 1, "should emit a span")
+			attrs := sr.Ended()[0].Attributes()
+			if tc.wantBody != "" {
+				assert.Contains(t, attrs, otelhttp.RequestBodyKey.String(tc.wantBody))
+			} else {
+				for _, kv := range attrs {
+					assert.NotEqual(t, otelhttp.RequestBodyKey, kv.Key)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerRequestIDHeader(t *testing.T) {
+	const header = "X-Request-ID"
+	testCases := []struct {
+		name      string
+		requestID string
+		generate  bool
+		// wantGenerated is true if an ID is expected to be generated.
+		wantGenerated bool
+	}{
+		{name: "present", requestID: "abc123"},
+		{name: "present with generation", requestID: "abc123", generate: true},
+		{name: "generated", generate: true, wantGenerated: true},
+		{name: "absent"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			opts := []otelhttp.Option{
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithRequestIDHeader(header),
+			}
+			if tc.generate {
+				opts = append(opts, otelhttp.WithRequestIDGeneration())
+			}
+			var ctxID string
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					ctxID = otelhttp.RequestIDFromContext(r.Context())
+				}), "test_handler", opts...,
+			)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.requestID != "" {
+				r.Header.Set(header, tc.requestID)
+			}
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, r)
+
+			wantID := tc.requestID
+			if tc.wantGenerated {
+				wantID = rr.Header().Get(header)
+				assert.Len(t, wantID, 32, "should generate an ID in the response header")
+			} else {
+				assert.Empty(t, rr.Header().Get(header), "should not set the response header")
+			}
+			assert.Equal(t, wantID, ctxID, "request context ID")
+
+			require.Len(t, sr.Ended(), 1, "should emit a span")
+			attrs := sr.Ended()[0].Attributes()
+			if wantID != "" {
+				assert.Contains(t, attrs, otelhttp.RequestIDKey.String(wantID))
+			} else {
+				for _, kv := range attrs {
+					assert.NotEqual(t, otelhttp.RequestIDKey, kv.Key)
+				}
+			}
+		})
+	}
+}
+
 func TestWithRouteTag(t *testing.T) {
 	route := "/some/route"
 
@@ -528,3 +691,603 @@ func TestWithRouteTag(t *testing.T) {
 		}
 	}
 }
+
+func TestHandlerWithServerName(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   string
+		wantHost string
+		wantPort int
+	}{
+		{name: "HostAndPort", server: "example.com:8443", wantHost: "example.com", wantPort: 8443},
+		{name: "HostOnly", server: "example.com", wantHost: "example.com", wantPort: 8080},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			reader := metric.NewManualReader()
+			meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+				"test_handler",
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithMeterProvider(meterProvider),
+				otelhttp.WithServerName(tt.server),
+			)
+
+			// The Host header is set by a reverse proxy and does not identify
+			// the server.
+			r := httptest.NewRequest(http.MethodGet, "http://proxy.internal:8080/", nil)
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			spans := spanRecorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Contains(t, spans[0].Attributes(), semconv.NetHostName(tt.wantHost))
+			assert.Contains(t, spans[0].Attributes(), semconv.NetHostPort(tt.wantPort))
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				var attrs attribute.Set
+				switch d := m.Data.(type) {
+				case metricdata.Sum[int64]:
+					require.Len(t, d.DataPoints, 1)
+					attrs = d.DataPoints[0].Attributes
+				case metricdata.Histogram[float64]:
+					require.Len(t, d.DataPoints, 1)
+					attrs = d.DataPoints[0].Attributes
+				default:
+					t.Fatalf("unexpected data type %T", d)
+				}
+				host, ok := attrs.Value(semconv.NetHostNameKey)
+				assert.True(t, ok, m.Name)
+				assert.Equal(t, tt.wantHost, host.AsString(), m.Name)
+				port, ok := attrs.Value(semconv.NetHostPortKey)
+				assert.True(t, ok, m.Name)
+				assert.Equal(t, int64(tt.wantPort), port.AsInt64(), m.Name)
+			}
+		})
+	}
+}
+
+func TestHandlerSemconvSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []otelhttp.Option
+		wantKeys []attribute.Key
+		noKeys   []attribute.Key
+	}{
+		{
+			name:     "Default",
+			wantKeys: []attribute.Key{"http.method", "http.status_code", "net.host.name", "http.target", "http.route"},
+			noKeys:   []attribute.Key{"http.request.method", "http.response.status_code", "server.address", "url.path"},
+		},
+		{
+			name:     "1.20.0",
+			opts:     []otelhttp.Option{otelhttp.WithSemconvSchema("1.20.0")},
+			wantKeys: []attribute.Key{"http.method", "http.status_code", "net.host.name", "http.target", "http.route"},
+			noKeys:   []attribute.Key{"http.request.method", "http.response.status_code", "server.address", "url.path"},
+		},
+		{
+			name:     "1.24.0",
+			opts:     []otelhttp.Option{otelhttp.WithSemconvSchema("1.24.0")},
+			wantKeys: []attribute.Key{"http.request.method", "http.response.status_code", "server.address", "url.path", "http.route"},
+			noKeys:   []attribute.Key{"http.method", "http.status_code", "net.host.name", "http.target"},
+		},
+		{
+			name:     "Unsupported",
+			opts:     []otelhttp.Option{otelhttp.WithSemconvSchema("0.1.0")},
+			wantKeys: []attribute.Key{"http.method", "http.status_code", "net.host.name", "http.target", "http.route"},
+			noKeys:   []attribute.Key{"http.request.method", "http.response.status_code", "server.address", "url.path"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+			h := otelhttp.NewHandler(
+				otelhttp.WithRouteTag("/items/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})),
+				"test_handler",
+				append([]otelhttp.Option{otelhttp.WithTracerProvider(provider)}, tt.opts...)...,
+			)
+			r := httptest.NewRequest(http.MethodGet, "http://example.com/items/42", nil)
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			spans := spanRecorder.Ended()
+			require.Len(t, spans, 1)
+			keys := make(map[attribute.Key]bool)
+			for _, kv := range spans[0].Attributes() {
+				keys[kv.Key] = true
+			}
+			for _, k := range tt.wantKeys {
+				assert.True(t, keys[k], "missing %s", k)
+			}
+			for _, k := range tt.noKeys {
+				assert.False(t, keys[k], "unexpected %s", k)
+			}
+		})
+	}
+}
+
+func TestHandlerAddMetricAttributes(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := io.WriteString(w, "hello"); err != nil {
+				t.Fatal(err)
+			}
+			// Added after the response was started, the attribute is still
+			// included as metrics are recorded at the end of the request.
+			ok := otelhttp.AddMetricAttributes(r.Context(), attribute.String("tenant", "acme"))
+			assert.True(t, ok, "request context should contain a Labeler")
+		}),
+		"test_handler",
+		otelhttp.WithMeterProvider(meterProvider),
+	)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var found bool
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "http.server.duration" {
+			continue
+		}
+		found = true
+		hist, ok := m.Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, hist.DataPoints, 1)
+		v, ok := hist.DataPoints[0].Attributes.Value("tenant")
+		assert.True(t, ok, "missing tenant attribute")
+		assert.Equal(t, "acme", v.AsString())
+	}
+	assert.True(t, found, "http.server.duration not recorded")
+}
+
+type deploymentColorKey struct{}
+
+func TestHandlerMetricAttributesFromContext(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Attributes of the request take precedence.
+			if r.URL.Query().Get("override") != "" {
+				otelhttp.AddMetricAttributes(r.Context(), attribute.String("deployment.color", "green"))
+			}
+		}),
+		"test_handler",
+		otelhttp.WithMeterProvider(meterProvider),
+		otelhttp.WithMetricAttributesFromContext(func(ctx context.Context) []attribute.KeyValue {
+			color, _ := ctx.Value(deploymentColorKey{}).(string)
+			return []attribute.KeyValue{attribute.String("deployment.color", color)}
+		}),
+	)
+	// The context value is set by an outer middleware.
+	serve := func(target string) {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r = r.WithContext(context.WithValue(r.Context(), deploymentColorKey{}, "blue"))
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	serve("/")
+	serve("/?override=1")
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var found bool
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "http.server.duration" {
+			continue
+		}
+		found = true
+		hist, ok := m.Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, hist.DataPoints, 2)
+		var colors []string
+		for _, dp := range hist.DataPoints {
+			v, ok := dp.Attributes.Value("deployment.color")
+			require.True(t, ok, "missing deployment.color attribute")
+			colors = append(colors, v.AsString())
+		}
+		assert.ElementsMatch(t, []string{"blue", "green"}, colors)
+	}
+	assert.True(t, found, "http.server.duration not recorded")
+}
+
+func TestHandlerConcurrentMetricAttributes(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			otelhttp.AddMetricAttributes(r.Context(), attribute.String("tenant", r.URL.Query().Get("tenant")))
+		}),
+		"test_handler",
+		otelhttp.WithMeterProvider(meterProvider),
+	)
+
+	const n = 50
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := httptest.NewRequest(http.MethodGet, "/?tenant="+strconv.Itoa(i), nil)
+			h.ServeHTTP(httptest.NewRecorder(), r)
+		}(i)
+	}
+	wg.Wait()
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var found bool
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "http.server.duration" {
+			continue
+		}
+		found = true
+		hist, ok := m.Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		// Each request is recorded with its own tenant, and only once.
+		require.Len(t, hist.DataPoints, n)
+		tenants := make(map[string]bool, n)
+		for _, dp := range hist.DataPoints {
+			assert.Equal(t, uint64(1), dp.Count)
+			assert.Equal(t, 7, dp.Attributes.Len(), "attributes: %v", dp.Attributes.ToSlice())
+			v, ok := dp.Attributes.Value("tenant")
+			require.True(t, ok, "missing tenant attribute")
+			tenants[v.AsString()] = true
+		}
+		assert.Len(t, tenants, n)
+	}
+	assert.True(t, found, "http.server.duration not recorded")
+}
+
+func TestAddMetricAttributesWithoutLabeler(t *testing.T) {
+	assert.False(t, otelhttp.AddMetricAttributes(context.Background(), attribute.String("tenant", "acme")))
+}
+
+func TestHandlerMarkHandlerStart(t *testing.T) {
+	const wait = 10 * time.Millisecond
+
+	histograms := func(t *testing.T, mark bool) map[string]metricdata.HistogramDataPoint[float64] {
+		reader := metric.NewManualReader()
+		meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+		var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if mark {
+				assert.True(t, otelhttp.MarkHandlerStart(r.Context()))
+			}
+			time.Sleep(wait)
+		})
+		// A middleware running before the handler.
+		queue := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(wait)
+			handler.ServeHTTP(w, r)
+		})
+		h := otelhttp.NewHandler(queue, "test_handler", otelhttp.WithMeterProvider(meterProvider))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		rm := metricdata.ResourceMetrics{}
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+
+		got := make(map[string]metricdata.HistogramDataPoint[float64])
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			if hist, ok := m.Data.(metricdata.Histogram[float64]); ok {
+				require.Len(t, hist.DataPoints, 1, m.Name)
+				got[m.Name] = hist.DataPoints[0]
+			}
+		}
+		return got
+	}
+
+	t.Run("Marked", func(t *testing.T) {
+		got := histograms(t, true)
+		require.Contains(t, got, "http.server.duration")
+		require.Contains(t, got, "http.server.queue.duration")
+		require.Contains(t, got, "http.server.handler.duration")
+
+		total := got["http.server.duration"].Sum
+		queue := got["http.server.queue.duration"].Sum
+		handler := got["http.server.handler.duration"].Sum
+		assert.GreaterOrEqual(t, queue, float64(wait.Milliseconds()))
+		assert.GreaterOrEqual(t, handler, float64(wait.Milliseconds()))
+		assert.InDelta(t, total, queue+handler, 0.001)
+	})
+
+	t.Run("NotMarked", func(t *testing.T) {
+		got := histograms(t, false)
+		assert.Contains(t, got, "http.server.duration")
+		assert.NotContains(t, got, "http.server.queue.duration")
+		assert.NotContains(t, got, "http.server.handler.duration")
+	})
+}
+
+func TestMarkHandlerStartWithoutHandler(t *testing.T) {
+	assert.False(t, otelhttp.MarkHandlerStart(context.Background()))
+}
+
+func TestHandlerSSEEvents(t *testing.T) {
+	const n = 5
+
+	collect := func(t *testing.T, handler http.HandlerFunc, opts ...otelhttp.Option) map[string]metricdata.Aggregation {
+		reader := metric.NewManualReader()
+		meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+		opts = append([]otelhttp.Option{otelhttp.WithMeterProvider(meterProvider)}, opts...)
+		h := otelhttp.NewHandler(handler, "test_handler", opts...)
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events", nil))
+
+		rm := metricdata.ResourceMetrics{}
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+
+		got := make(map[string]metricdata.Aggregation)
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			got[m.Name] = m.Data
+		}
+		return got
+	}
+	stream := func(explicit bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+			flusher, ok := w.(http.Flusher)
+			require.True(t, ok)
+			for i := 0; i < n; i++ {
+				_, _ = fmt.Fprintf(w, "data: %d\n\n", i)
+				if explicit {
+					assert.True(t, otelhttp.SSEEvent(r.Context()))
+					// A second event sent with the same flush.
+					_, _ = fmt.Fprintf(w, "data: %d\n\n", i)
+					assert.True(t, otelhttp.SSEEvent(r.Context()))
+				}
+				flusher.Flush()
+			}
+		}
+	}
+	events := func(t *testing.T, got map[string]metricdata.Aggregation) int64 {
+		require.Contains(t, got, "http.server.sse.events")
+		sum, ok := got["http.server.sse.events"].(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, sum.DataPoints, 1)
+		require.Contains(t, got, "http.server.sse.duration")
+		hist, ok := got["http.server.sse.duration"].(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, hist.DataPoints, 1)
+		assert.Equal(t, uint64(1), hist.DataPoints[0].Count)
+		return sum.DataPoints[0].Value
+	}
+
+	t.Run("Flushes", func(t *testing.T) {
+		got := collect(t, stream(false), otelhttp.WithSSEEvents())
+		assert.Equal(t, int64(n), events(t, got))
+	})
+
+	t.Run("Explicit", func(t *testing.T) {
+		got := collect(t, stream(true), otelhttp.WithSSEEvents())
+		assert.Equal(t, int64(2*n), events(t, got))
+	})
+
+	t.Run("NotSSE", func(t *testing.T) {
+		got := collect(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("hello"))
+			w.(http.Flusher).Flush()
+		}, otelhttp.WithSSEEvents())
+		assert.NotContains(t, got, "http.server.sse.events")
+		assert.NotContains(t, got, "http.server.sse.duration")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		got := collect(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.False(t, otelhttp.SSEEvent(r.Context()))
+			stream(false)(w, r)
+		})
+		assert.NotContains(t, got, "http.server.sse.events")
+		assert.NotContains(t, got, "http.server.sse.duration")
+	})
+}
+
+func BenchmarkHandlerServeHTTP(b *testing.B) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	mp := metric.NewMeterProvider(metric.WithReader(metric.NewManualReader()))
+
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}), "test_handler",
+		otelhttp.WithTracerProvider(tp),
+		otelhttp.WithMeterProvider(mp),
+	)
+	r, err := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+}
+
+func TestHandlerUserAgent(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []otelhttp.Option
+		want bool
+	}{
+		{name: "Default", want: true},
+		{name: "Record", opts: []otelhttp.Option{otelhttp.WithUserAgent(true)}, want: true},
+		{name: "Omit", opts: []otelhttp.Option{otelhttp.WithUserAgent(false)}, want: false},
+		{
+			name: "Omit1.24.0",
+			opts: []otelhttp.Option{otelhttp.WithUserAgent(false), otelhttp.WithSemconvSchema("1.24.0")},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+				"test_handler",
+				append([]otelhttp.Option{otelhttp.WithTracerProvider(provider)}, tt.opts...)...,
+			)
+			r := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+			r.Header.Set("User-Agent", "test-agent/1.0")
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			spans := spanRecorder.Ended()
+			require.Len(t, spans, 1)
+			var found bool
+			for _, kv := range spans[0].Attributes() {
+				if kv.Key == "user_agent.original" {
+					found = true
+					assert.Equal(t, "test-agent/1.0", kv.Value.AsString())
+				}
+			}
+			assert.Equal(t, tt.want, found)
+		})
+	}
+}
+
+func TestHandlerCancellationStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []otelhttp.Option
+		cancel       bool
+		wantStatus   codes.Code
+		wantCanceled bool
+	}{
+		{name: "Default", cancel: true, wantStatus: codes.Error},
+		{
+			name:         "Unset",
+			opts:         []otelhttp.Option{otelhttp.WithCancellationStatus(codes.Unset)},
+			cancel:       true,
+			wantStatus:   codes.Unset,
+			wantCanceled: true,
+		},
+		{
+			name:         "Error",
+			opts:         []otelhttp.Option{otelhttp.WithCancellationStatus(codes.Error)},
+			cancel:       true,
+			wantStatus:   codes.Error,
+			wantCanceled: true,
+		},
+		{
+			name:       "NotCanceled",
+			opts:       []otelhttp.Option{otelhttp.WithCancellationStatus(codes.Unset)},
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if tt.cancel {
+						// The client goes away while the request is served.
+						cancel()
+						<-r.Context().Done()
+					}
+					w.WriteHeader(http.StatusInternalServerError)
+				}),
+				"test_handler",
+				append([]otelhttp.Option{otelhttp.WithTracerProvider(provider)}, tt.opts...)...,
+			)
+			r := httptest.NewRequest(http.MethodGet, "http://example.com/", nil).WithContext(ctx)
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			spans := spanRecorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantStatus, spans[0].Status().Code)
+			if tt.wantCanceled {
+				assert.Contains(t, spans[0].Attributes(), otelhttp.CanceledKey.Bool(true))
+			} else {
+				for _, kv := range spans[0].Attributes() {
+					assert.NotEqual(t, otelhttp.CanceledKey, kv.Key)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerNotFoundHandler(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	mux := http.NewServeMux()
+	mux.Handle("/items", http.NotFoundHandler())
+	mux.Handle("/", otelhttp.NotFoundHandler(nil))
+	h := otelhttp.NewHandler(mux, "test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithMeterProvider(meterProvider),
+	)
+
+	for _, path := range []string{"/missing", "/items"} {
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil))
+		assert.Equal(t, http.StatusNotFound, rr.Code, path)
+	}
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 2)
+	// The unmatched path is recorded with the placeholder route.
+	assert.Equal(t, "GET "+otelhttp.UnmatchedRoute, spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPRoute(otelhttp.UnmatchedRoute))
+	assert.Contains(t, spans[0].Attributes(), otelhttp.RouteMatchedKey.Bool(false))
+	// The matched route returning a 404 is not.
+	for _, kv := range spans[1].Attributes() {
+		assert.NotEqual(t, otelhttp.RouteMatchedKey, kv.Key)
+		assert.NotEqual(t, attribute.String(string(semconv.HTTPRouteKey), otelhttp.UnmatchedRoute), kv)
+	}
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	var duration metricdata.Histogram[float64]
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == "http.server.duration" {
+			var ok bool
+			duration, ok = m.Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+		}
+	}
+	require.Len(t, duration.DataPoints, 2)
+	var unmatched int
+	for _, dp := range duration.DataPoints {
+		if v, ok := dp.Attributes.Value(otelhttp.RouteMatchedKey); ok {
+			assert.False(t, v.AsBool())
+			route, _ := dp.Attributes.Value(semconv.HTTPRouteKey)
+			assert.Equal(t, otelhttp.UnmatchedRoute, route.AsString())
+			unmatched++
+		}
+	}
+	assert.Equal(t, 1, unmatched)
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build go1.23
+
+//go:debug httpmuxgo121=0
+
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+)
+
+func TestHandlerServeMuxPattern(t *testing.T) {
+	testCases := []struct {
+		name         string
+		opts         []otelhttp.Option
+		wantSpanName string
+		wantRoute    bool
+	}{
+		{
+			name:         "default",
+			wantSpanName: "GET /items/{id}",
+			wantRoute:    true,
+		},
+		{
+			name: "custom span name formatter",
+			opts: []otelhttp.Option{
+				otelhttp.WithSpanNameFormatter(func(string, *http.Request) string { return "custom" }),
+			},
+			wantSpanName: "custom",
+			wantRoute:    true,
+		},
+		{
+			name:         "without serve mux pattern",
+			opts:         []otelhttp.Option{otelhttp.WithoutServeMuxPattern()},
+			wantSpanName: "test_handler",
+			wantRoute:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			reader := metric.NewManualReader()
+			mp := metric.NewMeterProvider(metric.WithReader(reader))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "42", r.PathValue("id"))
+			})
+
+			h := otelhttp.NewHandler(mux, "test_handler", append([]otelhttp.Option{
+				otelhttp.WithTracerProvider(tp),
+				otelhttp.WithMeterProvider(mp),
+			}, tc.opts...)...)
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/42", nil))
+
+			want := semconv.HTTPRoute("/items/{id}")
+
+			require.Len(t, sr.Ended(), 1)
+			span := sr.Ended()[0]
+			assert.Equal(t, tc.wantSpanName, span.Name())
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			var duration metricdata.Histogram[float64]
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				if m.Name == "http.server.duration" {
+					duration = m.Data.(metricdata.Histogram[float64])
+				}
+			}
+			require.Len(t, duration.DataPoints, 1)
+
+			if tc.wantRoute {
+				assert.Contains(t, span.Attributes(), want)
+				assert.Contains(t, duration.DataPoints[0].Attributes.ToSlice(), want)
+			} else {
+				assert.NotContains(t, span.Attributes(), want)
+				assert.NotContains(t, duration.DataPoints[0].Attributes.ToSlice(), want)
+			}
+		})
+	}
+}
+
+func TestHandlerRouteAugmentor(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(http.ResponseWriter, *http.Request) {})
+
+	h := otelhttp.NewHandler(mux, "test_handler",
+		otelhttp.WithTracerProvider(tp),
+		otelhttp.WithMeterProvider(mp),
+		otelhttp.WithRouteAugmentor(func(r *http.Request, route string) string {
+			if r.Header.Get("Accept") == "application/vnd.example.v2+json" {
+				return route + "#v2"
+			}
+			return route
+		}),
+	)
+	r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	r.Header.Set("Accept", "application/vnd.example.v2+json")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "GET /users/{id}#v2", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPRoute("/users/{id}#v2"))
+	assert.Equal(t, "GET /users/{id}", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), semconv.HTTPRoute("/users/{id}"))
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	var duration metricdata.Histogram[float64]
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == "http.server.duration" {
+			duration = m.Data.(metricdata.Histogram[float64])
+		}
+	}
+	require.Len(t, duration.DataPoints, 2)
+	var routes []string
+	for _, dp := range duration.DataPoints {
+		v, ok := dp.Attributes.Value(semconv.HTTPRouteKey)
+		require.True(t, ok)
+		routes = append(routes, v.AsString())
+	}
+	assert.ElementsMatch(t, []string{"/users/{id}#v2", "/users/{id}"}, routes)
+}
@@ -6,6 +6,7 @@ package test
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"io"
 	"net"
 	"net/http"
@@ -476,3 +477,124 @@ func assertClientScopeMetrics(t *testing.T, sm metricdata.ScopeMetrics, attrs at
 	}
 	metricdatatest.AssertEqual(t, want, sm.Metrics[2], metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
 }
+
+func TestTransportTLSAttributes(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	newTLSServer := func(http2 bool) *httptest.Server {
+		ts := httptest.NewUnstartedServer(handler)
+		ts.EnableHTTP2 = http2
+		ts.StartTLS()
+		return ts
+	}
+
+	tests := []struct {
+		name      string
+		server    func() *httptest.Server
+		opts      []otelhttp.Option
+		wantTLS   bool
+		wantProto int
+	}{
+		{
+			name:      "TLS",
+			server:    func() *httptest.Server { return newTLSServer(false) },
+			opts:      []otelhttp.Option{otelhttp.WithTLSAttributes()},
+			wantTLS:   true,
+			wantProto: 1,
+		},
+		{
+			name:      "HTTP2",
+			server:    func() *httptest.Server { return newTLSServer(true) },
+			opts:      []otelhttp.Option{otelhttp.WithTLSAttributes()},
+			wantTLS:   true,
+			wantProto: 2,
+		},
+		{
+			name:   "NoTLS",
+			server: func() *httptest.Server { return httptest.NewServer(handler) },
+			opts:   []otelhttp.Option{otelhttp.WithTLSAttributes()},
+		},
+		{
+			name:   "Disabled",
+			server: func() *httptest.Server { return newTLSServer(false) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := tt.server()
+			defer ts.Close()
+
+			sr := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			opts := append([]otelhttp.Option{otelhttp.WithTracerProvider(provider)}, tt.opts...)
+			c := ts.Client()
+			c.Transport = otelhttp.NewTransport(c.Transport, opts...)
+
+			res, err := c.Get(ts.URL)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			attrs := spans[0].Attributes()
+			if !tt.wantTLS {
+				for _, kv := range attrs {
+					assert.NotEqual(t, otelhttp.TLSProtocolVersionKey, kv.Key)
+					assert.NotEqual(t, otelhttp.TLSCipherKey, kv.Key)
+				}
+				return
+			}
+
+			require.NotNil(t, res.TLS)
+			assert.Contains(t, attrs, otelhttp.TLSProtocolVersionKey.String("1.3"))
+			assert.Contains(t, attrs, otelhttp.TLSCipherKey.String(tls.CipherSuiteName(res.TLS.CipherSuite)))
+			assert.Equal(t, tt.wantProto, res.ProtoMajor)
+		})
+	}
+}
+
+func TestTransportInjectionFilter(t *testing.T) {
+	var got http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	for _, tt := range []struct {
+		path       string
+		wantHeader bool
+	}{
+		{path: "/proxied", wantHeader: false},
+		{path: "/direct", wantHeader: true},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			c := http.Client{Transport: otelhttp.NewTransport(
+				http.DefaultTransport,
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithPropagators(propagation.TraceContext{}),
+				otelhttp.WithInjectionFilter(func(r *http.Request) bool {
+					return r.URL.Path == "/proxied"
+				}),
+			)}
+
+			res, err := c.Get(ts.URL + tt.path)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			if tt.wantHeader {
+				assert.NotEmpty(t, got.Get("traceparent"))
+			} else {
+				assert.Empty(t, got.Get("traceparent"))
+			}
+
+			// The span is created whether or not the context was injected.
+			assert.Len(t, sr.Ended(), 1)
+		})
+	}
+}
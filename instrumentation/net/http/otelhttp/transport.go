@@ -5,6 +5,7 @@ package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http
 
 import (
 	"context"
+	"crypto/tls"
 	"io"
 	"net/http"
 	"net/http/httptrace"
@@ -13,13 +14,13 @@ import (
 
 	"go.opentelemetry.io/otel/metric"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/internal/semconv"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/internal/semconvutil"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
-
-	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
 )
 
 // Transport implements the http.RoundTripper interface and wraps
@@ -34,6 +35,8 @@ type Transport struct {
 	filters           []Filter
 	spanNameFormatter func(string, *http.Request) string
 	clientTrace       func(context.Context) *httptrace.ClientTrace
+	injectionFilter   func(*http.Request) bool
+	tlsAttributes     bool
 
 	requestBytesCounter  metric.Int64Counter
 	responseBytesCounter metric.Int64Counter
@@ -77,6 +80,8 @@ func (t *Transport) applyConfig(c *config) {
 	t.filters = c.Filters
 	t.spanNameFormatter = c.SpanNameFormatter
 	t.clientTrace = c.ClientTrace
+	t.injectionFilter = c.InjectionFilter
+	t.tlsAttributes = c.TLSAttributes
 }
 
 func (t *Transport) createMeasures() {
@@ -154,8 +159,10 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 		r.Body = &bw
 	}
 
-	span.SetAttributes(semconvutil.HTTPClientRequest(r)...)
-	t.propagators.Inject(ctx, propagation.HeaderCarrier(r.Header))
+	span.SetAttributes(semconv.ClientRequestTraceAttrs(r)...)
+	if t.injectionFilter == nil || !t.injectionFilter(r) {
+		t.propagators.Inject(ctx, propagation.HeaderCarrier(r.Header))
+	}
 
 	res, err := t.rt.RoundTrip(r)
 	if err != nil {
@@ -179,6 +186,9 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 
 	// traces
 	span.SetAttributes(semconvutil.HTTPClientResponse(res)...)
+	if t.tlsAttributes && res.TLS != nil {
+		span.SetAttributes(tlsAttributes(res.TLS)...)
+	}
 	span.SetStatus(semconvutil.HTTPClientStatus(res.StatusCode))
 
 	res.Body = newWrappedBody(span, readRecordFunc, res.Body)
@@ -191,6 +201,27 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 	return res, err
 }
 
+// tlsAttributes returns the attributes describing the TLS connection state.
+func tlsAttributes(state *tls.ConnectionState) []attribute.KeyValue {
+	var version string
+	switch state.Version {
+	case tls.VersionTLS10:
+		version = "1.0"
+	case tls.VersionTLS11:
+		version = "1.1"
+	case tls.VersionTLS12:
+		version = "1.2"
+	case tls.VersionTLS13:
+		version = "1.3"
+	}
+
+	attrs := make([]attribute.KeyValue, 0, 2)
+	if version != "" {
+		attrs = append(attrs, TLSProtocolVersionKey.String(version))
+	}
+	return append(attrs, TLSCipherKey.String(tls.CipherSuiteName(state.CipherSuite)))
+}
+
 // newWrappedBody returns a new and appropriately scoped *wrappedBody as an
 // io.ReadCloser. If the passed body implements io.Writer, the returned value
 // will implement io.ReadWriteCloser.
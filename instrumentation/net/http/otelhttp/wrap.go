@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel/propagation"
 )
@@ -37,6 +38,37 @@ func (w *bodyWrapper) Close() error {
 	return w.ReadCloser.Close()
 }
 
+// teeReadCloser is an io.ReadCloser writing what is read from the wrapped
+// io.ReadCloser to a limitedBuffer.
+type teeReadCloser struct {
+	io.ReadCloser
+	buf *limitedBuffer
+}
+
+func (t *teeReadCloser) Read(b []byte) (int, error) {
+	n, err := t.ReadCloser.Read(b)
+	if n > 0 {
+		_, _ = t.buf.Write(b[:n])
+	}
+	return n, err
+}
+
+// limitedBuffer is an io.Writer keeping the first max bytes written to it.
+type limitedBuffer struct {
+	buf []byte
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if n := b.max - len(b.buf); n > 0 {
+		if len(p) < n {
+			n = len(p)
+		}
+		b.buf = append(b.buf, p[:n]...)
+	}
+	return len(p), nil
+}
+
 var _ http.ResponseWriter = &respWriterWrapper{}
 
 // respWriterWrapper wraps a http.ResponseWriter in order to track the number of
@@ -57,6 +89,10 @@ type respWriterWrapper struct {
 	statusCode  int
 	err         error
 	wroteHeader bool
+
+	// sse is the Server-Sent Events stream of the response, nil unless
+	// WithSSEEvents is used.
+	sse *sseStream
 }
 
 func (w *respWriterWrapper) Header() http.Header {
@@ -84,6 +120,19 @@ func (w *respWriterWrapper) WriteHeader(statusCode int) {
 	if !w.wroteHeader {
 		w.wroteHeader = true
 		w.statusCode = statusCode
+		if w.sse != nil {
+			w.sse.start = time.Now()
+		}
 	}
 	w.ResponseWriter.WriteHeader(statusCode)
 }
+
+// Flush counts the flush of a Server-Sent Events stream and calls next.
+func (w *respWriterWrapper) Flush(next func()) {
+	if !w.wroteHeader {
+		// Flushing writes the header.
+		w.WriteHeader(http.StatusOK)
+	}
+	w.sse.flushes.Add(1)
+	next()
+}
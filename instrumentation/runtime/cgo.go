@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
+
+import (
+	"context"
+	goruntime "runtime"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// numCgoCall returns the number of cgo calls made by the current process.
+var numCgoCall = goruntime.NumCgoCall
+
+// registerCgoCalls registers the go.cgo.calls counter.
+func (r *runtime) registerCgoCalls() error {
+	calls, err := r.meter.Int64ObservableCounter(
+		"go.cgo.calls",
+		metric.WithUnit("{call}"),
+		metric.WithDescription("Number of cgo calls made by the current process"),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = r.meter.RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(calls, numCgoCall())
+			return nil
+		},
+		calls,
+	)
+	return err
+}
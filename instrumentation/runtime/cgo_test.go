@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// callbackRecorder is a metric.Meter recording the registered callbacks.
+type callbackRecorder struct {
+	noop.Meter
+
+	callbacks []metric.Callback
+}
+
+func (r *callbackRecorder) RegisterCallback(f metric.Callback, _ ...metric.Observable) (metric.Registration, error) {
+	r.callbacks = append(r.callbacks, f)
+	return noop.Registration{}, nil
+}
+
+// int64Observer is a metric.Observer recording the observed int64 values.
+type int64Observer struct {
+	noop.Observer
+
+	values []int64
+}
+
+func (o *int64Observer) ObserveInt64(_ metric.Int64Observable, v int64, _ ...metric.ObserveOption) {
+	o.values = append(o.values, v)
+}
+
+func TestCgoCalls(t *testing.T) {
+	calls := []int64{3, 5}
+	orig := numCgoCall
+	t.Cleanup(func() { numCgoCall = orig })
+	numCgoCall = func() int64 {
+		v := calls[0]
+		calls = calls[1:]
+		return v
+	}
+
+	meter := &callbackRecorder{}
+	r := &runtime{meter: meter}
+	require.NoError(t, r.registerCgoCalls())
+	require.Len(t, meter.callbacks, 1)
+
+	o := &int64Observer{}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, meter.callbacks[0](context.Background(), o))
+	}
+	assert.Equal(t, []int64{3, 5}, o.values)
+}
+
+func TestCgoCallsNonDecreasing(t *testing.T) {
+	meter := &callbackRecorder{}
+	r := &runtime{meter: meter}
+	require.NoError(t, r.registerCgoCalls())
+	require.Len(t, meter.callbacks, 1)
+
+	o := &int64Observer{}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, meter.callbacks[0](context.Background(), o))
+	}
+	require.Len(t, o.values, 2)
+	assert.LessOrEqual(t, o.values[0], o.values[1])
+}
+
+func TestWithoutCgoCalls(t *testing.T) {
+	r := &nameRecorder{}
+	require.NoError(t, Start(WithMeterProvider(nameRecorderProvider{meter: r}), WithSemconvNames(), WithoutCgoCalls()))
+	assert.NotContains(t, r.names, "go.cgo.calls")
+
+	r = &nameRecorder{}
+	require.NoError(t, Start(WithMeterProvider(nameRecorderProvider{meter: r}), WithSemconvNames()))
+	assert.Contains(t, r.names, "go.cgo.calls")
+}
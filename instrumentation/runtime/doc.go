@@ -5,6 +5,8 @@
 //
 // The metric events produced are:
 //
+//	go.gc.pause                  (s)        Distribution of individual GC stop-the-world pause latencies
+//	go.memory.used               (bytes)    Memory used by the Go runtime, by memory type (go.memory.type: stack, other)
 //	runtime.go.cgo.calls         -          Number of cgo calls made by the current process
 //	runtime.go.gc.count          -          Number of completed garbage collection cycles
 //	runtime.go.gc.pause_ns       (ns)       Amount of nanoseconds in GC stop-the-world pauses
@@ -19,4 +21,18 @@
 //	runtime.go.mem.heap_sys      (bytes)    Bytes of heap memory obtained from the OS
 //	runtime.go.mem.live_objects  -          Number of live objects is the number of cumulative Mallocs - Frees
 //	runtime.uptime               (ms)       Milliseconds since application was initialized
+//
+// With the WithSemconvNames option, the runtime.* metrics are replaced by the
+// metrics of the Go runtime semantic conventions:
+//
+//	go.cgo.calls                 -          Number of cgo calls made by the current process (disabled with WithoutCgoCalls)
+//	go.config.gogc               (%)        Heap size target percentage configured by the user, otherwise 100
+//	go.gc.pause                  (s)        Distribution of individual GC stop-the-world pause latencies
+//	go.goroutine.count           -          Count of live goroutines
+//	go.memory.allocated          (bytes)    Memory allocated to the heap by the application
+//	go.memory.allocations        -          Count of allocations to the heap by the application
+//	go.memory.gc.goal            (bytes)    Heap size target for the end of the GC cycle
+//	go.memory.limit              (bytes)    Go runtime memory limit configured by the user, if a limit exists
+//	go.memory.used               (bytes)    Memory used by the Go runtime, by memory type
+//	go.processor.limit           -          The number of OS threads that can execute user-level Go code simultaneously
 package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
+
+import (
+	"context"
+	"math"
+	goruntime "runtime"
+	"runtime/metrics"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// gcPausesMetric is the runtime/metrics histogram of individual GC
+// stop-the-world pause latencies.
+const gcPausesMetric = "/gc/pauses:seconds"
+
+// gcPauseBoundaries are the explicit bucket boundaries, in seconds, used for
+// the go.gc.pause histogram. The runtime/metrics histogram has far more
+// buckets than is useful to export, these cover the range of pauses
+// typically observed.
+var gcPauseBoundaries = []float64{
+	0.000001, 0.000005, 0.00001, 0.00005, 0.0001, 0.0005,
+	0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1,
+}
+
+// readGCPauses returns the current cumulative runtime/metrics histogram of
+// GC pauses. It returns nil if the metric is not supported by the runtime.
+func readGCPauses() *metrics.Float64Histogram {
+	sample := []metrics.Sample{{Name: gcPausesMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindFloat64Histogram {
+		return nil
+	}
+	return sample[0].Value.Float64Histogram()
+}
+
+// gcPauses records the GC pauses reported by the runtime/metrics histogram
+// to an OpenTelemetry histogram.
+//
+// The runtime/metrics histogram is cumulative since the program started. Each
+// call to record only records the pauses that occurred since the previous
+// call.
+type gcPauses struct {
+	histogram metric.Float64Histogram
+	read      func() *metrics.Float64Histogram
+
+	// mu ensures concurrent collections do not record the same pauses.
+	mu sync.Mutex
+	// last holds the bucket counts of the previous snapshot.
+	last []uint64
+}
+
+func newGCPauses(histogram metric.Float64Histogram, read func() *metrics.Float64Histogram) *gcPauses {
+	p := &gcPauses{histogram: histogram, read: read}
+	// Only pauses that occur after registration are recorded.
+	if h := read(); h != nil {
+		p.last = append(p.last, h.Counts...)
+	}
+	return p
+}
+
+// recordOnGC records the GC pauses at the end of each GC cycle, so that they
+// are recorded independently of the collection of the other metrics.
+func (p *gcPauses) recordOnGC() {
+	// The finalizer of the sentinel runs once it is collected, that is
+	// after the next GC cycle, and re-arms itself for the following one.
+	goruntime.SetFinalizer(&gcSentinel{pauses: p}, onGC)
+}
+
+// gcSentinel is the object whose finalizer records the GC pauses. It holds
+// a pointer so that it is not batched by the tiny allocator, whose objects
+// are not guaranteed to be finalized.
+type gcSentinel struct {
+	pauses *gcPauses
+}
+
+func onGC(s *gcSentinel) {
+	s.pauses.record(context.Background())
+	goruntime.SetFinalizer(s, onGC)
+}
+
+// record records the GC pauses that occurred since the last call. Each pause
+// is recorded using a representative value of the runtime/metrics bucket it
+// was counted in.
+func (p *gcPauses) record(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h := p.read()
+	if h == nil {
+		return
+	}
+
+	for i, count := range h.Counts {
+		var prev uint64
+		if i < len(p.last) {
+			prev = p.last[i]
+		}
+		if count <= prev {
+			continue
+		}
+
+		v := bucketValue(h.Buckets[i], h.Buckets[i+1])
+		for n := count - prev; n > 0; n-- {
+			p.histogram.Record(ctx, v)
+		}
+	}
+	p.last = append(p.last[:0], h.Counts...)
+}
+
+// bucketValue returns the value used to represent observations in the bucket
+// [lower, upper). This is the midpoint of the bucket, or its finite boundary
+// if the bucket is unbounded.
+func bucketValue(lower, upper float64) float64 {
+	switch {
+	case math.IsInf(lower, -1):
+		return upper
+	case math.IsInf(upper, 1):
+		return lower
+	}
+	return lower + (upper-lower)/2
+}
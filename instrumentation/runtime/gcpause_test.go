@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"math"
+	goruntime "runtime"
+	"runtime/metrics"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+type recordingHistogram struct {
+	noop.Float64Histogram
+
+	values []float64
+}
+
+func (h *recordingHistogram) Record(_ context.Context, v float64, _ ...metric.RecordOption) {
+	h.values = append(h.values, v)
+}
+
+func TestGCPausesRecordsDelta(t *testing.T) {
+	buckets := []float64{math.Inf(-1), 0, 0.001, 0.002, math.Inf(1)}
+	snapshots := []*metrics.Float64Histogram{
+		{Counts: []uint64{0, 3, 1, 0}, Buckets: buckets},
+		{Counts: []uint64{0, 5, 1, 1}, Buckets: buckets},
+	}
+	var n int
+	read := func() *metrics.Float64Histogram {
+		h := snapshots[n]
+		if n < len(snapshots)-1 {
+			n++
+		}
+		return h
+	}
+
+	h := &recordingHistogram{}
+	p := newGCPauses(h, read)
+	p.record(context.Background())
+
+	want := []float64{0.0005, 0.0005, 0.002}
+	if len(h.values) != len(want) {
+		t.Fatalf("recorded %v, want %v", h.values, want)
+	}
+	for i, v := range h.values {
+		if v < 0 {
+			t.Errorf("recorded negative pause %v", v)
+		}
+		if v != want[i] {
+			t.Errorf("value %d: got %v, want %v", i, v, want[i])
+		}
+	}
+
+	// No new pauses since the last collection.
+	p.record(context.Background())
+	if len(h.values) != len(want) {
+		t.Errorf("recorded pauses without new observations: %v", h.values[len(want):])
+	}
+}
+
+func TestGCPausesUnsupported(t *testing.T) {
+	h := &recordingHistogram{}
+	p := newGCPauses(h, func() *metrics.Float64Histogram { return nil })
+	p.record(context.Background())
+	if len(h.values) != 0 {
+		t.Errorf("recorded %v for unsupported metric", h.values)
+	}
+}
+
+func TestReadGCPauses(t *testing.T) {
+	h := readGCPauses()
+	if h == nil {
+		t.Fatalf("%s not supported", gcPausesMetric)
+	}
+	if len(h.Buckets) != len(h.Counts)+1 {
+		t.Errorf("got %d buckets for %d counts", len(h.Buckets), len(h.Counts))
+	}
+}
+
+// syncHistogram is a recordingHistogram safe for concurrent use.
+type syncHistogram struct {
+	noop.Float64Histogram
+
+	mu     sync.Mutex
+	values []float64
+}
+
+func (h *syncHistogram) Record(_ context.Context, v float64, _ ...metric.RecordOption) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.values = append(h.values, v)
+}
+
+func (h *syncHistogram) len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.values)
+}
+
+func TestGCPausesRecordOnGC(t *testing.T) {
+	h := &syncHistogram{}
+	newGCPauses(h, readGCPauses).recordOnGC()
+
+	// The pauses are recorded at the end of the GC cycles, without any
+	// collection of the other metrics.
+	deadline := time.Now().Add(5 * time.Second)
+	for h.len() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no GC pause recorded")
+		}
+		goruntime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
+
+import (
+	"context"
+	"runtime/metrics"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// goMetric is a metric of the Go runtime semantic conventions whose value is
+// read from a runtime/metrics metric.
+type goMetric struct {
+	name        string
+	unit        string
+	description string
+	// counter is true if the metric is monotonic.
+	counter bool
+	// runtimeName is the name of the runtime/metrics metric the value is
+	// read from.
+	runtimeName string
+}
+
+// goMetrics are the metrics of the Go runtime semantic conventions reported
+// with WithSemconvNames, in addition to go.memory.used and go.gc.pause.
+var goMetrics = []goMetric{
+	{
+		name:        "go.memory.limit",
+		unit:        "By",
+		description: "Go runtime memory limit configured by the user, if a limit exists",
+		runtimeName: "/gc/gomemlimit:bytes",
+	},
+	{
+		name:        "go.memory.allocated",
+		unit:        "By",
+		description: "Memory allocated to the heap by the application",
+		counter:     true,
+		runtimeName: "/gc/heap/allocs:bytes",
+	},
+	{
+		name:        "go.memory.allocations",
+		unit:        "{allocation}",
+		description: "Count of allocations to the heap by the application",
+		counter:     true,
+		runtimeName: "/gc/heap/allocs:objects",
+	},
+	{
+		name:        "go.memory.gc.goal",
+		unit:        "By",
+		description: "Heap size target for the end of the GC cycle",
+		runtimeName: "/gc/heap/goal:bytes",
+	},
+	{
+		name:        "go.goroutine.count",
+		unit:        "{goroutine}",
+		description: "Count of live goroutines",
+		runtimeName: "/sched/goroutines:goroutines",
+	},
+	{
+		name:        "go.processor.limit",
+		unit:        "{thread}",
+		description: "The number of OS threads that can execute user-level Go code simultaneously",
+		runtimeName: "/sched/gomaxprocs:threads",
+	},
+	{
+		name:        "go.config.gogc",
+		unit:        "%",
+		description: "Heap size target percentage configured by the user, otherwise 100",
+		runtimeName: "/gc/gogc:percent",
+	},
+}
+
+// registerGoMetrics registers the metrics of goMetrics. A metric that is not
+// supported by the Go runtime is registered but never observed.
+func (r *runtime) registerGoMetrics() error {
+	instruments := make([]metric.Int64Observable, len(goMetrics))
+	samples := make([]metrics.Sample, len(goMetrics))
+	for i, m := range goMetrics {
+		var err error
+		if m.counter {
+			instruments[i], err = r.meter.Int64ObservableCounter(
+				m.name,
+				metric.WithUnit(m.unit),
+				metric.WithDescription(m.description),
+			)
+		} else {
+			instruments[i], err = r.meter.Int64ObservableUpDownCounter(
+				m.name,
+				metric.WithUnit(m.unit),
+				metric.WithDescription(m.description),
+			)
+		}
+		if err != nil {
+			return err
+		}
+		samples[i].Name = m.runtimeName
+	}
+
+	observables := make([]metric.Observable, len(instruments))
+	for i, inst := range instruments {
+		observables[i] = inst
+	}
+	// mu ensures concurrent collections do not read into the same samples.
+	var mu sync.Mutex
+	_, err := r.meter.RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			metrics.Read(samples)
+			for i, s := range samples {
+				if s.Value.Kind() != metrics.KindUint64 {
+					continue
+				}
+				o.ObserveInt64(instruments[i], int64(s.Value.Uint64()))
+			}
+			return nil
+		},
+		observables...,
+	)
+	return err
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"runtime/metrics"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// nameRecorder is a metric.Meter recording the names of the registered
+// instruments.
+type nameRecorder struct {
+	noop.Meter
+
+	names []string
+}
+
+// nameRecorderProvider is a metric.MeterProvider returning its nameRecorder.
+type nameRecorderProvider struct {
+	noop.MeterProvider
+
+	meter *nameRecorder
+}
+
+func (p nameRecorderProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return p.meter
+}
+
+func (r *nameRecorder) Int64ObservableCounter(name string, opts ...metric.Int64ObservableCounterOption) (metric.Int64ObservableCounter, error) {
+	r.names = append(r.names, name)
+	return r.Meter.Int64ObservableCounter(name, opts...)
+}
+
+func (r *nameRecorder) Int64ObservableUpDownCounter(name string, opts ...metric.Int64ObservableUpDownCounterOption) (metric.Int64ObservableUpDownCounter, error) {
+	r.names = append(r.names, name)
+	return r.Meter.Int64ObservableUpDownCounter(name, opts...)
+}
+
+func (r *nameRecorder) Int64Histogram(name string, opts ...metric.Int64HistogramOption) (metric.Int64Histogram, error) {
+	r.names = append(r.names, name)
+	return r.Meter.Int64Histogram(name, opts...)
+}
+
+func (r *nameRecorder) Float64Histogram(name string, opts ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	r.names = append(r.names, name)
+	return r.Meter.Float64Histogram(name, opts...)
+}
+
+func TestLegacyNames(t *testing.T) {
+	r := &nameRecorder{}
+	require.NoError(t, Start(WithMeterProvider(nameRecorderProvider{meter: r})))
+
+	assert.Contains(t, r.names, "runtime.uptime")
+	assert.Contains(t, r.names, "process.runtime.go.goroutines")
+	assert.Contains(t, r.names, "process.runtime.go.mem.heap_alloc")
+	assert.Contains(t, r.names, "go.memory.used")
+	assert.Contains(t, r.names, "go.gc.pause")
+	assert.NotContains(t, r.names, "go.goroutine.count")
+	// The legacy process.runtime.go.cgo.calls is not duplicated.
+	assert.NotContains(t, r.names, "go.cgo.calls")
+}
+
+func TestSemconvNames(t *testing.T) {
+	r := &nameRecorder{}
+	require.NoError(t, Start(WithMeterProvider(nameRecorderProvider{meter: r}), WithSemconvNames()))
+
+	want := []string{"go.cgo.calls", "go.gc.pause", "go.memory.used"}
+	for _, m := range goMetrics {
+		want = append(want, m.name)
+	}
+	assert.ElementsMatch(t, want, r.names)
+}
+
+func TestGoMetricsSupported(t *testing.T) {
+	supported := make(map[string]metrics.ValueKind)
+	for _, d := range metrics.All() {
+		supported[d.Name] = d.Kind
+	}
+	for _, m := range goMetrics {
+		assert.Equal(t, metrics.KindUint64, supported[m.runtimeName], m.runtimeName)
+	}
+}
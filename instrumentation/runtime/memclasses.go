@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
+
+import (
+	"runtime/metrics"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// memoryClassesPrefix is the prefix of the runtime/metrics memory
+	// classes. The leaf classes sum to memoryClassesTotal.
+	memoryClassesPrefix = "/memory/classes/"
+	memoryClassesTotal  = "/memory/classes/total:bytes"
+
+	// memoryTypeKey is the attribute key of the memory type of go.memory.used.
+	memoryTypeKey = attribute.Key("go.memory.type")
+)
+
+// memoryReleased is the runtime/metrics memory class of the heap memory
+// returned to the operating system, which is not used by the Go runtime.
+const memoryReleased = "/memory/classes/heap/released:bytes"
+
+// memoryType returns the go.memory.type of the runtime/metrics memory class
+// name, as defined by the semantic conventions: "stack" for the heap memory
+// reserved for stack space, "other" for all the other classes, including
+// the classes unknown to this package, for example added by newer Go
+// versions. An empty string is returned for the released memory, which is
+// not reported.
+func memoryType(name string) string {
+	switch name {
+	case "/memory/classes/heap/stacks:bytes":
+		return "stack"
+	case memoryReleased:
+		return ""
+	}
+	return "other"
+}
+
+// memoryClasses reads the runtime/metrics memory classes supported by the
+// runtime and aggregates them by memory type.
+type memoryClasses struct {
+	// types are the distinct memory types, sorted.
+	types []string
+	// attrs are the attribute sets of types.
+	attrs map[string]attribute.Set
+	// classType is the memory type of each sample.
+	classType []string
+
+	mu      sync.Mutex
+	samples []metrics.Sample
+}
+
+// newMemoryClasses returns a memoryClasses reading the memory classes among
+// descs. Only the supported classes are read, so the set of classes can
+// change across Go versions.
+func newMemoryClasses(descs []metrics.Description) *memoryClasses {
+	m := &memoryClasses{attrs: make(map[string]attribute.Set)}
+	for _, d := range descs {
+		if !strings.HasPrefix(d.Name, memoryClassesPrefix) || d.Name == memoryClassesTotal {
+			continue
+		}
+		if d.Kind != metrics.KindUint64 {
+			continue
+		}
+		t := memoryType(d.Name)
+		if t == "" {
+			continue
+		}
+		if _, ok := m.attrs[t]; !ok {
+			m.types = append(m.types, t)
+			m.attrs[t] = attribute.NewSet(memoryTypeKey.String(t))
+		}
+		m.samples = append(m.samples, metrics.Sample{Name: d.Name})
+		m.classType = append(m.classType, t)
+	}
+	sort.Strings(m.types)
+	return m
+}
+
+// read returns the number of bytes used by each memory type.
+func (m *memoryClasses) read() map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics.Read(m.samples)
+	used := make(map[string]int64, len(m.types))
+	for i, s := range m.samples {
+		if s.Value.Kind() != metrics.KindUint64 {
+			continue
+		}
+		used[m.classType[i]] += int64(s.Value.Uint64())
+	}
+	return used
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"runtime/metrics"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryType(t *testing.T) {
+	tests := map[string]string{
+		"/memory/classes/heap/objects:bytes":         "other",
+		"/memory/classes/heap/unused:bytes":          "other",
+		"/memory/classes/heap/stacks:bytes":          "stack",
+		"/memory/classes/os-stacks:bytes":            "other",
+		"/memory/classes/heap/released:bytes":        "",
+		"/memory/classes/metadata/mspan/inuse:bytes": "other",
+		"/memory/classes/profiling/buckets:bytes":    "other",
+		"/memory/classes/some-future-class:bytes":    "other",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, memoryType(name), name)
+	}
+}
+
+func TestMemoryClassesUnsupported(t *testing.T) {
+	m := newMemoryClasses([]metrics.Description{
+		{Name: "/gc/cycles/total:gc-cycles", Kind: metrics.KindUint64},
+		{Name: memoryClassesTotal, Kind: metrics.KindUint64},
+	})
+	assert.Empty(t, m.types)
+	assert.Empty(t, m.read())
+}
+
+func TestMemoryClassesSumToUsed(t *testing.T) {
+	m := newMemoryClasses(metrics.All())
+	assert.Equal(t, []string{"other", "stack"}, m.types)
+
+	var sum int64
+	for _, v := range m.read() {
+		sum += v
+	}
+
+	total := []metrics.Sample{{Name: memoryClassesTotal}, {Name: memoryReleased}}
+	metrics.Read(total)
+	require.Equal(t, metrics.KindUint64, total[0].Value.Kind())
+	require.Equal(t, metrics.KindUint64, total[1].Value.Kind())
+	used := total[0].Value.Uint64() - total[1].Value.Uint64()
+
+	// The released memory is not used. The classes and the total are read
+	// separately, allow for the memory mapped in between.
+	assert.InEpsilon(t, float64(used), float64(sum), 0.05)
+}
@@ -6,6 +6,7 @@ package runtime // import "go.opentelemetry.io/contrib/instrumentation/runtime"
 import (
 	"context"
 	goruntime "runtime"
+	"runtime/metrics"
 	"sync"
 	"time"
 
@@ -32,6 +33,13 @@ type config struct {
 	// MeterProvider sets the metric.MeterProvider.  If nil, the global
 	// Provider will be used.
 	MeterProvider metric.MeterProvider
+
+	// SemconvNames reports the metrics of the Go runtime semantic
+	// conventions instead of the legacy process.runtime.go metrics.
+	SemconvNames bool
+
+	// DisableCgoCalls disables the go.cgo.calls metric.
+	DisableCgoCalls bool
 }
 
 // Option supports configuring optional settings for runtime metrics.
@@ -75,6 +83,52 @@ func (o metricProviderOption) apply(c *config) {
 	}
 }
 
+// WithSemconvNames reports the metrics of the Go runtime semantic conventions
+// instead of the legacy runtime.uptime and process.runtime.go.* metrics, to
+// ease the migration to these conventions.
+//
+// The go.memory.used and go.gc.pause metrics are always reported, they
+// supersede the process.runtime.go.mem.* and process.runtime.go.gc.pause*
+// metrics. go.memory.used reports the stack and other memory types of the
+// semantic conventions and excludes the heap memory released to the
+// operating system. go.gc.pause is not defined by the semantic conventions.
+// With this option, process.runtime.go.goroutines is replaced by
+// go.goroutine.count, and the following metrics are added:
+//
+//	go.memory.allocated   (bytes)        Memory allocated to the heap by the application
+//	go.memory.allocations {allocation}   Count of allocations to the heap by the application
+//	go.memory.limit       (bytes)        Go runtime memory limit configured by the user
+//	go.memory.gc.goal     (bytes)        Heap size target for the end of the GC cycle
+//	go.processor.limit    {thread}       Number of OS threads that can execute user-level Go code simultaneously
+//	go.config.gogc        (%)            Heap size target percentage configured by the user
+//
+// The legacy metrics without semantic conventions equivalent, such as
+// process.runtime.go.cgo.calls, are no longer reported, go.cgo.calls is
+// reported instead unless WithoutCgoCalls is used. The metrics are read
+// from the runtime/metrics package, WithMinimumReadMemStatsInterval has no
+// effect.
+func WithSemconvNames() Option {
+	return semconvNamesOption{}
+}
+
+type semconvNamesOption struct{}
+
+func (semconvNamesOption) apply(c *config) {
+	c.SemconvNames = true
+}
+
+// WithoutCgoCalls disables the go.cgo.calls metric, the number of cgo calls
+// made by the current process, which is reported with WithSemconvNames.
+func WithoutCgoCalls() Option {
+	return cgoCallsOption{}
+}
+
+type cgoCallsOption struct{}
+
+func (cgoCallsOption) apply(c *config) {
+	c.DisableCgoCalls = true
+}
+
 // newConfig computes a config from the supplied Options.
 func newConfig(opts ...Option) config {
 	c := config{
@@ -107,6 +161,53 @@ func Start(opts ...Option) error {
 }
 
 func (r *runtime) register() error {
+	gcPause, err := r.meter.Float64Histogram(
+		"go.gc.pause",
+		metric.WithUnit("s"),
+		metric.WithDescription("Distribution of individual GC stop-the-world pause latencies"),
+		metric.WithExplicitBucketBoundaries(gcPauseBoundaries...),
+	)
+	if err != nil {
+		return err
+	}
+	newGCPauses(gcPause, readGCPauses).recordOnGC()
+
+	memoryUsed, err := r.meter.Int64ObservableUpDownCounter(
+		"go.memory.used",
+		metric.WithUnit("By"),
+		metric.WithDescription("Memory used by the Go runtime, by memory type"),
+	)
+	if err != nil {
+		return err
+	}
+	memClasses := newMemoryClasses(metrics.All())
+
+	_, err = r.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			used := memClasses.read()
+			for _, t := range memClasses.types {
+				o.ObserveInt64(memoryUsed, used[t], metric.WithAttributeSet(memClasses.attrs[t]))
+			}
+			return nil
+		},
+		memoryUsed,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !r.config.SemconvNames {
+		return r.registerLegacy()
+	}
+	if !r.config.DisableCgoCalls {
+		if err := r.registerCgoCalls(); err != nil {
+			return err
+		}
+	}
+	return r.registerGoMetrics()
+}
+
+func (r *runtime) registerLegacy() error {
 	startTime := time.Now()
 	uptime, err := r.meter.Int64ObservableCounter(
 		"runtime.uptime",
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xray // import "go.opentelemetry.io/contrib/propagators/aws/xray"
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// lambdaTraceHeaderEnvKey is the environment variable the AWS Lambda
+	// runtime sets to the X-Ray trace header of the current invocation.
+	lambdaTraceHeaderEnvKey = "_X_AMZN_TRACE_ID"
+	// lambdaTraceHeaderCtxKey is the context key the aws-lambda-go runtime
+	// uses to store the X-Ray trace header of the current invocation.
+	lambdaTraceHeaderCtxKey = "x-amzn-trace-id"
+)
+
+// ContextFromLambda returns a copy of ctx containing the remote span context
+// of the current AWS Lambda invocation.
+//
+// The X-Ray trace header is read from the invocation context populated by
+// the aws-lambda-go runtime. If it is not present there, the
+// _X_AMZN_TRACE_ID environment variable is used instead. If neither contains
+// a valid trace header, ctx is returned unchanged.
+func ContextFromLambda(ctx context.Context) context.Context {
+	header, _ := ctx.Value(lambdaTraceHeaderCtxKey).(string)
+	if header == "" {
+		header = os.Getenv(lambdaTraceHeaderEnvKey)
+	}
+	if header == "" {
+		return ctx
+	}
+
+	sc, err := extract(header)
+	if err != nil || !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
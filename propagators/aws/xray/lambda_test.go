@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextFromLambdaEnv(t *testing.T) {
+	t.Setenv(lambdaTraceHeaderEnvKey, "Root="+xrayTraceID+";Parent="+parentID64Str+";Sampled=1")
+
+	sc := trace.SpanContextFromContext(ContextFromLambda(context.Background()))
+	assert.Equal(t, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentSpanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}), sc)
+}
+
+func TestContextFromLambdaContextValue(t *testing.T) {
+	t.Setenv(lambdaTraceHeaderEnvKey, "")
+
+	//nolint:staticcheck // The aws-lambda-go runtime uses a string key.
+	ctx := context.WithValue(context.Background(), lambdaTraceHeaderCtxKey, "Root="+xrayTraceID+";Parent="+parentID64Str+";Sampled=0")
+
+	sc := trace.SpanContextFromContext(ContextFromLambda(ctx))
+	assert.Equal(t, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentSpanID,
+		TraceFlags: traceFlagNone,
+		Remote:     true,
+	}), sc)
+}
+
+func TestContextFromLambdaInvalid(t *testing.T) {
+	t.Setenv(lambdaTraceHeaderEnvKey, "Root="+xrayTraceIDIncorrectLength+";Parent="+parentID64Str+";Sampled=1")
+
+	ctx := context.Background()
+	assert.Equal(t, ctx, ContextFromLambda(ctx))
+}
+
+func TestContextFromLambdaMissing(t *testing.T) {
+	t.Setenv(lambdaTraceHeaderEnvKey, "")
+
+	ctx := context.Background()
+	assert.Equal(t, ctx, ContextFromLambda(ctx))
+}
@@ -418,6 +418,21 @@ var extractInvalidHeaders = []extractTest{
 			b3Sampled: "1",
 		},
 	},
+	{
+		name: "multiple: zero trace ID with debug flag",
+		headers: map[string]string{
+			b3TraceID: "00000000000000000000000000000000",
+			b3SpanID:  "cd00000000000000",
+			b3Flags:   "1",
+		},
+	},
+	{
+		name: "multiple: zero span ID with deferred sampling",
+		headers: map[string]string{
+			b3TraceID: "ab000000000000000000000000000000",
+			b3SpanID:  "0000000000000000",
+		},
+	},
 	{
 		name: "multiple: missing span ID",
 		headers: map[string]string{
@@ -504,6 +519,24 @@ var extractInvalidHeaders = []extractTest{
 			b3Context: "ab000000000000000000000000000000-cd00000000000000-1-EF00000000000000",
 		},
 	},
+	{
+		name: "single: zero trace ID",
+		headers: map[string]string{
+			b3Context: "00000000000000000000000000000000-cd00000000000000-1",
+		},
+	},
+	{
+		name: "single: zero 64 bit trace ID",
+		headers: map[string]string{
+			b3Context: "0000000000000000-cd00000000000000-d",
+		},
+	},
+	{
+		name: "single: zero span ID",
+		headers: map[string]string{
+			b3Context: "ab000000000000000000000000000000-0000000000000000",
+		},
+	},
 	{
 		name: "single: zero trace ID and span ID",
 		headers: map[string]string{
@@ -62,6 +62,69 @@ func TestExtractB3(t *testing.T) {
 	}
 }
 
+func TestExtractB3ZeroIDs(t *testing.T) {
+	type ctxKey struct{}
+	parent := context.WithValue(context.Background(), ctxKey{}, "parent")
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		valid   bool
+	}{
+		{
+			name: "single: zero trace ID",
+			headers: map[string]string{
+				b3Context: "00000000000000000000000000000000-cd00000000000000-1",
+			},
+		},
+		{
+			name: "single: zero span ID",
+			headers: map[string]string{
+				b3Context: "ab000000000000000000000000000000-0000000000000000-1",
+			},
+		},
+		{
+			name: "multiple: zero trace ID",
+			headers: map[string]string{
+				b3TraceID: "0000000000000000",
+				b3SpanID:  "cd00000000000000",
+				b3Flags:   "1",
+			},
+		},
+		{
+			name: "multiple: zero span ID",
+			headers: map[string]string{
+				b3TraceID: "ab000000000000000000000000000000",
+				b3SpanID:  "0000000000000000",
+			},
+		},
+		{
+			name: "valid",
+			headers: map[string]string{
+				b3Context: "ab000000000000000000000000000000-cd00000000000000-1",
+			},
+			valid: true,
+		},
+	}
+
+	propagator := b3.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := make(http.Header, len(tt.headers))
+			for h, v := range tt.headers {
+				header.Set(h, v)
+			}
+
+			ctx := propagator.Extract(parent, propagation.HeaderCarrier(header))
+			if tt.valid {
+				assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+				return
+			}
+			assert.Equal(t, parent, ctx, "context should be returned unchanged")
+		})
+	}
+}
+
 type testSpan struct {
 	trace.Span
 	sc trace.SpanContext
@@ -47,6 +47,8 @@ var (
 	errInvalidTraceIDValue       = errors.New("invalid B3 traceID value found")
 	errInvalidSpanIDValue        = errors.New("invalid B3 spanID value found")
 	errInvalidParentSpanIDValue  = errors.New("invalid B3 ParentSpanID value found")
+	errZeroTraceID               = errors.New("all-zero B3 traceID found")
+	errZeroSpanID                = errors.New("all-zero B3 spanID found")
 )
 
 type propagator struct {
@@ -120,10 +122,14 @@ func (b3 propagator) Inject(ctx context.Context, carrier propagation.TextMapCarr
 }
 
 // Extract extracts a context from the carrier if it contains B3 headers.
+//
+// Headers with an all-zero trace ID or span ID are treated as not present and
+// ctx is returned unchanged.
 func (b3 propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
 	var (
-		sc  trace.SpanContext
-		err error
+		sc     trace.SpanContext
+		err    error
+		parent = ctx
 	)
 
 	// Default to Single Header if a valid value exists.
@@ -132,6 +138,9 @@ func (b3 propagator) Extract(ctx context.Context, carrier propagation.TextMapCar
 		if err == nil && sc.IsValid() {
 			return trace.ContextWithRemoteSpanContext(ctx, sc)
 		}
+		if errors.Is(err, errZeroTraceID) || errors.Is(err, errZeroSpanID) {
+			return parent
+		}
 		// The Single Header value was invalid, fallback to Multiple Header.
 	}
 
@@ -143,6 +152,10 @@ func (b3 propagator) Extract(ctx context.Context, carrier propagation.TextMapCar
 		debugFlag    = carrier.Get(b3DebugFlagHeader)
 	)
 	ctx, sc, err = extractMultiple(ctx, traceID, spanID, parentSpanID, sampled, debugFlag)
+	if errors.Is(err, errZeroTraceID) || errors.Is(err, errZeroSpanID) {
+		// Do not propagate the sampling state of an invalid context.
+		return parent
+	}
 	if err != nil || !sc.IsValid() {
 		// clear the deferred flag if we don't have a valid SpanContext
 		return withDeferred(ctx, false)
@@ -199,6 +212,9 @@ func extractMultiple(ctx context.Context, traceID, spanID, parentSpanID, sampled
 
 	if traceID != "" {
 		requiredCount++
+		if isZeroID(traceID) {
+			return ctx, empty, errZeroTraceID
+		}
 		id := traceID
 		if len(traceID) == 16 {
 			// Pad 64-bit trace IDs.
@@ -211,6 +227,9 @@ func extractMultiple(ctx context.Context, traceID, spanID, parentSpanID, sampled
 
 	if spanID != "" {
 		requiredCount++
+		if isZeroID(spanID) {
+			return ctx, empty, errZeroSpanID
+		}
 		if scc.SpanID, err = trace.SpanIDFromHex(spanID); err != nil {
 			return ctx, empty, errInvalidSpanIDHeader
 		}
@@ -270,6 +289,9 @@ func extractSingle(ctx context.Context, contextHeader string) (context.Context,
 		default:
 			return ctx, empty, errInvalidTraceIDValue
 		}
+		if isZeroID(traceID) {
+			return ctx, empty, errZeroTraceID
+		}
 		var err error
 		scc.TraceID, err = trace.TraceIDFromHex(traceID)
 		if err != nil {
@@ -277,6 +299,9 @@ func extractSingle(ctx context.Context, contextHeader string) (context.Context,
 		}
 		pos += separatorWidth // {traceID}-
 
+		if isZeroID(contextHeader[pos : pos+spanIDWidth]) {
+			return ctx, empty, errZeroSpanID
+		}
 		scc.SpanID, err = trace.SpanIDFromHex(contextHeader[pos : pos+spanIDWidth])
 		if err != nil {
 			return ctx, empty, errInvalidSpanIDValue
@@ -329,3 +354,10 @@ func extractSingle(ctx context.Context, contextHeader string) (context.Context,
 
 	return ctx, trace.NewSpanContext(scc), nil
 }
+
+// isZeroID reports whether the hex encoded ID is all zeros. These IDs are
+// sent by some misbehaving implementations and never identify a trace or
+// span.
+func isZeroID(id string) bool {
+	return strings.Trim(id, "0") == ""
+}
@@ -9,6 +9,7 @@ type jaegerKeyType int
 
 const (
 	debugKey jaegerKeyType = iota
+	firehoseKey
 )
 
 // withDebug returns a copy of parent with debug set as the debug flag value .
@@ -28,3 +29,22 @@ func debugFromContext(ctx context.Context) bool {
 	}
 	return false
 }
+
+// withFirehose returns a copy of parent with firehose set as the firehose
+// flag value.
+func withFirehose(parent context.Context, firehose bool) context.Context {
+	return context.WithValue(parent, firehoseKey, firehose)
+}
+
+// firehoseFromContext returns the firehose value stored in ctx.
+//
+// If no firehose value is stored in ctx false is returned.
+func firehoseFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if firehose, ok := ctx.Value(firehoseKey).(bool); ok {
+		return firehose
+	}
+	return false
+}
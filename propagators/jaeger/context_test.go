@@ -6,4 +6,7 @@ package jaeger
 var (
 	WithDebug        = withDebug
 	DebugFromContext = debugFromContext
+
+	WithFirehose        = withFirehose
+	FirehoseFromContext = firehoseFromContext
 )
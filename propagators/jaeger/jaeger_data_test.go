@@ -74,16 +74,40 @@ var extractHeaders = []extractTest{
 		true,
 	},
 	{
-		"sampling state debug but sampled bit didn't set, result in not sampled decision",
+		"sampling state debug without sampled bit, forces sampled decision",
 		map[string]string{
 			jaegerHeader: fmt.Sprintf("%s:%s:0:2", traceID32Str, spanIDStr),
 		},
+		trace.SpanContextConfig{
+			TraceID:    traceID32,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		},
+		true,
+	},
+	{
+		"sampling state firehose not sampled",
+		map[string]string{
+			jaegerHeader: fmt.Sprintf("%s:%s:0:8", traceID32Str, spanIDStr),
+		},
 		trace.SpanContextConfig{
 			TraceID: traceID32,
 			SpanID:  spanID,
 		},
 		false,
 	},
+	{
+		"sampling state firehose sampled",
+		map[string]string{
+			jaegerHeader: fmt.Sprintf("%s:%s:0:9", traceID32Str, spanIDStr),
+		},
+		trace.SpanContextConfig{
+			TraceID:    traceID32,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		},
+		false,
+	},
 	{
 		"flag can be various length",
 		map[string]string{
@@ -214,6 +238,7 @@ type injectTest struct {
 	scc         trace.SpanContextConfig
 	wantHeaders map[string]string
 	debug       bool
+	firehose    bool
 }
 
 var injectHeaders = []injectTest{
@@ -249,6 +274,17 @@ var injectHeaders = []injectTest{
 		wantHeaders: map[string]string{
 			jaegerHeader: fmt.Sprintf("%s:%s:0:0", traceID32Str, spanIDStr),
 		},
+	}, {
+		name: "firehose",
+		scc: trace.SpanContextConfig{
+			TraceID:    traceID32,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		},
+		wantHeaders: map[string]string{
+			jaegerHeader: fmt.Sprintf("%s:%s:0:9", traceID32Str, spanIDStr),
+		},
+		firehose: true,
 	},
 }
 
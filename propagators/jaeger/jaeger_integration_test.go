@@ -81,7 +81,7 @@ func TestInjectJaeger(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				header := http.Header{}
 				ctx := trace.ContextWithSpanContext(
-					jaeger.WithDebug(context.Background(), tc.debug),
+					jaeger.WithFirehose(jaeger.WithDebug(context.Background(), tc.debug), tc.firehose),
 					trace.NewSpanContext(tc.scc),
 				)
 				propagator.Inject(ctx, propagation.HeaderCarrier(header))
@@ -96,3 +96,44 @@ func TestInjectJaeger(t *testing.T) {
 		}
 	}
 }
+
+func TestJaegerFlagsRoundTrip(t *testing.T) {
+	const (
+		traceID = "a1ce929d0e0e4736a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	for _, tc := range []struct {
+		flags        string
+		wantFlags    string
+		wantSampled  bool
+		wantDebug    bool
+		wantFirehose bool
+	}{
+		{flags: "0", wantFlags: "0"},
+		{flags: "1", wantFlags: "1", wantSampled: true},
+		{flags: "2", wantFlags: "3", wantSampled: true, wantDebug: true},
+		{flags: "3", wantFlags: "3", wantSampled: true, wantDebug: true},
+		{flags: "8", wantFlags: "8", wantFirehose: true},
+		{flags: "9", wantFlags: "9", wantSampled: true, wantFirehose: true},
+		{flags: "a", wantFlags: "b", wantSampled: true, wantDebug: true, wantFirehose: true},
+		{flags: "b", wantFlags: "b", wantSampled: true, wantDebug: true, wantFirehose: true},
+	} {
+		t.Run(tc.flags, func(t *testing.T) {
+			propagator := jaeger.Jaeger{}
+			header := http.Header{}
+			header.Set("uber-trace-id", traceID+":"+spanID+":0:"+tc.flags)
+
+			ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+			sc := trace.SpanContextFromContext(ctx)
+			assert.Equal(t, traceID, sc.TraceID().String())
+			assert.Equal(t, spanID, sc.SpanID().String())
+			assert.Equal(t, tc.wantSampled, sc.IsSampled(), "sampled")
+			assert.Equal(t, tc.wantDebug, jaeger.DebugFromContext(ctx), "debug")
+			assert.Equal(t, tc.wantFirehose, jaeger.FirehoseFromContext(ctx), "firehose")
+
+			out := http.Header{}
+			propagator.Inject(ctx, propagation.HeaderCarrier(out))
+			assert.Equal(t, traceID+":"+spanID+":0:"+tc.wantFlags, out.Get("uber-trace-id"))
+		})
+	}
+}
@@ -22,6 +22,7 @@ const (
 
 	idPaddingChar = "0"
 
+	flagsFirehose   = 0x08
 	flagsDebug      = 0x02
 	flagsSampled    = 0x01
 	flagsNotSampled = 0x00
@@ -58,13 +59,16 @@ func (jaeger Jaeger) Inject(ctx context.Context, carrier propagation.TextMapCarr
 		return
 	}
 	headers = append(headers, sc.TraceID().String(), sc.SpanID().String(), deprecatedParentSpanID)
+	flags := flagsNotSampled
 	if debugFromContext(ctx) {
-		headers = append(headers, fmt.Sprintf("%x", flagsDebug|flagsSampled))
+		flags |= flagsDebug | flagsSampled
 	} else if sc.IsSampled() {
-		headers = append(headers, fmt.Sprintf("%x", flagsSampled))
-	} else {
-		headers = append(headers, fmt.Sprintf("%x", flagsNotSampled))
+		flags |= flagsSampled
 	}
+	if firehoseFromContext(ctx) {
+		flags |= flagsFirehose
+	}
+	headers = append(headers, fmt.Sprintf("%x", flags))
 
 	carrier.Set(jaegerHeader, strings.Join(headers, separator))
 }
@@ -137,15 +141,20 @@ func extract(ctx context.Context, headerVal string) (context.Context, trace.Span
 			return ctx, empty, errMalformedFlag
 		}
 		if flag&flagsSampled == flagsSampled {
-			// if sample bit is set, we check if debug bit is also set
-			if flag&flagsDebug == flagsDebug {
-				scc.TraceFlags |= trace.FlagsSampled
-				ctx = withDebug(ctx, true)
-			} else {
-				scc.TraceFlags |= trace.FlagsSampled
-			}
+			scc.TraceFlags |= trace.FlagsSampled
+		}
+		// The debug bit forces the trace to be sampled, even if the sampled
+		// bit is not set.
+		if flag&flagsDebug == flagsDebug {
+			scc.TraceFlags |= trace.FlagsSampled
+			ctx = withDebug(ctx, true)
+		}
+		// The firehose bit has no corresponding flag in the span context, it
+		// is kept in the context to be propagated by Inject.
+		if flag&flagsFirehose == flagsFirehose {
+			ctx = withFirehose(ctx, true)
 		}
-		// ignore other bit, including firehose since we don't have corresponding flag in trace context.
+		// ignore other bits.
 	}
 	return ctx, trace.NewSpanContext(scc), nil
 }
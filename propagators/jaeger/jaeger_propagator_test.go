@@ -77,18 +77,18 @@ func TestJaeger_Extract(t *testing.T) {
 			true,
 		},
 		{
-			// if we didn't set sampled bit when debug bit is 1, then assuming it's not sampled
+			// the debug bit forces a sampled decision even if the sampled bit is not set
 			traceID128Str, spanID64Str, deprecatedParentSpanID, "2",
 			trace.SpanContextConfig{
 				TraceID:    traceID,
 				SpanID:     spanID,
-				TraceFlags: 0x00,
+				TraceFlags: trace.FlagsSampled,
 			},
 			nil,
-			false,
+			true,
 		},
 		{
-			// ignore firehose bit since we don't really have this feature in otel span context
+			// the firehose bit does not change the span context, it is kept in the context
 			traceID128Str, spanID64Str, deprecatedParentSpanID, "8",
 			trace.SpanContextConfig{
 				TraceID:    traceID,
@@ -3,4 +3,8 @@
 
 // Package ot implements the ot-tracer-* propagator used by the default Tracer
 // implementation from the OpenTracing project.
+//
+// The sampling decision is propagated with the ot-tracer-sampled header. A
+// "debug" baggage member set to "true" or "1", propagated with the
+// ot-baggage-debug header, forces the trace to be sampled.
 package ot // import "go.opentelemetry.io/contrib/propagators/ot"
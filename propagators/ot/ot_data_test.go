@@ -22,6 +22,7 @@ const (
 	baggageKey2    = "test2"
 	baggageValue2  = "value456"
 	baggageHeader2 = "ot-baggage-test2"
+	debugHeader    = "ot-baggage-debug"
 )
 
 var (
@@ -123,6 +124,35 @@ var extractHeaders = []extractTest{
 		},
 		emptyBaggage,
 	},
+	{
+		"debug flag forces sampling",
+		map[string]string{
+			traceIDHeader: traceID32Str,
+			spanIDHeader:  spanIDStr,
+			sampledHeader: "false",
+			debugHeader:   "true",
+		},
+		trace.SpanContextConfig{
+			TraceID:    traceID32,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		},
+		map[string]string{"debug": "true"},
+	},
+	{
+		"debug flag disabled",
+		map[string]string{
+			traceIDHeader: traceID32Str,
+			spanIDHeader:  spanIDStr,
+			sampledHeader: "false",
+			debugHeader:   "false",
+		},
+		trace.SpanContextConfig{
+			TraceID: traceID32,
+			SpanID:  spanID,
+		},
+		map[string]string{"debug": "false"},
+	},
 }
 
 var invalidExtractHeaders = []extractTest{
@@ -267,6 +297,22 @@ var injectHeaders = []injectTest{
 			baggageHeader2: baggageValue2,
 		},
 	},
+	{
+		name: "not sampled debug",
+		sc: trace.SpanContextConfig{
+			TraceID: traceID32,
+			SpanID:  spanID,
+		},
+		baggage: map[string]string{
+			"debug": "1",
+		},
+		wantHeaders: map[string]string{
+			traceIDHeader: traceID16Str,
+			spanIDHeader:  spanIDStr,
+			sampledHeader: "true",
+			debugHeader:   "1",
+		},
+	},
 }
 
 var invalidInjectHeaders = []injectTest{
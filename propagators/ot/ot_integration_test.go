@@ -115,3 +115,49 @@ func TestInjectOT(t *testing.T) {
 		}
 	}
 }
+
+func TestRoundTripOT(t *testing.T) {
+	testCases := []struct {
+		name        string
+		flags       trace.TraceFlags
+		debug       string
+		wantSampled bool
+	}{
+		{name: "sampled", flags: trace.FlagsSampled, wantSampled: true},
+		{name: "not sampled", wantSampled: false},
+		{name: "not sampled debug", debug: "true", wantSampled: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID16,
+				SpanID:     spanID,
+				TraceFlags: tc.flags,
+			}))
+			if tc.debug != "" {
+				m, err := baggage.NewMember("debug", tc.debug)
+				if err != nil {
+					t.Fatal(err)
+				}
+				bag, err := baggage.New(m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ctx = baggage.ContextWithBaggage(ctx, bag)
+			}
+
+			propagator := ot.OT{}
+			header := http.Header{}
+			propagator.Inject(ctx, propagation.HeaderCarrier(header))
+			got := trace.SpanContextFromContext(propagator.Extract(context.Background(), propagation.HeaderCarrier(header)))
+
+			if got.TraceID() != traceID16 || got.SpanID() != spanID {
+				t.Errorf("got trace ID %s, span ID %s", got.TraceID(), got.SpanID())
+			}
+			if got.IsSampled() != tc.wantSampled {
+				t.Errorf("got sampled %t, want %t", got.IsSampled(), tc.wantSampled)
+			}
+		})
+	}
+}
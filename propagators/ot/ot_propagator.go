@@ -23,6 +23,11 @@ const (
 	sampledHeader       = "ot-tracer-sampled"
 	baggageHeaderPrefix = "ot-baggage-"
 
+	// debugBaggageKey is the key of the baggage member forcing the trace to
+	// be sampled, as the legacy OpenTracing debug flag. It is propagated with
+	// the ot-baggage-debug header.
+	debugBaggageKey = "debug"
+
 	otTraceIDPadding = "0000000000000000"
 
 	traceID64BitsWidth = 64 / 4 // 16 hex character Trace ID.
@@ -55,13 +60,14 @@ func (o OT) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
 	carrier.Set(traceIDHeader, sc.TraceID().String()[len(sc.TraceID().String())-traceID64BitsWidth:])
 	carrier.Set(spanIDHeader, sc.SpanID().String())
 
-	if sc.IsSampled() {
+	bags := baggage.FromContext(ctx)
+	if sc.IsSampled() || isDebug(bags) {
 		carrier.Set(sampledHeader, "true")
 	} else {
 		carrier.Set(sampledHeader, "false")
 	}
 
-	for _, m := range baggage.FromContext(ctx).Members() {
+	for _, m := range bags.Members() {
 		carrier.Set(fmt.Sprintf("%s%s", baggageHeaderPrefix, m.Key()), m.Value())
 	}
 }
@@ -87,10 +93,24 @@ func (o OT) Extract(ctx context.Context, carrier propagation.TextMapCarrier) con
 	if err != nil {
 		return trace.ContextWithRemoteSpanContext(ctx, sc)
 	}
+	if isDebug(bags) {
+		// The debug flag forces the trace to be sampled, even if the sampled
+		// header says otherwise.
+		sc = sc.WithTraceFlags(sc.TraceFlags() | trace.FlagsSampled)
+	}
 	ctx = baggage.ContextWithBaggage(ctx, bags)
 	return trace.ContextWithRemoteSpanContext(ctx, sc)
 }
 
+// isDebug returns true if bags contains the debug flag.
+func isDebug(bags baggage.Baggage) bool {
+	switch strings.ToLower(bags.Member(debugBaggageKey).Value()) {
+	case "1", "true":
+		return true
+	}
+	return false
+}
+
 // Fields returns the OT header keys whose values are set with Inject.
 func (o OT) Fields() []string {
 	return []string{traceIDHeader, spanIDHeader, sampledHeader}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testutils // import "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/testutils"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/samplers/jaegerremote/internal/utils"
+)
+
+// FakeClock is a utils.Clock whose time only changes when it is advanced
+// with Advance, which makes the tests depending on time deterministic.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+var _ utils.Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a Ticker sending the time of the clock when it is
+// advanced past the next tick.
+func (c *FakeClock) NewTicker(d time.Duration) utils.Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTicker{
+		c:      make(chan time.Time, 1),
+		period: d,
+		next:   c.now.Add(d),
+	}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// Advance moves the time of the clock forward by d, and sends a tick on the
+// tickers whose next tick is due. Like a time.Ticker, a tick is dropped if
+// the previous one was not received yet.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		t.tick(c.now)
+	}
+}
+
+type fakeTicker struct {
+	mu      sync.Mutex
+	c       chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+
+func (t *fakeTicker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+}
+
+func (t *fakeTicker) tick(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped || now.Before(t.next) {
+		return
+	}
+	for !now.Before(t.next) {
+		t.next = t.next.Add(t.period)
+	}
+	select {
+	case t.c <- now:
+	default:
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils // import "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/utils"
+
+import "time"
+
+// Clock is the source of time of the sampler: the time used to replenish the
+// rate limiters and the tickers polling the sampling strategies. It can be
+// replaced in tests to control the time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTicker returns a Ticker sending the time every d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker sends the time on a channel at regular intervals.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker. No more ticks are sent after Stop returns.
+	Stop()
+}
+
+// RealClock is the Clock using the wall-clock time of the time package.
+type RealClock struct{}
+
+var _ Clock = RealClock{}
+
+// Now returns time.Now().
+func (RealClock) Now() time.Time { return time.Now() }
+
+// NewTicker returns a Ticker backed by a time.Ticker.
+func (RealClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
@@ -51,6 +51,15 @@ type RateLimiter struct {
 
 // NewRateLimiter creates a new RateLimiter.
 func NewRateLimiter(creditsPerSecond, maxBalance float64) *RateLimiter {
+	return NewRateLimiterWithClock(creditsPerSecond, maxBalance, RealClock{})
+}
+
+// NewRateLimiterWithClock creates a new RateLimiter replenishing its balance
+// with the time of clock. RealClock is used if clock is nil.
+func NewRateLimiterWithClock(creditsPerSecond, maxBalance float64, clock Clock) *RateLimiter {
+	if clock == nil {
+		clock = RealClock{}
+	}
 	balance := maxBalance
 	if creditsPerSecond == 0 {
 		balance = 0
@@ -60,8 +69,8 @@ func NewRateLimiter(creditsPerSecond, maxBalance float64) *RateLimiter {
 		creditsPerSecond: creditsPerSecond,
 		balance:          balance,
 		maxBalance:       maxBalance,
-		lastTick:         time.Now(),
-		timeNow:          time.Now,
+		lastTick:         clock.Now(),
+		timeNow:          clock.Now,
 	}
 }
 
@@ -26,6 +26,7 @@ import (
 
 	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
 	"go.opentelemetry.io/contrib/samplers/jaegerremote/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/trace"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -34,6 +35,24 @@ const (
 	defaultMaxOperations = 2000
 )
 
+// Attributes describing the sampling decision, added to sampled spans when
+// enabled with WithAttributesOn. They match the tags set by the Jaeger SDKs.
+const (
+	samplerTypeKey  = attribute.Key("sampler.type")
+	samplerParamKey = attribute.Key("sampler.param")
+
+	samplerTypeProbabilistic = "probabilistic"
+	samplerTypeRateLimiting  = "ratelimiting"
+	samplerTypeLowerBound    = "lowerbound"
+)
+
+func samplerAttributes(samplerType string, param float64) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		samplerTypeKey.String(samplerType),
+		samplerParamKey.Float64(param),
+	}
+}
+
 // -----------------------
 
 // probabilisticSampler is a sampler that randomly samples a certain percentage
@@ -41,6 +60,7 @@ const (
 type probabilisticSampler struct {
 	samplingRate     float64
 	samplingBoundary uint64
+	attributesOn     bool
 }
 
 const maxRandomNumber = ^(uint64(1) << 63) // i.e. 0x7fffffffffffffff
@@ -50,8 +70,8 @@ const maxRandomNumber = ^(uint64(1) << 63) // i.e. 0x7fffffffffffffff
 //
 // It relies on the fact that new trace IDs are 63bit random numbers themselves, thus making the sampling decision
 // without generating a new random number, but simply calculating if traceID < (samplingRate * 2^63).
-func newProbabilisticSampler(samplingRate float64) *probabilisticSampler {
-	s := new(probabilisticSampler)
+func newProbabilisticSampler(samplingRate float64, attributesOn bool) *probabilisticSampler {
+	s := &probabilisticSampler{attributesOn: attributesOn}
 	return s.init(samplingRate)
 }
 
@@ -70,10 +90,14 @@ func (s *probabilisticSampler) ShouldSample(p trace.SamplingParameters) trace.Sa
 	psc := oteltrace.SpanContextFromContext(p.ParentContext)
 	traceID := binary.BigEndian.Uint64(p.TraceID[0:8])
 	if s.samplingBoundary >= traceID&maxRandomNumber {
-		return trace.SamplingResult{
+		result := trace.SamplingResult{
 			Decision:   trace.RecordAndSample,
 			Tracestate: psc.TraceState(),
 		}
+		if s.attributesOn {
+			result.Attributes = samplerAttributes(samplerTypeProbabilistic, s.samplingRate)
+		}
+		return result
 	}
 	return trace.SamplingResult{
 		Decision:   trace.Drop,
@@ -111,17 +135,20 @@ func (s *probabilisticSampler) Description() string {
 type rateLimitingSampler struct {
 	maxTracesPerSecond float64
 	rateLimiter        *utils.RateLimiter
+	attributesOn       bool
+	clock              utils.Clock
 }
 
-// newRateLimitingSampler creates new rateLimitingSampler.
-func newRateLimitingSampler(maxTracesPerSecond float64) *rateLimitingSampler {
-	s := new(rateLimitingSampler)
+// newRateLimitingSampler creates new rateLimitingSampler. The rate limiter
+// is replenished with the time of clock, the wall-clock time if nil.
+func newRateLimitingSampler(maxTracesPerSecond float64, attributesOn bool, clock utils.Clock) *rateLimitingSampler {
+	s := &rateLimitingSampler{attributesOn: attributesOn, clock: clock}
 	return s.init(maxTracesPerSecond)
 }
 
 func (s *rateLimitingSampler) init(maxTracesPerSecond float64) *rateLimitingSampler {
 	if s.rateLimiter == nil {
-		s.rateLimiter = utils.NewRateLimiter(maxTracesPerSecond, math.Max(maxTracesPerSecond, 1.0))
+		s.rateLimiter = utils.NewRateLimiterWithClock(maxTracesPerSecond, math.Max(maxTracesPerSecond, 1.0), s.clock)
 	} else {
 		s.rateLimiter.Update(maxTracesPerSecond, math.Max(maxTracesPerSecond, 1.0))
 	}
@@ -132,10 +159,14 @@ func (s *rateLimitingSampler) init(maxTracesPerSecond float64) *rateLimitingSamp
 func (s *rateLimitingSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
 	psc := oteltrace.SpanContextFromContext(p.ParentContext)
 	if s.rateLimiter.CheckCredit(1.0) {
-		return trace.SamplingResult{
+		result := trace.SamplingResult{
 			Decision:   trace.RecordAndSample,
 			Tracestate: psc.TraceState(),
 		}
+		if s.attributesOn {
+			result.Attributes = samplerAttributes(samplerTypeRateLimiting, s.maxTracesPerSecond)
+		}
+		return result
 	}
 	return trace.SamplingResult{
 		Decision:   trace.Drop,
@@ -177,12 +208,14 @@ type guaranteedThroughputProbabilisticSampler struct {
 	lowerBoundSampler    *rateLimitingSampler
 	samplingRate         float64
 	lowerBound           float64
+	attributesOn         bool
 }
 
-func newGuaranteedThroughputProbabilisticSampler(lowerBound, samplingRate float64) *guaranteedThroughputProbabilisticSampler {
+func newGuaranteedThroughputProbabilisticSampler(lowerBound, samplingRate float64, attributesOn bool, clock utils.Clock) *guaranteedThroughputProbabilisticSampler {
 	s := &guaranteedThroughputProbabilisticSampler{
-		lowerBoundSampler: newRateLimitingSampler(lowerBound),
+		lowerBoundSampler: newRateLimitingSampler(lowerBound, false, clock),
 		lowerBound:        lowerBound,
+		attributesOn:      attributesOn,
 	}
 	s.setProbabilisticSampler(samplingRate)
 	return s
@@ -190,7 +223,7 @@ func newGuaranteedThroughputProbabilisticSampler(lowerBound, samplingRate float6
 
 func (s *guaranteedThroughputProbabilisticSampler) setProbabilisticSampler(samplingRate float64) {
 	if s.probabilisticSampler == nil {
-		s.probabilisticSampler = newProbabilisticSampler(samplingRate)
+		s.probabilisticSampler = newProbabilisticSampler(samplingRate, s.attributesOn)
 	} else if s.samplingRate != samplingRate {
 		s.probabilisticSampler.init(samplingRate)
 	}
@@ -204,6 +237,9 @@ func (s *guaranteedThroughputProbabilisticSampler) ShouldSample(p trace.Sampling
 		return result
 	}
 	result := s.lowerBoundSampler.ShouldSample(p)
+	if result.Decision == trace.RecordAndSample && s.attributesOn {
+		result.Attributes = samplerAttributes(samplerTypeLowerBound, s.samplingRate)
+	}
 	return result
 }
 
@@ -231,6 +267,8 @@ type perOperationSampler struct {
 	defaultSampler *probabilisticSampler
 	lowerBound     float64
 	maxOperations  int
+	attributesOn   bool
+	clock          utils.Clock
 
 	// see description in perOperationSamplerParams
 	operationNameLateBinding bool
@@ -249,6 +287,13 @@ type perOperationSamplerParams struct {
 	// For backwards compatibility this option is off by default.
 	OperationNameLateBinding bool
 
+	// Add attributes describing the sampling decision to sampled spans.
+	AttributesOn bool
+
+	// Source of time of the rate limiters of the lower bound samplers, the
+	// wall-clock time if nil.
+	Clock utils.Clock
+
 	// Initial configuration of the sampling strategies (usually retrieved from the backend by Remote Sampler).
 	Strategies *jaeger_api_v2.PerOperationSamplingStrategies
 }
@@ -263,14 +308,18 @@ func newPerOperationSampler(params perOperationSamplerParams) *perOperationSampl
 		sampler := newGuaranteedThroughputProbabilisticSampler(
 			params.Strategies.DefaultLowerBoundTracesPerSecond,
 			strategy.ProbabilisticSampling.SamplingRate,
+			params.AttributesOn,
+			params.Clock,
 		)
 		samplers[strategy.Operation] = sampler
 	}
 	return &perOperationSampler{
 		samplers:                 samplers,
-		defaultSampler:           newProbabilisticSampler(params.Strategies.DefaultSamplingProbability),
+		defaultSampler:           newProbabilisticSampler(params.Strategies.DefaultSamplingProbability, params.AttributesOn),
 		lowerBound:               params.Strategies.DefaultLowerBoundTracesPerSecond,
 		maxOperations:            params.MaxOperations,
+		attributesOn:             params.AttributesOn,
+		clock:                    params.Clock,
 		operationNameLateBinding: params.OperationNameLateBinding,
 	}
 }
@@ -300,7 +349,7 @@ func (s *perOperationSampler) getSamplerForOperation(operation string) trace.Sam
 	if len(s.samplers) >= s.maxOperations {
 		return s.defaultSampler
 	}
-	newSampler := newGuaranteedThroughputProbabilisticSampler(s.lowerBound, s.defaultSampler.SamplingRate())
+	newSampler := newGuaranteedThroughputProbabilisticSampler(s.lowerBound, s.defaultSampler.SamplingRate(), s.attributesOn, s.clock)
 	s.samplers[operation] = newSampler
 	return newSampler
 }
@@ -324,13 +373,15 @@ func (s *perOperationSampler) update(strategies *jaeger_api_v2.PerOperationSampl
 			sampler := newGuaranteedThroughputProbabilisticSampler(
 				lowerBound,
 				samplingRate,
+				s.attributesOn,
+				s.clock,
 			)
 			newSamplers[operation] = sampler
 		}
 	}
 	s.lowerBound = strategies.DefaultLowerBoundTracesPerSecond
 	if s.defaultSampler.SamplingRate() != strategies.DefaultSamplingProbability {
-		s.defaultSampler = newProbabilisticSampler(strategies.DefaultSamplingProbability)
+		s.defaultSampler = newProbabilisticSampler(strategies.DefaultSamplingProbability, s.attributesOn)
 	}
 	s.samplers = newSamplers
 }
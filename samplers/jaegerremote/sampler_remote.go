@@ -20,6 +20,7 @@ package jaegerremote // import "go.opentelemetry.io/contrib/samplers/jaegerremot
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -31,6 +32,7 @@ import (
 	"github.com/gogo/protobuf/jsonpb"
 
 	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
+	"go.opentelemetry.io/contrib/samplers/jaegerremote/internal/utils"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -99,6 +101,11 @@ func New(
 // ShouldSample returns a sampling choice based on the passed sampling
 // parameters.
 func (s *Sampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	// The overrides are not modified once the Sampler is created.
+	if sampler, ok := s.operationOverrides[p.Name]; ok {
+		return sampler.ShouldSample(p)
+	}
+
 	s.RLock()
 	defer s.RUnlock()
 	return s.sampler.ShouldSample(p)
@@ -123,18 +130,35 @@ func (s *Sampler) Description() string {
 	return "JaegerRemoteSampler{}"
 }
 
+// SetSamplingServerURL changes the URL of the sampling server the sampling
+// strategy is fetched from. The new URL is used from the next fetch, on the
+// next tick of the sampling refresh interval or call to UpdateSampler. This
+// allows the sampling server to move, for example when its address is found
+// with service discovery, without recreating the Sampler.
+//
+// This has no effect if a custom fetcher is configured with
+// WithSamplingStrategyFetcher, an error is logged instead.
+func (s *Sampler) SetSamplingServerURL(samplingServerURL string) {
+	f, ok := s.samplingFetcher.(*httpSamplingStrategyFetcher)
+	if !ok {
+		s.logger.Error(errors.New("custom sampling strategy fetcher"), "cannot set the sampling server URL", "url", samplingServerURL)
+		return
+	}
+	f.serverURL.Store(samplingServerURL)
+}
+
 func (s *Sampler) pollController() {
-	ticker := time.NewTicker(s.samplingRefreshInterval)
+	ticker := s.clock.NewTicker(s.samplingRefreshInterval)
 	defer ticker.Stop()
-	s.pollControllerWithTicker(ticker)
+	s.pollControllerWithTicker(ticker.C())
 }
 
-func (s *Sampler) pollControllerWithTicker(ticker *time.Ticker) {
+func (s *Sampler) pollControllerWithTicker(tick <-chan time.Time) {
 	s.UpdateSampler()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-tick:
 			s.UpdateSampler()
 		case wg := <-s.doneChan:
 			wg.Done()
@@ -170,6 +194,7 @@ func (s *Sampler) UpdateSampler() {
 		s.logger.Error(err, "failed to handle sampling strategy response", "response", res)
 		return
 	}
+	s.logger.V(1).Info("sampling strategy updated", "sampler", s.sampler.Description())
 }
 
 // NB: this function should only be called while holding a Write lock.
@@ -190,7 +215,9 @@ func (s *Sampler) updateSamplerViaUpdaters(strategy interface{}) error {
 // -----------------------
 
 // probabilisticSamplerUpdater is used by Sampler to parse sampling configuration.
-type probabilisticSamplerUpdater struct{}
+type probabilisticSamplerUpdater struct {
+	AttributesOn bool
+}
 
 // Update implements Update of samplerUpdater.
 func (u *probabilisticSamplerUpdater) Update(sampler trace.Sampler, strategy interface{}) (trace.Sampler, error) {
@@ -206,7 +233,7 @@ func (u *probabilisticSamplerUpdater) Update(sampler trace.Sampler, strategy int
 				}
 				return sampler, nil
 			}
-			return newProbabilisticSampler(probabilistic.SamplingRate), nil
+			return newProbabilisticSampler(probabilistic.SamplingRate, u.AttributesOn), nil
 		}
 	}
 	return nil, nil
@@ -215,7 +242,10 @@ func (u *probabilisticSamplerUpdater) Update(sampler trace.Sampler, strategy int
 // -----------------------
 
 // rateLimitingSamplerUpdater is used by Sampler to parse sampling configuration.
-type rateLimitingSamplerUpdater struct{}
+type rateLimitingSamplerUpdater struct {
+	AttributesOn bool
+	Clock        utils.Clock
+}
 
 // Update implements Update of samplerUpdater.
 func (u *rateLimitingSamplerUpdater) Update(sampler trace.Sampler, strategy interface{}) (trace.Sampler, error) {
@@ -230,7 +260,7 @@ func (u *rateLimitingSamplerUpdater) Update(sampler trace.Sampler, strategy inte
 				rl.Update(rateLimit)
 				return rl, nil
 			}
-			return newRateLimitingSampler(rateLimit), nil
+			return newRateLimitingSampler(rateLimit, u.AttributesOn, u.Clock), nil
 		}
 	}
 	return nil, nil
@@ -243,6 +273,8 @@ func (u *rateLimitingSamplerUpdater) Update(sampler trace.Sampler, strategy inte
 type perOperationSamplerUpdater struct {
 	MaxOperations            int
 	OperationNameLateBinding bool
+	AttributesOn             bool
+	Clock                    utils.Clock
 }
 
 // Update implements Update of samplerUpdater.
@@ -260,6 +292,8 @@ func (u *perOperationSamplerUpdater) Update(sampler trace.Sampler, strategy inte
 			return newPerOperationSampler(perOperationSamplerParams{
 				MaxOperations:            u.MaxOperations,
 				OperationNameLateBinding: u.OperationNameLateBinding,
+				AttributesOn:             u.AttributesOn,
+				Clock:                    u.Clock,
 				Strategies:               operations,
 			}), nil
 		}
@@ -270,23 +304,24 @@ func (u *perOperationSamplerUpdater) Update(sampler trace.Sampler, strategy inte
 // -----------------------
 
 type httpSamplingStrategyFetcher struct {
-	serverURL  string
+	serverURL  atomic.Value // string
 	httpClient http.Client
 }
 
 func newHTTPSamplingStrategyFetcher(serverURL string) *httpSamplingStrategyFetcher {
-	return &httpSamplingStrategyFetcher{
-		serverURL: serverURL,
+	f := &httpSamplingStrategyFetcher{
 		httpClient: http.Client{
 			Timeout: defaultRemoteSamplingTimeout,
 		},
 	}
+	f.serverURL.Store(serverURL)
+	return f
 }
 
 func (f *httpSamplingStrategyFetcher) Fetch(serviceName string) ([]byte, error) {
 	v := url.Values{}
 	v.Set("service", serviceName)
-	uri := f.serverURL + "?" + v.Encode()
+	uri := f.serverURL.Load().(string) + "?" + v.Encode()
 
 	resp, err := f.httpClient.Get(uri)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/go-logr/logr"
 
+	"go.opentelemetry.io/contrib/samplers/jaegerremote/internal/utils"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -35,32 +36,44 @@ type config struct {
 	updaters                []samplerUpdater
 	posParams               perOperationSamplerParams
 	logger                  logr.Logger
+	attributesOn            bool
+	clock                   utils.Clock
+	operationOverrides      map[string]trace.Sampler
 }
 
 // newConfig returns an appropriately configured config.
 func newConfig(options ...Option) config {
 	c := config{
-		sampler:                 newProbabilisticSampler(0.001),
 		samplingServerURL:       defaultSamplingServerURL,
 		samplingRefreshInterval: defaultSamplingRefreshInterval,
 		samplingFetcher:         newHTTPSamplingStrategyFetcher(defaultSamplingServerURL),
 		samplingParser:          new(samplingStrategyParserImpl),
-		updaters: []samplerUpdater{
-			new(probabilisticSamplerUpdater),
-			new(rateLimitingSamplerUpdater),
-		},
 		posParams: perOperationSamplerParams{
 			MaxOperations:            defaultSamplingMaxOperations,
 			OperationNameLateBinding: defaultSamplingOperationNameLateBinding,
 		},
 		logger: logr.Discard(),
+		clock:  utils.RealClock{},
 	}
 	for _, option := range options {
 		option.apply(&c)
 	}
+	// The defaults depend on the attributesOn option and are therefore
+	// created after all options have been applied.
+	if c.sampler == nil {
+		c.sampler = newProbabilisticSampler(0.001, c.attributesOn)
+	}
+	if c.updaters == nil {
+		c.updaters = []samplerUpdater{
+			&probabilisticSamplerUpdater{AttributesOn: c.attributesOn},
+			&rateLimitingSamplerUpdater{AttributesOn: c.attributesOn, Clock: c.clock},
+		}
+	}
 	c.updaters = append([]samplerUpdater{&perOperationSamplerUpdater{
 		MaxOperations:            c.posParams.MaxOperations,
 		OperationNameLateBinding: c.posParams.OperationNameLateBinding,
+		AttributesOn:             c.attributesOn,
+		Clock:                    c.clock,
 	}}, c.updaters...)
 	return c
 }
@@ -119,12 +132,51 @@ func WithSamplingRefreshInterval(samplingRefreshInterval time.Duration) Option {
 }
 
 // WithLogger configures the sampler to log operation and debug information with logger.
+//
+// Errors fetching, parsing, or applying a sampling strategy are logged as
+// errors. Successful strategy updates are logged at verbosity level 1. If this
+// option is not provided, all log messages are discarded.
 func WithLogger(logger logr.Logger) Option {
 	return optionFunc(func(c *config) {
 		c.logger = logger
 	})
 }
 
+// WithAttributesOn creates an Option that makes the sampler add attributes
+// describing its decision to the sampled spans. The "sampler.type" attribute
+// holds the type of the sampler that made the decision ("probabilistic",
+// "ratelimiting" or "lowerbound") and "sampler.param" holds its parameter:
+// the sampling probability, or the maximum number of traces per second for
+// the rate limiting sampler. This matches the behavior of the Jaeger SDKs.
+//
+// The attributes are only added by the samplers created from remote
+// sampling strategies and the default initial sampler, not by a sampler set
+// with WithInitialSampler.
+func WithAttributesOn() Option {
+	return optionFunc(func(c *config) {
+		c.attributesOn = true
+	})
+}
+
+// WithOperationOverride creates an Option that makes the sampler use sampler
+// for the spans named operation, e.g. trace.AlwaysSample() for a critical
+// endpoint. The override takes precedence over the sampling strategies of the
+// server, including their per-operation strategies, and over the initial
+// sampler. The last sampler is used if the option is used several times for
+// the same operation, and a nil sampler removes the override of the operation.
+func WithOperationOverride(operation string, sampler trace.Sampler) Option {
+	return optionFunc(func(c *config) {
+		if sampler == nil {
+			delete(c.operationOverrides, operation)
+			return
+		}
+		if c.operationOverrides == nil {
+			c.operationOverrides = make(map[string]trace.Sampler)
+		}
+		c.operationOverrides[operation] = sampler
+	})
+}
+
 // WithSamplingStrategyFetcher creates an Option that initializes the sampling strategy fetcher.
 // Custom fetcher can be used for setting custom headers, timeouts, etc., or getting
 // sampling strategies from a different source, like files.
@@ -134,6 +186,22 @@ func WithSamplingStrategyFetcher(fetcher SamplingStrategyFetcher) Option {
 	})
 }
 
+// WithStrategyParser creates an Option that replaces the parser of the
+// responses of the sampling strategy fetcher. This can be used for sampling
+// strategies in a format extending the one of Jaeger, for example with
+// rates specific to a tenant. The Strategy returned by parser is applied by
+// the Sampler, it is not applied if parser returns an error.
+//
+// By default, the responses are parsed as the JSON sampling strategies of
+// Jaeger.
+func WithStrategyParser(parser func([]byte) (Strategy, error)) Option {
+	return optionFunc(func(c *config) {
+		if parser != nil {
+			c.samplingParser = strategyParserFunc(parser)
+		}
+	})
+}
+
 // samplingStrategyParser creates a Option that initializes sampling strategy parser.
 func withSamplingStrategyParser(parser samplingStrategyParser) Option {
 	return optionFunc(func(c *config) {
@@ -147,3 +215,12 @@ func withUpdaters(updaters ...samplerUpdater) Option {
 		c.updaters = updaters
 	})
 }
+
+// withClock creates a Option that sets the source of time of the sampler,
+// used by the ticker polling the sampling strategies and by the rate
+// limiters of the samplers created from them.
+func withClock(clock utils.Clock) Option {
+	return optionFunc(func(c *config) {
+		c.clock = clock
+	})
+}
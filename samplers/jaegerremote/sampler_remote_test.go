@@ -22,22 +22,28 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/go-logr/logr/testr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
 	"go.opentelemetry.io/contrib/samplers/jaegerremote/internal/testutils"
+	"go.opentelemetry.io/contrib/samplers/jaegerremote/internal/utils"
 	"go.opentelemetry.io/otel/sdk/trace"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 func TestRemotelyControlledSampler_updateConcurrentSafe(t *testing.T) {
-	initSampler := newProbabilisticSampler(0.123)
+	initSampler := newProbabilisticSampler(0.123, false)
 	fetcher := &testSamplingStrategyFetcher{response: []byte("probabilistic")}
 	parser := new(testSamplingStrategyParser)
 	updaters := []samplerUpdater{new(probabilisticSamplerUpdater)}
@@ -106,7 +112,7 @@ func (p *testSamplingStrategyParser) Parse(response []byte) (interface{}, error)
 }
 
 func TestRemoteSamplerOptions(t *testing.T) {
-	initSampler := newProbabilisticSampler(0.123)
+	initSampler := newProbabilisticSampler(0.123, false)
 	fetcher := new(fakeSamplingFetcher)
 	parser := new(samplingStrategyParserImpl)
 	logger := testr.New(t)
@@ -131,10 +137,85 @@ func TestRemoteSamplerOptions(t *testing.T) {
 	assert.Equal(t, 42*time.Second, sampler.samplingRefreshInterval)
 	assert.Same(t, fetcher, sampler.samplingFetcher)
 	assert.Same(t, parser, sampler.samplingParser)
-	assert.EqualValues(t, sampler.updaters[0], &perOperationSamplerUpdater{MaxOperations: 42, OperationNameLateBinding: true})
+	assert.EqualValues(t, sampler.updaters[0], &perOperationSamplerUpdater{MaxOperations: 42, OperationNameLateBinding: true, Clock: utils.RealClock{}})
 	assert.Equal(t, logger, sampler.logger)
 }
 
+func TestRemoteSamplerWithAttributesOn(t *testing.T) {
+	sampler := New("test", WithAttributesOn(), WithSamplingRefreshInterval(time.Hour))
+	defer sampler.Close()
+
+	result := sampler.ShouldSample(makeSamplingParameters(0, testOperationName))
+	assert.Equal(t, trace.RecordAndSample, result.Decision)
+	assert.Equal(t, samplerAttributes(samplerTypeProbabilistic, 0.001), result.Attributes)
+
+	err := sampler.updateSamplerViaUpdaters(&jaeger_api_v2.SamplingStrategyResponse{
+		StrategyType: jaeger_api_v2.SamplingStrategyType_RATE_LIMITING,
+		RateLimitingSampling: &jaeger_api_v2.RateLimitingSamplingStrategy{
+			MaxTracesPerSecond: 10,
+		},
+	})
+	require.NoError(t, err)
+	result = sampler.ShouldSample(makeSamplingParameters(0, testOperationName))
+	assert.Equal(t, trace.RecordAndSample, result.Decision)
+	assert.Equal(t, samplerAttributes(samplerTypeRateLimiting, 10), result.Attributes)
+}
+
+func TestRemoteSamplerOperationOverride(t *testing.T) {
+	sampler := New(
+		"test",
+		WithSamplingRefreshInterval(time.Hour),
+		WithSamplingStrategyFetcher(new(fakeSamplingFetcher)),
+		WithOperationOverride("critical", trace.AlwaysSample()),
+	)
+	defer sampler.Close()
+
+	// The remote strategy never samples the operations, including the
+	// overridden one.
+	err := sampler.updateSamplerViaUpdaters(&jaeger_api_v2.SamplingStrategyResponse{
+		StrategyType: jaeger_api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{
+			SamplingRate: 0,
+		},
+		OperationSampling: &jaeger_api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability: 0,
+			PerOperationStrategies: []*jaeger_api_v2.OperationSamplingStrategy{
+				{
+					Operation: "critical",
+					ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{
+						SamplingRate: 0,
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	for i := uint64(1); i <= 100; i++ {
+		assert.Equal(t, trace.RecordAndSample, sampler.ShouldSample(makeSamplingParameters(i, "critical")).Decision)
+		assert.Equal(t, trace.Drop, sampler.ShouldSample(makeSamplingParameters(i, testOperationName)).Decision)
+	}
+}
+
+func TestRemoteSamplerOperationOverrideNil(t *testing.T) {
+	sampler := New(
+		"test",
+		WithSamplingRefreshInterval(time.Hour),
+		WithSamplingStrategyFetcher(new(fakeSamplingFetcher)),
+		WithInitialSampler(trace.NeverSample()),
+		WithOperationOverride("critical", trace.AlwaysSample()),
+		WithOperationOverride("critical", nil),
+		WithOperationOverride("other", nil),
+	)
+	defer sampler.Close()
+
+	// The nil samplers remove the override, the initial sampler is used.
+	assert.Empty(t, sampler.operationOverrides)
+	for _, name := range []string{"critical", "other"} {
+		assert.Equal(t, trace.Drop, sampler.ShouldSample(makeSamplingParameters(1, name)).Decision)
+	}
+}
+
 func TestRemoteSamplerOptionsDefaults(t *testing.T) {
 	options := newConfig()
 	sampler, ok := options.sampler.(*probabilisticSampler)
@@ -149,7 +230,7 @@ func initAgent(t *testing.T) (*testutils.MockAgent, *Sampler) {
 	agent, err := testutils.StartMockAgent()
 	require.NoError(t, err)
 
-	initialSampler := newProbabilisticSampler(0.001)
+	initialSampler := newProbabilisticSampler(0.001, false)
 	sampler := New(
 		"client app",
 		WithSamplingServerURL("http://"+agent.SamplingServerAddr()),
@@ -176,7 +257,7 @@ func TestRemotelyControlledSampler(t *testing.T) {
 	agent, remoteSampler := initAgent(t)
 	defer agent.Close()
 
-	defaultSampler := newProbabilisticSampler(0.001)
+	defaultSampler := newProbabilisticSampler(0.001, false)
 	remoteSampler.setSampler(defaultSampler)
 
 	agent.AddSamplingStrategy("client app",
@@ -194,13 +275,12 @@ func TestRemotelyControlledSampler(t *testing.T) {
 	remoteSampler.setSampler(defaultSampler)
 
 	c := make(chan time.Time)
-	ticker := &time.Ticker{C: c}
 	// reset closed so the next call to Close() correctly stops the polling goroutine
 	remoteSampler.closed = 0
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		remoteSampler.pollControllerWithTicker(ticker)
+		remoteSampler.pollControllerWithTicker(c)
 	}()
 
 	c <- time.Now() // force update based on timer
@@ -212,6 +292,60 @@ func TestRemotelyControlledSampler(t *testing.T) {
 	assert.EqualValues(t, testDefaultSamplingProbability, s2.samplingRate, "Sampler should have been updated from timer")
 }
 
+type countingSamplingStrategyFetcher struct {
+	testSamplingStrategyFetcher
+	count atomic.Int64
+}
+
+func (c *countingSamplingStrategyFetcher) Fetch(serviceName string) ([]byte, error) {
+	c.count.Add(1)
+	return c.testSamplingStrategyFetcher.Fetch(serviceName)
+}
+
+func TestRemotelyControlledSamplerClock(t *testing.T) {
+	clock := testutils.NewFakeClock(time.Unix(0, 0))
+	fetcher := &countingSamplingStrategyFetcher{
+		testSamplingStrategyFetcher: testSamplingStrategyFetcher{response: []byte("rateLimiting")},
+	}
+	sampler := New(
+		"test",
+		WithSamplingRefreshInterval(time.Minute),
+		WithSamplingStrategyFetcher(fetcher),
+		withSamplingStrategyParser(new(testSamplingStrategyParser)),
+		withClock(clock),
+	)
+	defer sampler.Close()
+
+	// The strategy is fetched and applied on startup, the ticker is then
+	// started.
+	require.Eventually(t, func() bool {
+		sampler.RLock()
+		defer sampler.RUnlock()
+		_, ok := sampler.sampler.(*rateLimitingSampler)
+		return ok
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int64(1), fetcher.count.Load())
+
+	// The rate limiting sampler of the strategy uses the clock of the sampler.
+	params := makeSamplingParameters(1, testOperationName)
+	for i := 0; i < 100; i++ {
+		require.Equal(t, trace.RecordAndSample, sampler.ShouldSample(params).Decision)
+	}
+	assert.Equal(t, trace.Drop, sampler.ShouldSample(params).Decision)
+	clock.Advance(10 * time.Millisecond)
+	assert.Equal(t, trace.RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, trace.Drop, sampler.ShouldSample(params).Decision)
+
+	// The strategy is polled when the clock is advanced past the refresh
+	// interval.
+	clock.Advance(30 * time.Second)
+	assert.Never(t, func() bool { return fetcher.count.Load() != 1 }, 50*time.Millisecond, time.Millisecond)
+	clock.Advance(30 * time.Second)
+	require.Eventually(t, func() bool { return fetcher.count.Load() == 2 }, time.Second, time.Millisecond)
+	clock.Advance(time.Minute)
+	require.Eventually(t, func() bool { return fetcher.count.Load() == 3 }, time.Second, time.Millisecond)
+}
+
 func TestRemotelyControlledSampler_updateSampler(t *testing.T) {
 	tests := []struct {
 		probabilities              map[string]float64
@@ -293,7 +427,7 @@ func TestRemotelyControlledSampler_updateSampler(t *testing.T) {
 }
 
 func TestRemotelyControlledSampler_ImmediatelyUpdateOnStartup(t *testing.T) {
-	initSampler := newProbabilisticSampler(0.123)
+	initSampler := newProbabilisticSampler(0.123, false)
 	fetcher := &testSamplingStrategyFetcher{response: []byte("rateLimiting")}
 	parser := new(testSamplingStrategyParser)
 	updaters := []samplerUpdater{new(probabilisticSamplerUpdater), new(rateLimitingSamplerUpdater)}
@@ -371,6 +505,29 @@ func TestSamplerQueryError(t *testing.T) {
 	assert.Equal(t, initSampler, sampler.sampler, "Sampler should not have been updated due to query error")
 }
 
+func TestRemotelyControlledSampler_updateSamplerLogs(t *testing.T) {
+	var logs []string
+	logger := funcr.New(func(prefix, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{Verbosity: 1})
+
+	sampler := New("test", WithLogger(logger), WithSamplingStrategyFetcher(&fakeSamplingFetcher{}))
+	sampler.Close() // stop timer-based updates, we want to call them manually
+	logs = nil
+
+	sampler.UpdateSampler()
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], `"msg"="failed to fetch sampling strategy"`)
+	assert.Contains(t, logs[0], `"error"="query error"`)
+
+	logs = nil
+	sampler.samplingFetcher = &testSamplingStrategyFetcher{response: []byte("probabilistic")}
+	sampler.samplingParser = new(testSamplingStrategyParser)
+	sampler.UpdateSampler()
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], `"msg"="sampling strategy updated"`)
+}
+
 type fakeSamplingFetcher struct{}
 
 func (c *fakeSamplingFetcher) Fetch(serviceName string) ([]byte, error) {
@@ -422,12 +579,12 @@ func TestRemotelyControlledSampler_updateSamplerFromAdaptiveSampler(t *testing.T
 }
 
 func TestRemotelyControlledSampler_updateRateLimitingOrProbabilisticSampler(t *testing.T) {
-	probabilisticSampler := newProbabilisticSampler(0.002)
-	otherProbabilisticSampler := newProbabilisticSampler(0.003)
-	maxProbabilisticSampler := newProbabilisticSampler(1.0)
+	probabilisticSampler := newProbabilisticSampler(0.002, false)
+	otherProbabilisticSampler := newProbabilisticSampler(0.003, false)
+	maxProbabilisticSampler := newProbabilisticSampler(1.0, false)
 
-	rateLimitingSampler := newRateLimitingSampler(2)
-	otherRateLimitingSampler := newRateLimitingSampler(3)
+	rateLimitingSampler := newRateLimitingSampler(2, false, nil)
+	otherRateLimitingSampler := newRateLimitingSampler(3, false, nil)
 
 	testCases := []struct {
 		res                  *jaeger_api_v2.SamplingStrategyResponse
@@ -595,3 +752,55 @@ func TestDefaultSamplingStrategyFetcher_Timeout(t *testing.T) {
 	fetcher := newHTTPSamplingStrategyFetcher("")
 	assert.Equal(t, defaultRemoteSamplingTimeout, fetcher.httpClient.Timeout)
 }
+
+func TestRemotelyControlledSampler_SetSamplingServerURL(t *testing.T) {
+	newServer := func(hits *atomic.Int64) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			_, _ = w.Write([]byte(`{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0.5}}`))
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	var oldHits, newHits atomic.Int64
+	oldSrv := newServer(&oldHits)
+	newSrv := newServer(&newHits)
+
+	sampler := New(
+		"test",
+		WithSamplingServerURL(oldSrv.URL),
+		WithSamplingRefreshInterval(10*time.Millisecond),
+	)
+	defer sampler.Close()
+
+	require.Eventually(t, func() bool { return oldHits.Load() > 0 }, time.Second, time.Millisecond, "old server not polled")
+
+	sampler.SetSamplingServerURL(newSrv.URL)
+	require.Eventually(t, func() bool { return newHits.Load() > 0 }, time.Second, time.Millisecond, "new server not polled")
+
+	// In-flight fetches to the old server are done once the new server is
+	// polled, no more fetch should hit it.
+	stale := oldHits.Load()
+	sampler.UpdateSampler()
+	assert.Equal(t, stale, oldHits.Load(), "old server polled after the URL changed")
+}
+
+func TestRemotelyControlledSampler_SetSamplingServerURLCustomFetcher(t *testing.T) {
+	fetcher := &testSamplingStrategyFetcher{response: []byte("probabilistic")}
+	var logged atomic.Bool
+	logger := funcr.New(func(prefix, args string) {
+		if strings.Contains(args, "cannot set the sampling server URL") {
+			logged.Store(true)
+		}
+	}, funcr.Options{})
+	sampler := New(
+		"test",
+		WithSamplingStrategyFetcher(fetcher),
+		WithSamplingRefreshInterval(time.Hour),
+		WithLogger(logger),
+	)
+	defer sampler.Close()
+
+	sampler.SetSamplingServerURL("http://localhost:5778/sampling")
+	assert.True(t, logged.Load(), "an error should be logged")
+}
@@ -21,10 +21,12 @@ package jaegerremote
 import (
 	"encoding/binary"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
+	"go.opentelemetry.io/contrib/samplers/jaegerremote/internal/testutils"
 	"go.opentelemetry.io/otel/sdk/trace"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -41,7 +43,7 @@ const (
 func TestProbabilisticSampler(t *testing.T) {
 	var traceID oteltrace.TraceID
 
-	sampler := newProbabilisticSampler(0.5)
+	sampler := newProbabilisticSampler(0.5, false)
 	binary.BigEndian.PutUint64(traceID[:], testMaxID+10)
 	result := sampler.ShouldSample(trace.SamplingParameters{TraceID: traceID})
 	assert.Equal(t, trace.Drop, result.Decision)
@@ -60,7 +62,7 @@ func TestProbabilisticSampler(t *testing.T) {
 }
 
 func TestRateLimitingSampler(t *testing.T) {
-	sampler := newRateLimitingSampler(2)
+	sampler := newRateLimitingSampler(2, false, nil)
 	result := sampler.ShouldSample(trace.SamplingParameters{Name: testOperationName})
 	assert.Equal(t, trace.RecordAndSample, result.Decision)
 	result = sampler.ShouldSample(trace.SamplingParameters{Name: testOperationName})
@@ -68,21 +70,93 @@ func TestRateLimitingSampler(t *testing.T) {
 	result = sampler.ShouldSample(trace.SamplingParameters{Name: testOperationName})
 	assert.Equal(t, trace.Drop, result.Decision)
 
-	sampler = newRateLimitingSampler(0.1)
+	sampler = newRateLimitingSampler(0.1, false, nil)
 	result = sampler.ShouldSample(trace.SamplingParameters{Name: testOperationName})
 	assert.Equal(t, trace.RecordAndSample, result.Decision)
 	result = sampler.ShouldSample(trace.SamplingParameters{Name: testOperationName})
 	assert.Equal(t, trace.Drop, result.Decision)
 
-	sampler = newRateLimitingSampler(0)
+	sampler = newRateLimitingSampler(0, false, nil)
 	result = sampler.ShouldSample(trace.SamplingParameters{Name: testOperationName})
 	assert.Equal(t, trace.Drop, result.Decision)
 }
 
+func TestRateLimitingSamplerClock(t *testing.T) {
+	clock := testutils.NewFakeClock(time.Unix(0, 0))
+	sampler := newRateLimitingSampler(2, false, clock)
+	params := trace.SamplingParameters{Name: testOperationName}
+	assert.Equal(t, trace.RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, trace.RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, trace.Drop, sampler.ShouldSample(params).Decision)
+
+	// The balance is only replenished when the clock is advanced.
+	clock.Advance(250 * time.Millisecond)
+	assert.Equal(t, trace.Drop, sampler.ShouldSample(params).Decision)
+	clock.Advance(250 * time.Millisecond)
+	assert.Equal(t, trace.RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, trace.Drop, sampler.ShouldSample(params).Decision)
+
+	// The balance is capped to the maximum number of traces per second.
+	clock.Advance(time.Hour)
+	assert.Equal(t, trace.RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, trace.RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, trace.Drop, sampler.ShouldSample(params).Decision)
+}
+
+func TestSamplerAttributes(t *testing.T) {
+	var traceID oteltrace.TraceID
+	binary.BigEndian.PutUint64(traceID[:], testMaxID-20)
+	sampled := trace.SamplingParameters{Name: testOperationName, TraceID: traceID}
+
+	t.Run("probabilistic", func(t *testing.T) {
+		result := newProbabilisticSampler(0.5, true).ShouldSample(sampled)
+		assert.Equal(t, trace.RecordAndSample, result.Decision)
+		assert.Equal(t, samplerAttributes(samplerTypeProbabilistic, 0.5), result.Attributes)
+
+		var dropID oteltrace.TraceID
+		binary.BigEndian.PutUint64(dropID[:], testMaxID+10)
+		result = newProbabilisticSampler(0.5, true).ShouldSample(trace.SamplingParameters{TraceID: dropID})
+		assert.Equal(t, trace.Drop, result.Decision)
+		assert.Empty(t, result.Attributes)
+	})
+
+	t.Run("ratelimiting", func(t *testing.T) {
+		result := newRateLimitingSampler(2, true, nil).ShouldSample(sampled)
+		assert.Equal(t, trace.RecordAndSample, result.Decision)
+		assert.Equal(t, samplerAttributes(samplerTypeRateLimiting, 2), result.Attributes)
+	})
+
+	t.Run("lowerbound", func(t *testing.T) {
+		// A sampling rate of 0 leaves the decision to the lower bound sampler.
+		result := newGuaranteedThroughputProbabilisticSampler(2, 0, true, nil).ShouldSample(sampled)
+		assert.Equal(t, trace.RecordAndSample, result.Decision)
+		assert.Equal(t, samplerAttributes(samplerTypeLowerBound, 0), result.Attributes)
+	})
+
+	t.Run("per operation", func(t *testing.T) {
+		sampler := newPerOperationSampler(perOperationSamplerParams{
+			AttributesOn: true,
+			Strategies: &jaeger_api_v2.PerOperationSamplingStrategies{
+				DefaultSamplingProbability:       testDefaultSamplingProbability,
+				DefaultLowerBoundTracesPerSecond: 1.0,
+			},
+		})
+		result := sampler.ShouldSample(sampled)
+		assert.Equal(t, trace.RecordAndSample, result.Decision)
+		assert.Equal(t, samplerAttributes(samplerTypeProbabilistic, testDefaultSamplingProbability), result.Attributes)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Empty(t, newProbabilisticSampler(0.5, false).ShouldSample(sampled).Attributes)
+		assert.Empty(t, newRateLimitingSampler(2, false, nil).ShouldSample(sampled).Attributes)
+		assert.Empty(t, newGuaranteedThroughputProbabilisticSampler(2, 0, false, nil).ShouldSample(sampled).Attributes)
+	})
+}
+
 func TestGuaranteedThroughputProbabilisticSamplerUpdate(t *testing.T) {
 	samplingRate := 0.5
 	lowerBound := 2.0
-	sampler := newGuaranteedThroughputProbabilisticSampler(lowerBound, samplingRate)
+	sampler := newGuaranteedThroughputProbabilisticSampler(lowerBound, samplingRate, false, nil)
 	assert.Equal(t, lowerBound, sampler.lowerBound)
 	assert.Equal(t, samplingRate, sampler.samplingRate)
 
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremote // import "go.opentelemetry.io/contrib/samplers/jaegerremote"
+
+import (
+	"errors"
+
+	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
+)
+
+// Strategy is a sampling strategy applied by the Sampler. It is returned by
+// the parser configured with WithStrategyParser.
+//
+// One of its fields must be set. If more than one is set, PerOperation takes
+// precedence over Probabilistic, which takes precedence over RateLimiting.
+type Strategy struct {
+	// Probabilistic samples a fraction of the traces.
+	Probabilistic *ProbabilisticStrategy
+	// RateLimiting samples up to a number of traces per second.
+	RateLimiting *RateLimitingStrategy
+	// PerOperation samples the traces with a sampling rate specific to
+	// their root span name.
+	PerOperation *PerOperationStrategy
+}
+
+// ProbabilisticStrategy samples a fraction of the traces.
+type ProbabilisticStrategy struct {
+	// SamplingRate is the fraction of traces sampled, in the range [0, 1].
+	SamplingRate float64
+}
+
+// RateLimitingStrategy samples up to a number of traces per second.
+type RateLimitingStrategy struct {
+	// MaxTracesPerSecond is the maximum number of traces sampled per second.
+	MaxTracesPerSecond int32
+}
+
+// PerOperationStrategy samples traces with a sampling rate specific to
+// their root span name, the operation.
+type PerOperationStrategy struct {
+	// DefaultSamplingProbability is the sampling rate of the operations
+	// without a strategy in Operations.
+	DefaultSamplingProbability float64
+	// DefaultLowerBoundTracesPerSecond is the minimum number of traces
+	// sampled per second for each operation.
+	DefaultLowerBoundTracesPerSecond float64
+	// Operations are the sampling strategies of specific operations.
+	Operations []OperationStrategy
+}
+
+// OperationStrategy is the sampling strategy of an operation.
+type OperationStrategy struct {
+	// Operation is the name of the root spans of the traces.
+	Operation string
+	// SamplingRate is the fraction of traces sampled, in the range [0, 1].
+	SamplingRate float64
+}
+
+var errEmptyStrategy = errors.New("sampling strategy must specify a strategy type")
+
+// strategyParserFunc is a samplingStrategyParser using a user provided
+// function to parse the sampling strategy.
+type strategyParserFunc func([]byte) (Strategy, error)
+
+// Parse parses response with f, and converts the returned Strategy to the
+// type recognized by the samplerUpdaters.
+func (f strategyParserFunc) Parse(response []byte) (interface{}, error) {
+	s, err := f(response)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case s.PerOperation != nil:
+		operations := make([]*jaeger_api_v2.OperationSamplingStrategy, 0, len(s.PerOperation.Operations))
+		for _, o := range s.PerOperation.Operations {
+			operations = append(operations, &jaeger_api_v2.OperationSamplingStrategy{
+				Operation: o.Operation,
+				ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{
+					SamplingRate: o.SamplingRate,
+				},
+			})
+		}
+		return &jaeger_api_v2.SamplingStrategyResponse{
+			OperationSampling: &jaeger_api_v2.PerOperationSamplingStrategies{
+				DefaultSamplingProbability:       s.PerOperation.DefaultSamplingProbability,
+				DefaultLowerBoundTracesPerSecond: s.PerOperation.DefaultLowerBoundTracesPerSecond,
+				PerOperationStrategies:           operations,
+			},
+		}, nil
+	case s.Probabilistic != nil:
+		return &jaeger_api_v2.SamplingStrategyResponse{
+			StrategyType: jaeger_api_v2.SamplingStrategyType_PROBABILISTIC,
+			ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{
+				SamplingRate: s.Probabilistic.SamplingRate,
+			},
+		}, nil
+	case s.RateLimiting != nil:
+		return &jaeger_api_v2.SamplingStrategyResponse{
+			StrategyType: jaeger_api_v2.SamplingStrategyType_RATE_LIMITING,
+			RateLimitingSampling: &jaeger_api_v2.RateLimitingSamplingStrategy{
+				MaxTracesPerSecond: s.RateLimiting.MaxTracesPerSecond,
+			},
+		}, nil
+	}
+	return nil, errEmptyStrategy
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremote
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	jaeger_api_v2 "go.opentelemetry.io/contrib/samplers/jaegerremote/internal/proto-gen/jaeger-idl/proto/api_v2"
+)
+
+// tenantStrategies is a sampling strategy format extending the one of Jaeger
+// with sampling rates specific to a tenant.
+type tenantStrategies struct {
+	DefaultRate float64            `json:"defaultRate"`
+	Tenants     map[string]float64 `json:"tenants"`
+}
+
+func tenantParser(tenant string) func([]byte) (Strategy, error) {
+	return func(b []byte) (Strategy, error) {
+		var s tenantStrategies
+		if err := json.Unmarshal(b, &s); err != nil {
+			return Strategy{}, err
+		}
+		rate, ok := s.Tenants[tenant]
+		if !ok {
+			rate = s.DefaultRate
+		}
+		return Strategy{Probabilistic: &ProbabilisticStrategy{SamplingRate: rate}}, nil
+	}
+}
+
+func TestWithStrategyParser(t *testing.T) {
+	fetcher := &testSamplingStrategyFetcher{
+		response: []byte(`{"defaultRate":0.1,"tenants":{"acme":0.42}}`),
+	}
+	sampler := New(
+		"test",
+		WithInitialSampler(newProbabilisticSampler(0.123, false)),
+		WithSamplingRefreshInterval(time.Hour),
+		WithSamplingStrategyFetcher(fetcher),
+		WithStrategyParser(tenantParser("acme")),
+	)
+	defer sampler.Close()
+
+	sampler.UpdateSampler()
+	sampler.RLock()
+	defer sampler.RUnlock()
+	s, ok := sampler.sampler.(*probabilisticSampler)
+	require.True(t, ok, "sampler: %T", sampler.sampler)
+	assert.Equal(t, 0.42, s.SamplingRate())
+}
+
+func TestWithStrategyParserError(t *testing.T) {
+	initSampler := newProbabilisticSampler(0.123, false)
+	sampler := New(
+		"test",
+		WithInitialSampler(initSampler),
+		WithSamplingRefreshInterval(time.Hour),
+		WithSamplingStrategyFetcher(&testSamplingStrategyFetcher{response: []byte("{}")}),
+		WithStrategyParser(func([]byte) (Strategy, error) {
+			return Strategy{}, errors.New("invalid strategy")
+		}),
+	)
+	defer sampler.Close()
+
+	sampler.UpdateSampler()
+	sampler.RLock()
+	defer sampler.RUnlock()
+	assert.Equal(t, initSampler, sampler.sampler)
+}
+
+func TestWithStrategyParserNil(t *testing.T) {
+	c := newConfig(WithStrategyParser(nil))
+	assert.IsType(t, new(samplingStrategyParserImpl), c.samplingParser)
+}
+
+func TestStrategyParserFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy Strategy
+		want     *jaeger_api_v2.SamplingStrategyResponse
+	}{
+		{
+			name:     "probabilistic",
+			strategy: Strategy{Probabilistic: &ProbabilisticStrategy{SamplingRate: 0.42}},
+			want: &jaeger_api_v2.SamplingStrategyResponse{
+				StrategyType:          jaeger_api_v2.SamplingStrategyType_PROBABILISTIC,
+				ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.42},
+			},
+		},
+		{
+			name:     "rate limiting",
+			strategy: Strategy{RateLimiting: &RateLimitingStrategy{MaxTracesPerSecond: 42}},
+			want: &jaeger_api_v2.SamplingStrategyResponse{
+				StrategyType:         jaeger_api_v2.SamplingStrategyType_RATE_LIMITING,
+				RateLimitingSampling: &jaeger_api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: 42},
+			},
+		},
+		{
+			name: "per operation",
+			strategy: Strategy{
+				Probabilistic: &ProbabilisticStrategy{SamplingRate: 0.1},
+				PerOperation: &PerOperationStrategy{
+					DefaultSamplingProbability:       0.5,
+					DefaultLowerBoundTracesPerSecond: 1,
+					Operations:                       []OperationStrategy{{Operation: "op", SamplingRate: 0.42}},
+				},
+			},
+			want: &jaeger_api_v2.SamplingStrategyResponse{
+				OperationSampling: &jaeger_api_v2.PerOperationSamplingStrategies{
+					DefaultSamplingProbability:       0.5,
+					DefaultLowerBoundTracesPerSecond: 1,
+					PerOperationStrategies: []*jaeger_api_v2.OperationSamplingStrategy{
+						{
+							Operation:             "op",
+							ProbabilisticSampling: &jaeger_api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.42},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser := strategyParserFunc(func([]byte) (Strategy, error) { return test.strategy, nil })
+			got, err := parser.Parse(nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	_, err := strategyParserFunc(func([]byte) (Strategy, error) { return Strategy{}, nil }).Parse(nil)
+	assert.ErrorIs(t, err, errEmptyStrategy)
+}
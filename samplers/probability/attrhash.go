@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability // import "go.opentelemetry.io/contrib/samplers/probability"
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type attributeHash struct {
+	key       attribute.Key
+	rate      float64
+	threshold uint64
+	fallback  sdktrace.Sampler
+}
+
+// NewAttributeHashSampler returns a Sampler that samples the spans with a
+// rate probability, based on a hash of the value of their key attribute.
+//
+// The decision only depends on the value of the attribute: all the spans
+// started with the same value, for example the same user ID, are either all
+// sampled or all dropped, across traces. The attribute needs to be passed
+// when starting the span, with trace.WithAttributes, to be seen by the
+// Sampler. The spans without the key attribute are sampled with
+// TraceIDRatioBased(rate).
+//
+// The decision does not depend on the parent span. Wrap the Sampler with
+// ParentBased to sample the descendants of a span consistently with it.
+//
+// A rate of 1 or more samples all spans, a rate of 0 or less samples none.
+func NewAttributeHashSampler(key attribute.Key, rate float64) sdktrace.Sampler {
+	s := &attributeHash{
+		key:      key,
+		rate:     rate,
+		fallback: sdktrace.TraceIDRatioBased(rate),
+	}
+	switch {
+	case rate >= 1:
+		s.threshold = math.MaxUint64
+	case rate > 0:
+		s.threshold = uint64(rate * math.MaxUint64)
+	}
+	return s
+}
+
+// ShouldSample returns the sampling decision based on the hash of the value
+// of the key attribute of the span, or of its trace ID if the span does not
+// have this attribute.
+func (s *attributeHash) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	value, ok := s.lookup(p.Attributes)
+	if !ok {
+		return s.fallback.ShouldSample(p)
+	}
+
+	decision := sdktrace.Drop
+	if s.rate >= 1 || (s.threshold > 0 && hashValue(value) < s.threshold) {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+// Description returns a description of the Sampler.
+func (s *attributeHash) Description() string {
+	return fmt.Sprintf("AttributeHashSampler{%s,%g}", s.key, s.rate)
+}
+
+func (s *attributeHash) lookup(attrs []attribute.KeyValue) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if kv.Key == s.key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+// hashValue returns the FNV-1a hash of the string representation of v.
+func hashValue(v attribute.Value) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(v.Emit()))
+	return h.Sum64()
+}
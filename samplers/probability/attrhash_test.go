@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const userKey = attribute.Key("user.id")
+
+func TestAttributeHashSamplerConsistent(t *testing.T) {
+	sampler := NewAttributeHashSampler(userKey, 0.5)
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+
+	var sampled int
+	const users = 1000
+	for u := 0; u < users; u++ {
+		attrs := []attribute.KeyValue{userKey.String(fmt.Sprintf("user-%d", u))}
+		first := sampler.ShouldSample(sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       randomTraceID(rng),
+			Name:          "span",
+			Attributes:    attrs,
+		}).Decision
+		if first == sdktrace.RecordAndSample {
+			sampled++
+		}
+
+		for i := 0; i < 10; i++ {
+			got := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				TraceID:       randomTraceID(rng),
+				Name:          "span",
+				Attributes:    attrs,
+			}).Decision
+			assert.Equalf(t, first, got, "inconsistent decision for user-%d", u)
+		}
+	}
+	assert.InDelta(t, 0.5, float64(sampled)/users, 0.05, "sampling rate")
+}
+
+func TestAttributeHashSamplerFallback(t *testing.T) {
+	sampler := NewAttributeHashSampler(userKey, 0.25)
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+
+	const n = 10000
+	var sampled int
+	for i := 0; i < n; i++ {
+		tid := randomTraceID(rng)
+		params := sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       tid,
+			Name:          "span",
+			Attributes:    []attribute.KeyValue{attribute.String("other", "value")},
+		}
+		got := sampler.ShouldSample(params).Decision
+		want := sdktrace.TraceIDRatioBased(0.25).ShouldSample(params).Decision
+		assert.Equal(t, want, got, "trace ID based decision")
+		if got == sdktrace.RecordAndSample {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 0.25, float64(sampled)/n, 0.02, "sampling rate")
+}
+
+func TestAttributeHashSamplerBounds(t *testing.T) {
+	params := sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		Name:          "span",
+		Attributes:    []attribute.KeyValue{userKey.Int(42)},
+	}
+	assert.Equal(t, sdktrace.RecordAndSample, NewAttributeHashSampler(userKey, 1).ShouldSample(params).Decision)
+	assert.Equal(t, sdktrace.Drop, NewAttributeHashSampler(userKey, 0).ShouldSample(params).Decision)
+	assert.Equal(t, sdktrace.Drop, NewAttributeHashSampler(userKey, -1).ShouldSample(params).Decision)
+}
+
+func TestAttributeHashSamplerDescription(t *testing.T) {
+	assert.Equal(t, "AttributeHashSampler{user.id,0.5}", NewAttributeHashSampler(userKey, 0.5).Description())
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package probability provides samplers that can be composed with the
+// samplers of the OpenTelemetry Go SDK.
+//
+// For consistent probability sampling, see the
+// [go.opentelemetry.io/contrib/samplers/probability/consistent] package.
+package probability // import "go.opentelemetry.io/contrib/samplers/probability"
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability // import "go.opentelemetry.io/contrib/samplers/probability"
+
+import (
+	"context"
+	"fmt"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// forceSampleTraceStateKey is the key of the tracestate entry recording that
+// a trace is force-sampled. It propagates the decision to the descendant
+// spans, and to the downstream services using a ForceSampleable Sampler with
+// WithRemoteForceSample.
+const forceSampleTraceStateKey = "forcesample@otelcontrib"
+
+type forceSampleKey struct{}
+
+// WithForceSample returns a copy of ctx requesting the spans started with it
+// to be sampled by the Samplers returned by NewForceSampleable.
+func WithForceSample(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceSampleKey{}, true)
+}
+
+// forceSample returns true if the spans started with ctx must be sampled,
+// either because of WithForceSample or because the parent span is
+// force-sampled. The tracestate of a remote parent span is only honored if
+// remote is true.
+func forceSample(ctx context.Context, remote bool) bool {
+	if forced, _ := ctx.Value(forceSampleKey{}).(bool); forced {
+		return true
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.IsRemote() && !remote {
+		return false
+	}
+	return sc.TraceState().Get(forceSampleTraceStateKey) == "1"
+}
+
+type (
+	// ForceSampleableOption is an option to the ForceSampleable Sampler.
+	ForceSampleableOption interface {
+		apply(*forceSampleableConfig)
+	}
+
+	forceSampleableConfig struct {
+		remote bool
+	}
+
+	forceSampleableRemote bool
+)
+
+// WithRemoteForceSample makes the Sampler honor the force-sampling decision
+// recorded in the tracestate of remote parent spans, so that the traces
+// force-sampled by an upstream service are sampled as well.
+//
+// The tracestate is set by the callers of the service: only use this option
+// if they are trusted, as any of them can otherwise force all its traces to
+// be sampled.
+func WithRemoteForceSample() ForceSampleableOption {
+	return forceSampleableRemote(true)
+}
+
+func (r forceSampleableRemote) apply(cfg *forceSampleableConfig) {
+	cfg.remote = bool(r)
+}
+
+type forceSampleable struct {
+	base   sdktrace.Sampler
+	remote bool
+}
+
+// NewForceSampleable returns a Sampler that samples the spans started with a
+// context returned by WithForceSample, regardless of the decision of base.
+// The sampling decision of the other spans is delegated to base.
+//
+// The decision is recorded in the tracestate of the force-sampled spans, so
+// that their descendants are sampled as well. The tracestate of remote parent
+// spans is ignored unless WithRemoteForceSample is used, so that untrusted
+// callers cannot force the traces of the service to be sampled. This is
+// useful to trace specific requests, for example during load tests or
+// debugging.
+//
+// If base is nil, the default Sampler of the SDK,
+// ParentBased(AlwaysSample), is used.
+func NewForceSampleable(base sdktrace.Sampler, opts ...ForceSampleableOption) sdktrace.Sampler {
+	if base == nil {
+		base = sdktrace.ParentBased(sdktrace.AlwaysSample())
+	}
+	var cfg forceSampleableConfig
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	return &forceSampleable{base: base, remote: cfg.remote}
+}
+
+// ShouldSample returns RecordAndSample if the span is force-sampled, and the
+// decision of the base Sampler otherwise.
+func (s *forceSampleable) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if !forceSample(p.ParentContext, s.remote) {
+		return s.base.ShouldSample(p)
+	}
+
+	ts := trace.SpanContextFromContext(p.ParentContext).TraceState()
+	if forced, err := ts.Insert(forceSampleTraceStateKey, "1"); err == nil {
+		ts = forced
+	}
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.RecordAndSample,
+		Tracestate: ts,
+	}
+}
+
+// Description returns a description of the Sampler and its base Sampler.
+func (s *forceSampleable) Description() string {
+	return fmt.Sprintf("ForceSampleable{%s}", s.base.Description())
+}
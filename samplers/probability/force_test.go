@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestForceSampleable(t *testing.T) {
+	sampler := NewForceSampleable(sdktrace.NeverSample())
+	params := sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       trace.TraceID{0x01},
+		Name:          "span",
+	}
+
+	res := sampler.ShouldSample(params)
+	assert.Equal(t, sdktrace.Drop, res.Decision, "should delegate to base")
+	assert.Equal(t, "", res.Tracestate.Get(forceSampleTraceStateKey))
+
+	params.ParentContext = WithForceSample(context.Background())
+	res = sampler.ShouldSample(params)
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision, "should be force-sampled")
+	assert.Equal(t, "1", res.Tracestate.Get(forceSampleTraceStateKey))
+}
+
+func TestForceSampleableFromTraceState(t *testing.T) {
+	ts, err := trace.ParseTraceState("vendor=value,forcesample@otelcontrib=1")
+	require.NoError(t, err)
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceState: ts,
+		Remote:     true,
+	})
+	params := sdktrace.SamplingParameters{
+		ParentContext: trace.ContextWithRemoteSpanContext(context.Background(), parent),
+		TraceID:       parent.TraceID(),
+		Name:          "span",
+	}
+
+	res := NewForceSampleable(sdktrace.NeverSample()).ShouldSample(params)
+	assert.Equal(t, sdktrace.Drop, res.Decision, "should not trust the remote tracestate by default")
+
+	res = NewForceSampleable(sdktrace.NeverSample(), WithRemoteForceSample()).ShouldSample(params)
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision, "should honor the parent tracestate")
+	assert.Equal(t, "1", res.Tracestate.Get(forceSampleTraceStateKey))
+	assert.Equal(t, "value", res.Tracestate.Get("vendor"), "should keep the parent tracestate")
+
+	// The tracestate of a local parent is always honored.
+	local := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceState: ts,
+	})
+	params.ParentContext = trace.ContextWithSpanContext(context.Background(), local)
+	res = NewForceSampleable(sdktrace.NeverSample()).ShouldSample(params)
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision, "should honor the local parent tracestate")
+}
+
+func TestForceSampleablePropagation(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(NewForceSampleable(sdktrace.NeverSample())),
+		sdktrace.WithSpanProcessor(sr),
+	)
+	tracer := tp.Tracer("test")
+
+	ctx, parent := tracer.Start(WithForceSample(context.Background()), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	_, other := tracer.Start(context.Background(), "other")
+	other.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2, "only the force-sampled spans should be sampled")
+	for _, s := range spans {
+		assert.Equal(t, "1", s.SpanContext().TraceState().Get(forceSampleTraceStateKey))
+	}
+}
+
+func TestForceSampleableDescription(t *testing.T) {
+	assert.Equal(t, "ForceSampleable{AlwaysOffSampler}", NewForceSampleable(sdktrace.NeverSample()).Description())
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability // import "go.opentelemetry.io/contrib/samplers/probability"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type bySpanKind struct {
+	kinds       []trace.SpanKind
+	samplers    map[trace.SpanKind]sdktrace.Sampler
+	fallback    sdktrace.Sampler
+	description string
+}
+
+// NewBySpanKind returns a Sampler that delegates the sampling decision of a
+// span to the Sampler in samplers registered for the kind of the span. The
+// fallback Sampler is used for spans of a kind not in samplers.
+//
+// For example, the following samples all server spans and 1% of internal
+// spans:
+//
+//	NewBySpanKind(map[trace.SpanKind]sdktrace.Sampler{
+//		trace.SpanKindServer:   sdktrace.AlwaysSample(),
+//		trace.SpanKindInternal: sdktrace.TraceIDRatioBased(0.01),
+//	}, sdktrace.AlwaysSample())
+//
+// If fallback is nil, the default Sampler of the SDK,
+// ParentBased(AlwaysSample), is used.
+func NewBySpanKind(samplers map[trace.SpanKind]sdktrace.Sampler, fallback sdktrace.Sampler) sdktrace.Sampler {
+	if fallback == nil {
+		fallback = sdktrace.ParentBased(sdktrace.AlwaysSample())
+	}
+
+	s := &bySpanKind{
+		samplers: make(map[trace.SpanKind]sdktrace.Sampler, len(samplers)),
+		fallback: fallback,
+	}
+	for kind, sampler := range samplers {
+		if sampler == nil {
+			continue
+		}
+		s.kinds = append(s.kinds, kind)
+		s.samplers[kind] = sampler
+	}
+	sort.Slice(s.kinds, func(i, j int) bool { return s.kinds[i] < s.kinds[j] })
+
+	var b strings.Builder
+	b.WriteString("BySpanKind{")
+	for _, kind := range s.kinds {
+		fmt.Fprintf(&b, "%s:%s,", kind, s.samplers[kind].Description())
+	}
+	fmt.Fprintf(&b, "fallback:%s}", fallback.Description())
+	s.description = b.String()
+
+	return s
+}
+
+// ShouldSample returns the sampling decision of the Sampler registered for
+// the kind of the span being sampled.
+func (s *bySpanKind) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if sampler, ok := s.samplers[p.Kind]; ok {
+		return sampler.ShouldSample(p)
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+// Description returns a description of the Sampler and the Samplers it
+// delegates to.
+func (s *bySpanKind) Description() string {
+	return s.description
+}
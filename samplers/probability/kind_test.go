@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func randomTraceID(rng *rand.Rand) trace.TraceID {
+	var tid trace.TraceID
+	_, _ = rng.Read(tid[:])
+	return tid
+}
+
+func TestBySpanKind(t *testing.T) {
+	sampler := NewBySpanKind(map[trace.SpanKind]sdktrace.Sampler{
+		trace.SpanKindServer:   sdktrace.AlwaysSample(),
+		trace.SpanKindInternal: sdktrace.TraceIDRatioBased(0.01),
+	}, sdktrace.NeverSample())
+
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+	const n = 100000
+	var server, internal, client int
+	for i := 0; i < n; i++ {
+		params := sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       randomTraceID(rng),
+			Name:          "span",
+		}
+
+		params.Kind = trace.SpanKindServer
+		if sampler.ShouldSample(params).Decision == sdktrace.RecordAndSample {
+			server++
+		}
+		params.Kind = trace.SpanKindInternal
+		if sampler.ShouldSample(params).Decision == sdktrace.RecordAndSample {
+			internal++
+		}
+		params.Kind = trace.SpanKindClient
+		if sampler.ShouldSample(params).Decision == sdktrace.RecordAndSample {
+			client++
+		}
+	}
+
+	assert.Equal(t, n, server, "server spans should always be sampled")
+	assert.InDelta(t, 0.01, float64(internal)/n, 0.002, "internal spans sampling rate")
+	assert.Equal(t, 0, client, "client spans should use the fallback sampler")
+}
+
+func TestBySpanKindDescription(t *testing.T) {
+	sampler := NewBySpanKind(map[trace.SpanKind]sdktrace.Sampler{
+		trace.SpanKindServer:   sdktrace.AlwaysSample(),
+		trace.SpanKindInternal: sdktrace.TraceIDRatioBased(0.01),
+	}, sdktrace.NeverSample())
+
+	want := "BySpanKind{internal:TraceIDRatioBased{0.01},server:AlwaysOnSampler,fallback:AlwaysOffSampler}"
+	assert.Equal(t, want, sampler.Description())
+}
+
+func TestBySpanKindDefaultFallback(t *testing.T) {
+	sampler := NewBySpanKind(nil, nil)
+	assert.Equal(t, "BySpanKind{fallback:ParentBased{root:AlwaysOnSampler,remoteParentSampled:AlwaysOnSampler,remoteParentNotSampled:AlwaysOffSampler,localParentSampled:AlwaysOnSampler,localParentNotSampled:AlwaysOffSampler}}", sampler.Description())
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability // import "go.opentelemetry.io/contrib/samplers/probability"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Hashing is the hashing of the trace ID used by the RatioBased Sampler.
+type Hashing int
+
+const (
+	// HashTraceID uses the bits of the last 8 bytes of the trace ID, as
+	// sdktrace.TraceIDRatioBased does. This is the default.
+	HashTraceID Hashing = iota
+	// HashFNV uses the FNV-1a hash of the 16 bytes of the trace ID. This
+	// spreads the trace IDs that are not uniformly random, and matches the
+	// samplers of other services using this hashing.
+	HashFNV
+)
+
+// String returns the name of h.
+func (h Hashing) String() string {
+	switch h {
+	case HashTraceID:
+		return "traceid"
+	case HashFNV:
+		return "fnv"
+	}
+	return fmt.Sprintf("Hashing(%d)", int(h))
+}
+
+// maxPrecision is the maximum, and default, number of bits compared by the
+// RatioBased Sampler. It is the precision of sdktrace.TraceIDRatioBased.
+const maxPrecision = 63
+
+type (
+	// RatioBasedOption is an option to the RatioBased Sampler.
+	RatioBasedOption interface {
+		apply(*ratioBasedConfig)
+	}
+
+	ratioBasedConfig struct {
+		hashing   Hashing
+		precision int
+	}
+
+	ratioBasedHashing Hashing
+
+	ratioBasedPrecision int
+)
+
+// WithHashing sets the hashing of the trace ID used by the Sampler. The
+// default is HashTraceID.
+func WithHashing(h Hashing) RatioBasedOption {
+	return ratioBasedHashing(h)
+}
+
+func (h ratioBasedHashing) apply(cfg *ratioBasedConfig) {
+	cfg.hashing = Hashing(h)
+}
+
+// WithPrecision sets the number of bits of the hash compared to the ratio by
+// the Sampler, between 1 and 63. The ratio is rounded down to a multiple of
+// 2^-bits. Values out of range are clamped. The default is 63.
+func WithPrecision(bits int) RatioBasedOption {
+	return ratioBasedPrecision(bits)
+}
+
+func (p ratioBasedPrecision) apply(cfg *ratioBasedConfig) {
+	cfg.precision = int(p)
+}
+
+type ratioBased struct {
+	ratio     float64
+	hashing   Hashing
+	precision int
+	threshold uint64
+}
+
+// NewRatioBased returns a Sampler that samples a ratio of the traces based
+// on a hash of their trace ID. The decision only depends on the trace ID:
+// it is the same for all the spans of a trace, and for all the services
+// using the same ratio, hashing and precision.
+//
+// The decision does not depend on the parent span. Wrap the Sampler with
+// ParentBased to sample the descendants of a span consistently with it.
+//
+// A ratio of 1 or more samples all traces, a ratio of 0 or less samples
+// none.
+func NewRatioBased(ratio float64, opts ...RatioBasedOption) sdktrace.Sampler {
+	cfg := ratioBasedConfig{
+		hashing:   HashTraceID,
+		precision: maxPrecision,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if cfg.precision < 1 {
+		cfg.precision = 1
+	} else if cfg.precision > maxPrecision {
+		cfg.precision = maxPrecision
+	}
+
+	s := &ratioBased{
+		ratio:     ratio,
+		hashing:   cfg.hashing,
+		precision: cfg.precision,
+	}
+	switch {
+	case ratio >= 1:
+		s.threshold = 1 << cfg.precision
+	case ratio > 0:
+		s.threshold = uint64(ratio * float64(uint64(1)<<cfg.precision))
+	}
+	return s
+}
+
+// ShouldSample returns the sampling decision based on the hash of the trace
+// ID of the span.
+func (s *ratioBased) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	decision := sdktrace.Drop
+	if s.hash(p.TraceID) < s.threshold {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+// hash returns the precision bits of the hash of id.
+func (s *ratioBased) hash(id trace.TraceID) uint64 {
+	var h uint64
+	switch s.hashing {
+	case HashFNV:
+		f := fnv.New64a()
+		_, _ = f.Write(id[:])
+		h = f.Sum64()
+	default:
+		h = binary.BigEndian.Uint64(id[8:16])
+	}
+	return h >> (64 - s.precision)
+}
+
+// Description returns a description of the Sampler.
+func (s *ratioBased) Description() string {
+	return fmt.Sprintf("RatioBased{%g,%s,%d}", s.ratio, s.hashing, s.precision)
+}
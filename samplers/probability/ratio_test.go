@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestRatioBasedDescription(t *testing.T) {
+	assert.Equal(t, "RatioBased{0.25,traceid,63}", NewRatioBased(0.25).Description())
+	assert.Equal(t, "RatioBased{0.5,fnv,14}", NewRatioBased(0.5, WithHashing(HashFNV), WithPrecision(14)).Description())
+	assert.Equal(t, "RatioBased{0.5,traceid,1}", NewRatioBased(0.5, WithPrecision(0)).Description())
+	assert.Equal(t, "RatioBased{0.5,traceid,63}", NewRatioBased(0.5, WithPrecision(64)).Description())
+}
+
+func TestRatioBasedMatchesTraceIDRatioBased(t *testing.T) {
+	const ratio = 0.3
+	sampler := NewRatioBased(ratio)
+	std := sdktrace.TraceIDRatioBased(ratio)
+
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+	for i := 0; i < 10000; i++ {
+		params := sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       randomTraceID(rng),
+			Name:          "span",
+		}
+		assert.Equal(t, std.ShouldSample(params).Decision, sampler.ShouldSample(params).Decision)
+	}
+}
+
+func TestRatioBasedStable(t *testing.T) {
+	for _, opts := range [][]RatioBasedOption{
+		nil,
+		{WithHashing(HashFNV)},
+		{WithHashing(HashFNV), WithPrecision(14)},
+	} {
+		// Independent samplers, e.g. in different services, decide the same.
+		a, b := NewRatioBased(0.5, opts...), NewRatioBased(0.5, opts...)
+
+		rng := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+		for i := 0; i < 1000; i++ {
+			params := sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				TraceID:       randomTraceID(rng),
+				Name:          "span",
+			}
+			want := a.ShouldSample(params).Decision
+			assert.Equal(t, want, a.ShouldSample(params).Decision, a.Description())
+			assert.Equal(t, want, b.ShouldSample(params).Decision, a.Description())
+		}
+	}
+}
+
+func TestRatioBasedRate(t *testing.T) {
+	for _, tc := range []struct {
+		ratio float64
+		opts  []RatioBasedOption
+		want  float64
+	}{
+		{ratio: 0, want: 0},
+		{ratio: 1, want: 1},
+		{ratio: 0.25, want: 0.25},
+		{ratio: 0.25, opts: []RatioBasedOption{WithHashing(HashFNV)}, want: 0.25},
+		{ratio: 0.1, opts: []RatioBasedOption{WithHashing(HashFNV), WithPrecision(10)}, want: 0.1},
+		// The ratio is rounded down to a multiple of 2^-2.
+		{ratio: 0.3, opts: []RatioBasedOption{WithPrecision(2)}, want: 0.25},
+		{ratio: 1, opts: []RatioBasedOption{WithPrecision(1)}, want: 1},
+	} {
+		sampler := NewRatioBased(tc.ratio, tc.opts...)
+		t.Run(sampler.Description(), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+			const n = 100000
+			var sampled int
+			for i := 0; i < n; i++ {
+				params := sdktrace.SamplingParameters{
+					ParentContext: context.Background(),
+					TraceID:       randomTraceID(rng),
+					Name:          "span",
+				}
+				if sampler.ShouldSample(params).Decision == sdktrace.RecordAndSample {
+					sampled++
+				}
+			}
+			assert.InDelta(t, tc.want, float64(sampled)/n, 0.01)
+		})
+	}
+}
+
+func TestRatioBasedFNVSequentialTraceIDs(t *testing.T) {
+	// Trace IDs only differing in their first bytes are all sampled, or all
+	// dropped, with the default hashing. FNV spreads them.
+	sampler := NewRatioBased(0.5, WithHashing(HashFNV))
+	const n = 10000
+	var sampled int
+	for i := 0; i < n; i++ {
+		params := sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			Name:          "span",
+		}
+		params.TraceID[0], params.TraceID[1] = byte(i>>8), byte(i)
+		if sampler.ShouldSample(params).Decision == sdktrace.RecordAndSample {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 0.5, float64(sampled)/n, 0.05)
+}
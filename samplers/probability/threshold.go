@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability // import "go.opentelemetry.io/contrib/samplers/probability"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// otTraceStateKey is the key of the OpenTelemetry tracestate entry.
+	otTraceStateKey = "ot"
+	// thresholdSubkey is the subkey of the rejection threshold of the
+	// sampling decision in the OpenTelemetry tracestate entry.
+	thresholdSubkey = "th"
+	// randomValueSubkey is the subkey of the explicit randomness of the
+	// trace in the OpenTelemetry tracestate entry.
+	randomValueSubkey = "rv"
+
+	// randomBits is the number of bits of the randomness and thresholds.
+	randomBits = 56
+	// maxThreshold is the threshold rejecting all the traces, it is not
+	// encoded in the tracestate.
+	maxThreshold = uint64(1) << randomBits
+	// randomValueDigits is the number of hexadecimal digits of rv values.
+	randomValueDigits = randomBits / 4
+)
+
+type traceStateRatioBased struct {
+	ratio     float64
+	threshold uint64
+}
+
+// NewTraceStateRatioBased returns a Sampler that samples a ratio of the
+// traces consistently with the samplers of the OpenTelemetry SDKs using the
+// W3C tracestate randomness and threshold, e.g. the SDKs of other languages.
+//
+// The decision compares the 56-bit randomness of the trace with the
+// rejection threshold derived from ratio: the trace is sampled if its
+// randomness is greater than or equal to the threshold. The randomness is
+// read from the rv subkey of the "ot" tracestate entry of the parent span,
+// if it is present and valid, and from the last 7 bytes of the trace ID
+// otherwise. All the spans of a trace are given the same decision by all
+// the samplers using the same ratio.
+//
+// The threshold is recorded with the th subkey of the "ot" tracestate entry
+// of the sampled spans, replacing the one of the parent span, so that the
+// adjusted count of the spans can be computed downstream. It is removed from
+// the tracestate of the dropped spans. The other subkeys, including rv, are
+// kept.
+//
+// The decision does not depend on the sampling decision of the parent span.
+// Wrap the Sampler with ParentBased to sample the descendants of a span
+// consistently with it: their tracestate keeps the threshold of their root.
+//
+// A ratio of 1 or more samples all traces, a ratio of 0 or less samples
+// none.
+func NewTraceStateRatioBased(ratio float64) sdktrace.Sampler {
+	s := &traceStateRatioBased{ratio: ratio, threshold: maxThreshold}
+	switch {
+	case ratio >= 1:
+		s.threshold = 0
+	case ratio > 0:
+		// Computed from the rounded sampling probability, as 1-ratio loses
+		// the precision of ratio.
+		s.threshold = maxThreshold - uint64(math.Round(ratio*float64(maxThreshold)))
+	}
+	return s
+}
+
+// ShouldSample returns the sampling decision based on the randomness of the
+// trace and records it in the tracestate.
+func (s *traceStateRatioBased) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	state := trace.SpanContextFromContext(p.ParentContext).TraceState()
+	ot := parseOTTraceState(state.Get(otTraceStateKey))
+
+	randomness, ok := ot.randomValue()
+	if !ok {
+		randomness = binary.BigEndian.Uint64(p.TraceID[8:16]) & (maxThreshold - 1)
+	}
+
+	decision := sdktrace.Drop
+	ot.threshold = ""
+	if s.threshold < maxThreshold && randomness >= s.threshold {
+		decision = sdktrace.RecordAndSample
+		ot.threshold = encodeThreshold(s.threshold)
+	}
+
+	if v := ot.String(); v != "" {
+		// Note: see the note in
+		// "go.opentelemetry.io/otel/trace".TraceState.Insert(). The
+		// error below is not a condition we're supposed to handle.
+		state, _ = state.Insert(otTraceStateKey, v)
+	} else {
+		state = state.Delete(otTraceStateKey)
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: state,
+	}
+}
+
+// Description returns a description of the Sampler.
+func (s *traceStateRatioBased) Description() string {
+	return fmt.Sprintf("TraceStateRatioBased{%g}", s.ratio)
+}
+
+// encodeThreshold returns the th value of threshold: its 14 hexadecimal
+// digits without the trailing zeros, "0" for the threshold sampling all the
+// traces.
+func encodeThreshold(threshold uint64) string {
+	th := strings.TrimRight(fmt.Sprintf("%0*x", randomValueDigits, threshold), "0")
+	if th == "" {
+		return "0"
+	}
+	return th
+}
+
+// otTraceState is the value of the OpenTelemetry tracestate entry.
+type otTraceState struct {
+	threshold string
+	random    string
+	// others are the other subkeys and their values, in order.
+	others []string
+}
+
+// parseOTTraceState parses the value of the OpenTelemetry tracestate entry,
+// a list of subkey:value pairs separated by semicolons.
+func parseOTTraceState(v string) otTraceState {
+	var ot otTraceState
+	if v == "" {
+		return ot
+	}
+	for _, field := range strings.Split(v, ";") {
+		key, value, _ := strings.Cut(field, ":")
+		switch key {
+		case thresholdSubkey:
+			ot.threshold = value
+		case randomValueSubkey:
+			ot.random = value
+		default:
+			ot.others = append(ot.others, field)
+		}
+	}
+	return ot
+}
+
+// randomValue returns the explicit randomness of the trace, false if it is
+// absent or invalid. It must be exactly 14 hexadecimal digits.
+func (ot otTraceState) randomValue() (uint64, bool) {
+	if ot.random == "" {
+		return 0, false
+	}
+	if len(ot.random) != randomValueDigits {
+		otel.Handle(fmt.Errorf("invalid tracestate random value %q: must be %d hexadecimal digits", ot.random, randomValueDigits))
+		return 0, false
+	}
+	r, err := strconv.ParseUint(ot.random, 16, 64)
+	if err != nil {
+		otel.Handle(fmt.Errorf("invalid tracestate random value %q: %w", ot.random, err))
+		return 0, false
+	}
+	return r, true
+}
+
+// String returns the value of the OpenTelemetry tracestate entry.
+func (ot otTraceState) String() string {
+	fields := make([]string, 0, 2+len(ot.others))
+	if ot.threshold != "" {
+		fields = append(fields, thresholdSubkey+":"+ot.threshold)
+	}
+	if ot.random != "" {
+		fields = append(fields, randomValueSubkey+":"+ot.random)
+	}
+	fields = append(fields, ot.others...)
+	return strings.Join(fields, ";")
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// parentWithTraceState returns a context holding a remote span context with
+// the tracestate ts.
+func parentWithTraceState(t *testing.T, ts string) context.Context {
+	t.Helper()
+	state, err := trace.ParseTraceState(ts)
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+		TraceState: state,
+		Remote:     true,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestTraceStateRatioBasedDescription(t *testing.T) {
+	assert.Equal(t, "TraceStateRatioBased{0.25}", NewTraceStateRatioBased(0.25).Description())
+}
+
+func TestTraceStateRatioBasedThreshold(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  string
+	}{
+		{ratio: 1, want: "0"},
+		{ratio: 2, want: "0"},
+		{ratio: 0.5, want: "8"},
+		{ratio: 0.25, want: "c"},
+		{ratio: 0.1, want: "e6666666666666"},
+		// Rounded to the 53-bit precision of float64.
+		{ratio: 1.0 / 3, want: "aaaaaaaaaaaaac"},
+	}
+	for _, tt := range tests {
+		s := NewTraceStateRatioBased(tt.ratio).(*traceStateRatioBased)
+		assert.Equal(t, tt.want, encodeThreshold(s.threshold), "ratio %g", tt.ratio)
+	}
+
+	// Ratios less than 2^-57 are rounded to 0.
+	for _, ratio := range []float64{0, -1, 1e-18} {
+		s := NewTraceStateRatioBased(ratio).(*traceStateRatioBased)
+		assert.Equal(t, maxThreshold, s.threshold, "ratio %g", ratio)
+	}
+}
+
+func TestTraceStateRatioBasedTraceID(t *testing.T) {
+	sampler := NewTraceStateRatioBased(0.5)
+	params := sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		Name:          "span",
+	}
+
+	// The randomness is read from the last 7 bytes of the trace ID.
+	params.TraceID = trace.TraceID{8: 0xff, 9: 0x7f, 10: 0xff, 11: 0xff, 12: 0xff, 13: 0xff, 14: 0xff, 15: 0xff}
+	res := sampler.ShouldSample(params)
+	assert.Equal(t, sdktrace.Drop, res.Decision)
+	assert.Equal(t, "", res.Tracestate.Get(otTraceStateKey))
+
+	params.TraceID = trace.TraceID{8: 0x00, 9: 0x80}
+	res = sampler.ShouldSample(params)
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision)
+	assert.Equal(t, "th:8", res.Tracestate.Get(otTraceStateKey))
+}
+
+func TestTraceStateRatioBasedRandomValue(t *testing.T) {
+	sampler := NewTraceStateRatioBased(0.5)
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+
+	tests := []struct {
+		name      string
+		state     string
+		want      sdktrace.SamplingDecision
+		wantState string
+	}{
+		{
+			name:      "SampledRandomValue",
+			state:     "ot=rv:80000000000000",
+			want:      sdktrace.RecordAndSample,
+			wantState: "th:8;rv:80000000000000",
+		},
+		{
+			name:      "DroppedRandomValue",
+			state:     "ot=rv:7fffffffffffff",
+			want:      sdktrace.Drop,
+			wantState: "rv:7fffffffffffff",
+		},
+		{
+			name:      "ParentThresholdReplaced",
+			state:     "ot=th:c;rv:f0000000000000;x:1,vendor=value",
+			want:      sdktrace.RecordAndSample,
+			wantState: "th:8;rv:f0000000000000;x:1",
+		},
+		{
+			name:      "ParentThresholdRemoved",
+			state:     "ot=th:0;rv:00000000000001",
+			want:      sdktrace.Drop,
+			wantState: "rv:00000000000001",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The decision is the same for all the trace IDs.
+			for i := 0; i < 100; i++ {
+				res := sampler.ShouldSample(sdktrace.SamplingParameters{
+					ParentContext: parentWithTraceState(t, tt.state),
+					TraceID:       randomTraceID(rng),
+					Name:          "span",
+				})
+				require.Equal(t, tt.want, res.Decision)
+				require.Equal(t, tt.wantState, res.Tracestate.Get(otTraceStateKey))
+			}
+		})
+	}
+}
+
+func TestTraceStateRatioBasedInvalidRandomValue(t *testing.T) {
+	sampler := NewTraceStateRatioBased(0.5)
+	for _, rv := range []string{"8", "8000000000000000", "zzzzzzzzzzzzzz"} {
+		// The randomness of the trace ID is used instead.
+		res := sampler.ShouldSample(sdktrace.SamplingParameters{
+			ParentContext: parentWithTraceState(t, "ot=rv:"+rv),
+			TraceID:       trace.TraceID{8: 0xff, 9: 0xff},
+			Name:          "span",
+		})
+		assert.Equal(t, sdktrace.RecordAndSample, res.Decision, rv)
+	}
+}
+
+func TestTraceStateRatioBasedConsistent(t *testing.T) {
+	// A trace sampled with a ratio is sampled with all the greater ratios.
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+	ratios := []float64{0.01, 0.1, 0.25, 0.5, 0.9, 1}
+	for i := 0; i < 10000; i++ {
+		params := sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       randomTraceID(rng),
+			Name:          "span",
+		}
+		var sampled bool
+		for _, ratio := range ratios {
+			d := NewTraceStateRatioBased(ratio).ShouldSample(params).Decision
+			if sampled {
+				require.Equal(t, sdktrace.RecordAndSample, d, "ratio %g", ratio)
+			}
+			sampled = d == sdktrace.RecordAndSample
+		}
+	}
+}
+
+func TestTraceStateRatioBasedBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+	for i := 0; i < 1000; i++ {
+		params := sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       randomTraceID(rng),
+			Name:          "span",
+		}
+		assert.Equal(t, sdktrace.Drop, NewTraceStateRatioBased(0).ShouldSample(params).Decision)
+		assert.Equal(t, sdktrace.RecordAndSample, NewTraceStateRatioBased(1).ShouldSample(params).Decision)
+	}
+}
+
+func TestTraceStateRatioBasedParentBased(t *testing.T) {
+	// The descendants of a span sampled by a root using another ratio keep
+	// the threshold of the root.
+	sampler := sdktrace.ParentBased(NewTraceStateRatioBased(0.5))
+	res := sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: parentWithTraceState(t, "ot=th:c;rv:d0000000000000"),
+		TraceID:       trace.TraceID{0x01},
+		Name:          "span",
+	})
+	assert.Equal(t, sdktrace.RecordAndSample, res.Decision)
+	assert.Equal(t, "th:c;rv:d0000000000000", res.Tracestate.Get(otTraceStateKey))
+}
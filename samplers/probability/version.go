@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probability // import "go.opentelemetry.io/contrib/samplers/probability"
+
+// Version is the current release version of the probability samplers.
+func Version() string {
+	return "0.20.0"
+	// This string is updated by the pre_release.sh script during release
+}
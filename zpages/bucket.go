@@ -19,8 +19,13 @@ package zpages // import "go.opentelemetry.io/contrib/zpages"
 
 import (
 	"time"
+	"unicode/utf8"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -28,6 +33,12 @@ const (
 	defaultBucketCapacity = 10
 	// samplePeriod is the minimum time between accepting spans in a single bucket.
 	samplePeriod = time.Second
+	// maxSampledAttributeValueLength is the maximum length of the string
+	// attribute values of the spans stored in a bucket.
+	maxSampledAttributeValueLength = 256
+	// maxSampledEvents is the maximum number of events of the spans stored in
+	// a bucket.
+	maxSampledEvents = 32
 )
 
 // bucket is a container for a set of spans for latency buckets or errored spans.
@@ -54,7 +65,7 @@ func (b *bucket) add(s sdktrace.ReadOnlySpan) {
 		return
 	}
 	b.nextTime = s.EndTime().Add(samplePeriod)
-	b.buffer[b.nextIndex] = s
+	b.buffer[b.nextIndex] = newSampledSpan(s)
 	b.nextIndex++
 	if b.nextIndex == len(b.buffer) {
 		b.nextIndex = 0
@@ -74,3 +85,152 @@ func (b *bucket) len() int {
 func (b *bucket) spans() []sdktrace.ReadOnlySpan {
 	return append([]sdktrace.ReadOnlySpan(nil), b.buffer[0:b.len()]...)
 }
+
+// sampledSpan is a snapshot of a span stored in a bucket. The attributes and
+// events are bounded so that the memory retained by the samples stays
+// limited, the other fields are kept as is.
+type sampledSpan struct {
+	// ReadOnlySpan is only embedded to implement its unexported method, it is
+	// nil. All the other methods are implemented by sampledSpan: a method
+	// added to the interface by a newer SDK would panic until it is
+	// implemented, which TestSampledSpanMethods detects.
+	sdktrace.ReadOnlySpan
+
+	name                 string
+	spanContext          trace.SpanContext
+	parent               trace.SpanContext
+	spanKind             trace.SpanKind
+	startTime            time.Time
+	endTime              time.Time
+	attributes           []attribute.KeyValue
+	links                []sdktrace.Link
+	events               []sdktrace.Event
+	status               sdktrace.Status
+	instrumentationScope instrumentation.Scope
+	resource             *resource.Resource
+	droppedAttributes    int
+	droppedLinks         int
+	droppedEvents        int
+	childSpanCount       int
+}
+
+// newSampledSpan returns the snapshot of s to be stored in a bucket. The
+// events that do not fit are accounted for as dropped events.
+func newSampledSpan(s sdktrace.ReadOnlySpan) *sampledSpan {
+	events := s.Events()
+	droppedEvents := s.DroppedEvents()
+	if len(events) > maxSampledEvents {
+		droppedEvents += len(events) - maxSampledEvents
+		events = events[:maxSampledEvents]
+	}
+	sampledEvents := make([]sdktrace.Event, len(events))
+	for i, e := range events {
+		sampledEvents[i] = sdktrace.Event{
+			Name:                  e.Name,
+			Attributes:            sampledAttributes(e.Attributes),
+			DroppedAttributeCount: e.DroppedAttributeCount,
+			Time:                  e.Time,
+		}
+	}
+	links := s.Links()
+	sampledLinks := make([]sdktrace.Link, len(links))
+	for i, l := range links {
+		sampledLinks[i] = sdktrace.Link{
+			SpanContext:           l.SpanContext,
+			Attributes:            sampledAttributes(l.Attributes),
+			DroppedAttributeCount: l.DroppedAttributeCount,
+		}
+	}
+
+	return &sampledSpan{
+		name:                 s.Name(),
+		spanContext:          s.SpanContext(),
+		parent:               s.Parent(),
+		spanKind:             s.SpanKind(),
+		startTime:            s.StartTime(),
+		endTime:              s.EndTime(),
+		attributes:           sampledAttributes(s.Attributes()),
+		links:                sampledLinks,
+		events:               sampledEvents,
+		status:               s.Status(),
+		instrumentationScope: s.InstrumentationScope(),
+		resource:             s.Resource(),
+		droppedAttributes:    s.DroppedAttributes(),
+		droppedLinks:         s.DroppedLinks(),
+		droppedEvents:        droppedEvents,
+		childSpanCount:       s.ChildSpanCount(),
+	}
+}
+
+func (s *sampledSpan) Name() string                   { return s.name }
+func (s *sampledSpan) SpanContext() trace.SpanContext { return s.spanContext }
+func (s *sampledSpan) Parent() trace.SpanContext      { return s.parent }
+func (s *sampledSpan) SpanKind() trace.SpanKind       { return s.spanKind }
+func (s *sampledSpan) StartTime() time.Time           { return s.startTime }
+func (s *sampledSpan) EndTime() time.Time             { return s.endTime }
+func (s *sampledSpan) Status() sdktrace.Status        { return s.status }
+func (s *sampledSpan) Resource() *resource.Resource   { return s.resource }
+func (s *sampledSpan) DroppedAttributes() int         { return s.droppedAttributes }
+func (s *sampledSpan) DroppedLinks() int              { return s.droppedLinks }
+func (s *sampledSpan) DroppedEvents() int             { return s.droppedEvents }
+func (s *sampledSpan) ChildSpanCount() int            { return s.childSpanCount }
+
+// Attributes returns a copy of the attributes of s, the samples are read
+// concurrently.
+func (s *sampledSpan) Attributes() []attribute.KeyValue {
+	return append([]attribute.KeyValue(nil), s.attributes...)
+}
+
+// Links returns a copy of the links of s.
+func (s *sampledSpan) Links() []sdktrace.Link {
+	return append([]sdktrace.Link(nil), s.links...)
+}
+
+// Events returns a copy of the events of s, which the handlers sort.
+func (s *sampledSpan) Events() []sdktrace.Event {
+	return append([]sdktrace.Event(nil), s.events...)
+}
+
+func (s *sampledSpan) InstrumentationScope() instrumentation.Scope {
+	return s.instrumentationScope
+}
+
+func (s *sampledSpan) InstrumentationLibrary() instrumentation.Library {
+	return s.instrumentationScope
+}
+
+// sampledAttributes returns a copy of attrs with the string values truncated
+// to maxSampledAttributeValueLength.
+func sampledAttributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make([]attribute.KeyValue, len(attrs))
+	for i, kv := range attrs {
+		switch kv.Value.Type() {
+		case attribute.STRING:
+			kv.Value = attribute.StringValue(truncate(kv.Value.AsString()))
+		case attribute.STRINGSLICE:
+			v := kv.Value.AsStringSlice()
+			for j := range v {
+				v[j] = truncate(v[j])
+			}
+			kv.Value = attribute.StringSliceValue(v)
+		}
+		out[i] = kv
+	}
+	return out
+}
+
+// truncate returns s truncated to at most maxSampledAttributeValueLength
+// bytes without splitting a UTF-8 encoded rune.
+func truncate(s string) string {
+	if len(s) <= maxSampledAttributeValueLength {
+		return s
+	}
+	n := maxSampledAttributeValueLength
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
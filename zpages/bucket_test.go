@@ -4,11 +4,17 @@
 package zpages
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -20,12 +26,61 @@ type testSpan struct {
 	startTime   time.Time
 	endTime     time.Time
 	status      sdktrace.Status
+	attributes  []attribute.KeyValue
+	events      []sdktrace.Event
+	links       []sdktrace.Link
+	scope       instrumentation.Scope
+	resource    *resource.Resource
 }
 
 func (ts *testSpan) SpanContext() trace.SpanContext {
 	return ts.spanContext
 }
 
+func (ts *testSpan) Parent() trace.SpanContext {
+	return trace.SpanContext{}
+}
+
+func (ts *testSpan) SpanKind() trace.SpanKind {
+	return trace.SpanKindInternal
+}
+
+func (ts *testSpan) Attributes() []attribute.KeyValue {
+	return ts.attributes
+}
+
+func (ts *testSpan) Events() []sdktrace.Event {
+	return ts.events
+}
+
+func (ts *testSpan) Links() []sdktrace.Link {
+	return ts.links
+}
+
+func (ts *testSpan) InstrumentationScope() instrumentation.Scope {
+	return ts.scope
+}
+
+func (ts *testSpan) Resource() *resource.Resource {
+	return ts.resource
+}
+
+func (ts *testSpan) DroppedLinks() int {
+	return 0
+}
+
+func (ts *testSpan) ChildSpanCount() int {
+	return 0
+}
+
+func (ts *testSpan) DroppedAttributes() int {
+	return 0
+}
+
+func (ts *testSpan) DroppedEvents() int {
+	return 0
+}
+
 func (ts *testSpan) Status() sdktrace.Status {
 	return ts.status
 }
@@ -91,3 +146,109 @@ func TestBucketZeroCapacity(t *testing.T) {
 	assert.Equal(t, 0, bkt.len())
 	assert.Len(t, bkt.spans(), 0)
 }
+
+func TestBucketSampledSpan(t *testing.T) {
+	long := strings.Repeat("a", maxSampledAttributeValueLength+10)
+	events := make([]sdktrace.Event, maxSampledEvents+5)
+	for i := range events {
+		events[i] = sdktrace.Event{
+			Name:       "event",
+			Time:       time.Unix(1, int64(i)),
+			Attributes: []attribute.KeyValue{attribute.String("message", long)},
+		}
+	}
+
+	bkt := newBucket(defaultBucketCapacity)
+	bkt.add(&testSpan{
+		endTime: time.Unix(1, 0),
+		attributes: []attribute.KeyValue{
+			attribute.String("short", "value"),
+			attribute.String("long", long),
+			attribute.StringSlice("slice", []string{long}),
+			attribute.Int("int", 1),
+		},
+		events: events,
+	})
+	spans := bkt.spans()
+	require.Len(t, spans, 1)
+	s := spans[0]
+
+	truncated := long[:maxSampledAttributeValueLength]
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("short", "value"),
+		attribute.String("long", truncated),
+		attribute.StringSlice("slice", []string{truncated}),
+		attribute.Int("int", 1),
+	}, s.Attributes())
+
+	require.Len(t, s.Events(), maxSampledEvents)
+	assert.Equal(t, 5, s.DroppedEvents())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("message", truncated)}, s.Events()[0].Attributes)
+	// The attributes of the original span are not modified.
+	assert.Equal(t, long, events[0].Attributes[0].Value.AsString())
+}
+
+func TestBucketSampledSpanFields(t *testing.T) {
+	long := strings.Repeat("a", maxSampledAttributeValueLength+10)
+	link := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	})
+	scope := instrumentation.Scope{Name: "scope", Version: "v1"}
+	res := resource.NewSchemaless(attribute.String("service.name", "svc"))
+
+	bkt := newBucket(defaultBucketCapacity)
+	bkt.add(&testSpan{
+		endTime: time.Unix(1, 0),
+		links: []sdktrace.Link{{
+			SpanContext: link,
+			Attributes:  []attribute.KeyValue{attribute.String("long", long)},
+		}},
+		scope:    scope,
+		resource: res,
+	})
+	spans := bkt.spans()
+	require.Len(t, spans, 1)
+	s := spans[0]
+
+	assert.Equal(t, []sdktrace.Link{{
+		SpanContext: link,
+		Attributes:  []attribute.KeyValue{attribute.String("long", long[:maxSampledAttributeValueLength])},
+	}}, s.Links())
+	assert.Equal(t, scope, s.InstrumentationScope())
+	assert.Equal(t, scope, s.InstrumentationLibrary())
+	assert.Same(t, res, s.Resource())
+}
+
+func TestSampledSpanEventsCopy(t *testing.T) {
+	s := newSampledSpan(&testSpan{
+		events: []sdktrace.Event{{Name: "b"}, {Name: "a"}},
+	})
+
+	// Sorting the events of a request does not modify the sample.
+	events := s.Events()
+	events[0], events[1] = events[1], events[0]
+	assert.Equal(t, []sdktrace.Event{{Name: "b"}, {Name: "a"}}, s.Events())
+}
+
+func TestSampledSpanMethods(t *testing.T) {
+	// The embedded ReadOnlySpan is nil, all the exported methods of the
+	// interface must be implemented by sampledSpan.
+	s := reflect.ValueOf(sdktrace.ReadOnlySpan(newSampledSpan(&testSpan{})))
+	typ := reflect.TypeOf((*sdktrace.ReadOnlySpan)(nil)).Elem()
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		if !m.IsExported() {
+			continue
+		}
+		assert.NotPanics(t, func() { s.MethodByName(m.Name).Call(nil) }, m.Name)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short"))
+
+	// A multi-byte rune straddling the limit is not split.
+	s := strings.Repeat("a", maxSampledAttributeValueLength-1) + "é"
+	assert.Equal(t, strings.Repeat("a", maxSampledAttributeValueLength-1), truncate(s))
+}
@@ -18,6 +18,7 @@
 package zpages // import "go.opentelemetry.io/contrib/zpages"
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -39,6 +40,9 @@ const (
 	// spanLatencyBucketQueryField is the header for latency based samples.
 	// Default is [0, 8] representing the latency buckets, where 0 is the first one.
 	spanLatencyBucketQueryField = "zlatencybucket"
+	// spanFormatQueryField is the header for the output format. When set to
+	// "json", the spans of the requested name and type are output as JSON.
+	spanFormatQueryField = "zformat"
 	// maxTraceMessageLength is the maximum length of a message in tracez output.
 	maxTraceMessageLength = 1024
 )
@@ -72,6 +76,9 @@ type tracezHandler struct {
 }
 
 // NewTracezHandler returns an http.Handler that can be used to serve HTTP requests for trace zpages.
+//
+// The spans of a name and type are output as JSON, instead of HTML, when the
+// request has the "zformat=json" query parameter.
 func NewTracezHandler(sp *SpanProcessor) http.Handler {
 	return &tracezHandler{sp: sp}
 }
@@ -87,6 +94,14 @@ func (th *tracezHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	spanType, _ := strconv.Atoi(r.Form.Get(spanTypeQueryField))
 	spanSubtype, _ := strconv.Atoi(r.Form.Get(spanLatencyBucketQueryField))
 
+	if r.Form.Get(spanFormatQueryField) == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(th.getTraceJSONData(spanName, spanType, spanSubtype)); err != nil {
+			log.Printf("zpages: encoding json: %v", err)
+		}
+		return
+	}
+
 	if err := headerTemplate.Execute(w, headerData{Title: "Trace Spans"}); err != nil {
 		log.Printf("zpages: executing template: %v", err)
 	}
@@ -103,17 +118,20 @@ func (th *tracezHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (th *tracezHandler) getTraceTableData(spanName string, spanType, latencyBucket int) traceTableData {
-	var spans []sdktrace.ReadOnlySpan
+func (th *tracezHandler) spans(spanName string, spanType, latencyBucket int) []sdktrace.ReadOnlySpan {
 	switch spanType {
 	case 0: // active
-		spans = th.sp.activeSpans(spanName)
+		return th.sp.activeSpans(spanName)
 	case 1: // latency
-		spans = th.sp.spansByLatency(spanName, latencyBucket)
+		return th.sp.spansByLatency(spanName, latencyBucket)
 	case 2: // error
-		spans = th.sp.errorSpans(spanName)
+		return th.sp.errorSpans(spanName)
 	}
+	return nil
+}
 
+func (th *tracezHandler) getTraceTableData(spanName string, spanType, latencyBucket int) traceTableData {
+	spans := th.spans(spanName, spanType, latencyBucket)
 	data := traceTableData{
 		Name: spanName,
 		Num:  len(spans),
@@ -148,6 +166,82 @@ func (th *tracezHandler) getSummaryTableData() summaryTableData {
 	return data
 }
 
+// spanJSON is the JSON representation of a span.
+type spanJSON struct {
+	Name              string                 `json:"name"`
+	TraceID           string                 `json:"trace_id"`
+	SpanID            string                 `json:"span_id"`
+	ParentSpanID      string                 `json:"parent_span_id,omitempty"`
+	Sampled           bool                   `json:"sampled"`
+	StartTime         time.Time              `json:"start_time"`
+	EndTime           *time.Time             `json:"end_time,omitempty"`
+	StatusCode        string                 `json:"status_code"`
+	StatusDescription string                 `json:"status_description,omitempty"`
+	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+	DroppedAttributes int                    `json:"dropped_attributes,omitempty"`
+	Events            []eventJSON            `json:"events,omitempty"`
+	DroppedEvents     int                    `json:"dropped_events,omitempty"`
+}
+
+// eventJSON is the JSON representation of a span event.
+type eventJSON struct {
+	Name       string                 `json:"name"`
+	Time       time.Time              `json:"time"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+func (th *tracezHandler) getTraceJSONData(spanName string, spanType, latencyBucket int) []spanJSON {
+	spans := th.spans(spanName, spanType, latencyBucket)
+	data := make([]spanJSON, 0, len(spans))
+	for _, s := range spans {
+		data = append(data, newSpanJSON(s))
+	}
+	return data
+}
+
+func newSpanJSON(s sdktrace.ReadOnlySpan) spanJSON {
+	sc := s.SpanContext()
+	out := spanJSON{
+		Name:              s.Name(),
+		TraceID:           sc.TraceID().String(),
+		SpanID:            sc.SpanID().String(),
+		Sampled:           sc.IsSampled(),
+		StartTime:         s.StartTime(),
+		StatusCode:        s.Status().Code.String(),
+		StatusDescription: s.Status().Description,
+		Attributes:        attributesJSON(s.Attributes()),
+		DroppedAttributes: s.DroppedAttributes(),
+		DroppedEvents:     s.DroppedEvents(),
+	}
+	if p := s.Parent(); p.IsValid() {
+		out.ParentSpanID = p.SpanID().String()
+	}
+	if end := s.EndTime(); !end.IsZero() {
+		out.EndTime = &end
+	}
+	es := events(s.Events())
+	sort.Sort(es)
+	for _, e := range es {
+		out.Events = append(out.Events, eventJSON{
+			Name:       e.Name,
+			Time:       e.Time,
+			Attributes: attributesJSON(e.Attributes),
+		})
+	}
+	return out
+}
+
+func attributesJSON(attrs []attribute.KeyValue) map[string]interface{} {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make(map[string]interface{}, len(attrs))
+	for _, kv := range attrs {
+		out[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return out
+}
+
 type spanRow struct {
 	Fields [3]string
 	trace.SpanContext
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zpages
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newSampledErrorSpan(t *testing.T) (*SpanProcessor, trace.SpanContext) {
+	t.Helper()
+
+	zsp := NewSpanProcessor()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSpanProcessor(zsp),
+	)
+	_, span := tp.Tracer("test").Start(context.Background(), "sampled")
+	span.SetAttributes(attribute.String("user.id", "user-42"))
+	span.AddEvent("cache.miss", trace.WithAttributes(attribute.String("cache.key", "key-7")))
+	span.SetStatus(codes.Error, "failed")
+	span.End()
+	return zsp, span.SpanContext()
+}
+
+func TestTracezHandlerSampleDetails(t *testing.T) {
+	zsp, _ := newSampledErrorSpan(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/tracez?zspanname=sampled&ztype=2", nil)
+	NewTracezHandler(zsp).ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, "user.id=user-42")
+	assert.Contains(t, body, "cache.miss")
+	assert.Contains(t, body, "cache.key=key-7")
+}
+
+func TestTracezHandlerJSON(t *testing.T) {
+	zsp, sc := newSampledErrorSpan(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/tracez?zspanname=sampled&ztype=2&zformat=json", nil)
+	NewTracezHandler(zsp).ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var got []spanJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "sampled", got[0].Name)
+	assert.Equal(t, sc.TraceID().String(), got[0].TraceID)
+	assert.Equal(t, sc.SpanID().String(), got[0].SpanID)
+	assert.Equal(t, "Error", got[0].StatusCode)
+	assert.Equal(t, "failed", got[0].StatusDescription)
+	assert.Equal(t, map[string]interface{}{"user.id": "user-42"}, got[0].Attributes)
+	require.Len(t, got[0].Events, 1)
+	assert.Equal(t, "cache.miss", got[0].Events[0].Name)
+	assert.Equal(t, map[string]interface{}{"cache.key": "key-7"}, got[0].Events[0].Attributes)
+}